@@ -1,108 +1,244 @@
-package main
-
-import (
-	"fmt"
-	"log"
-	"net"
-	"net/http"
-	"os"
-	"strconv"
-	"strings"
-	"time"
-
-	"github.com/sschwartz96/stockpile/db"
-	"github.com/sschwartz96/syncapod/internal/config"
-	"github.com/sschwartz96/syncapod/internal/database"
-	sGRPC "github.com/sschwartz96/syncapod/internal/grpc"
-	"github.com/sschwartz96/syncapod/internal/handler"
-	"github.com/sschwartz96/syncapod/internal/podcast"
-	"github.com/sschwartz96/syncapod/internal/services"
-)
-
-func main() {
-	// read config
-	cfg, err := readConfig("config.json")
-	if err != nil {
-		log.Fatal("Main() error, could not read config: ", err)
-	}
-
-	log.Println("Running syncapod version: ", cfg.Version)
-
-	// connect to db
-	log.Println("connecting to db")
-	dbClient, err := database.NewMongoClient(cfg)
-	if err != nil {
-		log.Fatal("couldn't connect to db: ", err)
-	}
-
-	// setup & start gRPC server
-	grpcServer := sGRPC.NewServer(cfg, dbClient,
-		services.NewAuthService(dbClient),
-		services.NewPodcastService(dbClient),
-	)
-	go func() {
-		// setup listener
-		grpcListener, err := net.Listen("tcp", ":"+strconv.Itoa(cfg.GRPCPort))
-		if err != nil {
-			log.Fatalf("could not listen on port %d, err: %v", cfg.GRPCPort, err)
-		}
-		// start server
-		err = grpcServer.Start(grpcListener)
-		if err != nil {
-			log.Fatal(err)
-		}
-	}()
-
-	// start updating podcasts
-	go updatePodcasts(dbClient)
-
-	log.Println("setting up handlers")
-	// setup handler
-	handler, err := handler.CreateHandler(dbClient, cfg)
-	if err != nil {
-		log.Fatal("could not setup handlers: ", err)
-	}
-
-	// start server
-	log.Println("starting server")
-	port := strings.TrimSpace(strconv.Itoa(cfg.Port))
-	if cfg.Port == 443 {
-		// setup redirect server
-		go func() {
-			if err = http.ListenAndServe(":80", http.HandlerFunc(redirect)); err != nil {
-				log.Fatalf("redirect server failed %v\n", err)
-			}
-		}()
-
-		err = http.ListenAndServeTLS(":"+port, cfg.CertFile, cfg.KeyFile, handler)
-	} else {
-		err = http.ListenAndServe(":"+port, handler)
-	}
-
-	if err != nil {
-		log.Fatal("couldn't not start server:", err)
-	}
-
-}
-
-func updatePodcasts(dbClient db.Database) {
-	for {
-		err := podcast.UpdatePodcasts(dbClient)
-		if err != nil {
-			log.Println("main/updatePodcasts() error:", err)
-		}
-		time.Sleep(time.Minute * 15)
-	}
-}
-
-func redirect(res http.ResponseWriter, req *http.Request) {
-	http.Redirect(res, req, "https://syncapod.com"+req.RequestURI, http.StatusMovedPermanently)
-}
-
-func readConfig(path string) (*config.Config, error) {
-	cfgFile, err := os.Open(path)
-	if err != nil {
-		return nil, fmt.Errorf("readConfig() error opening file: %v", err)
-	}
-	return config.ReadConfig(cfgFile)
-}
+package main
+
+import (
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/archive"
+	"github.com/sschwartz96/syncapod/internal/auth"
+	"github.com/sschwartz96/syncapod/internal/config"
+	"github.com/sschwartz96/syncapod/internal/database"
+	sGRPC "github.com/sschwartz96/syncapod/internal/grpc"
+	"github.com/sschwartz96/syncapod/internal/handler"
+	"github.com/sschwartz96/syncapod/internal/mail"
+	"github.com/sschwartz96/syncapod/internal/podcast"
+	"github.com/sschwartz96/syncapod/internal/services"
+	"github.com/sschwartz96/syncapod/internal/user"
+)
+
+func main() {
+	mergeEpisodes := flag.Bool("merge-duplicate-episodes", false, "one-off migration merging duplicate episodes, exits once done")
+	mergeUserEpisodes := flag.Bool("merge-duplicate-user-episodes", false, "one-off migration merging the duplicate user episodes of the same user & episode, exits once done")
+	normalizeEmails := flag.Bool("normalize-user-emails", false, "one-off migration lowercasing user emails and clearing those of users sharing one, exits once done")
+	backfill := flag.String("backfill", "", "rss url of a podcast, or \"all\", to import the back catalog of from its paged & archived feeds, exits once done")
+	flag.Parse()
+
+	// read config
+	cfg, err := readConfig("config.json")
+	if err != nil {
+		log.Fatal("Main() error, could not read config: ", err)
+	}
+
+	log.Println("Running syncapod version: ", cfg.Version)
+
+	// connect to db
+	log.Println("connecting to db")
+	dbClient, err := database.NewMongoClient(cfg)
+	if err != nil {
+		log.Fatal("couldn't connect to db: ", err)
+	}
+
+	if *mergeEpisodes {
+		merged, err := podcast.MergeDuplicateEpisodes(dbClient)
+		if err != nil {
+			log.Fatal("couldn't merge duplicate episodes: ", err)
+		}
+		log.Printf("merged %d duplicate episodes\n", merged)
+		return
+	}
+
+	if *mergeUserEpisodes {
+		merged, err := podcast.MergeDuplicateUserEpisodes(dbClient)
+		if err != nil {
+			log.Fatal("couldn't merge duplicate user episodes: ", err)
+		}
+		log.Printf("merged %d duplicate user episodes\n", merged)
+		return
+	}
+
+	if *normalizeEmails {
+		cleared, err := user.NormalizeEmails(dbClient)
+		if err != nil {
+			log.Fatal("couldn't normalize user emails: ", err)
+		}
+		log.Printf("cleared %d duplicate user emails\n", cleared)
+		return
+	}
+
+	// after the migrations, which remove the duplicates violating them
+	err = database.CreateIndexes(dbClient)
+	if err != nil {
+		log.Fatal("couldn't create indexes, run the migrations removing duplicates first: ", err)
+	}
+
+	if *backfill != "" {
+		added, err := runBackfill(dbClient, *backfill)
+		if err != nil {
+			log.Fatal("couldn't backfill podcasts: ", err)
+		}
+		log.Printf("backfilled %d episodes\n", added)
+		return
+	}
+
+	// private feeds are only enabled with a key to encrypt their credentials
+	creds, err := readCredentials(dbClient, cfg.CredentialKey)
+	if err != nil {
+		log.Fatal("couldn't setup private feed credentials: ", err)
+	}
+
+	// account emails are logged if no smtp server is configured
+	mailer, err := readMailer(&cfg.Mail)
+	if err != nil {
+		log.Fatal("couldn't setup mailer: ", err)
+	}
+	accounts := auth.NewAccounts(dbClient, mailer, cfg.BaseURL)
+
+	// setup & start gRPC server
+	grpcServer := sGRPC.NewServer(cfg, dbClient,
+		services.NewAuthService(dbClient, accounts),
+		services.NewPodcastService(dbClient, creds),
+	)
+	go func() {
+		// setup listener
+		grpcListener, err := net.Listen("tcp", ":"+strconv.Itoa(cfg.GRPCPort))
+		if err != nil {
+			log.Fatalf("could not listen on port %d, err: %v", cfg.GRPCPort, err)
+		}
+		// start server
+		err = grpcServer.Start(grpcListener)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	// start updating podcasts
+	scheduler := podcast.NewScheduler(dbClient, creds, 10)
+	go scheduler.Start()
+
+	// extract the chapters embedded in new episodes
+	chapters := podcast.NewChapterExtractor(dbClient)
+	go chapters.Start()
+
+	// fingerprint new episodes for their dynamically inserted ads
+	adDetector := podcast.NewAdDetector(dbClient)
+	go adDetector.Start()
+
+	// generate the waveforms & silence maps of new episodes
+	waveforms := podcast.NewWaveformGenerator(dbClient)
+	go waveforms.Start()
+
+	// subscribe to the feeds that push their updates
+	webSub := podcast.NewWebSub(dbClient, cfg.BaseURL)
+	go webSub.Start()
+
+	// archive the enclosures of subscribed podcasts
+	archiveStore, err := readArchiveStore(&cfg.Archive)
+	if err != nil {
+		log.Fatal("couldn't setup archive store: ", err)
+	}
+	if archiveStore != nil {
+		archiver := archive.NewArchiver(dbClient, archiveStore, cfg.Archive.KeepLatest, cfg.Archive.MaxAgeDays)
+		go archiver.Start()
+	}
+
+	log.Println("setting up handlers")
+	// setup handler
+	handler, err := handler.CreateHandler(dbClient, cfg, webSub, archiveStore, accounts)
+	if err != nil {
+		log.Fatal("could not setup handlers: ", err)
+	}
+
+	// start server
+	log.Println("starting server")
+	port := strings.TrimSpace(strconv.Itoa(cfg.Port))
+	if cfg.Port == 443 {
+		// setup redirect server
+		go func() {
+			if err = http.ListenAndServe(":80", http.HandlerFunc(redirect)); err != nil {
+				log.Fatalf("redirect server failed %v\n", err)
+			}
+		}()
+
+		err = http.ListenAndServeTLS(":"+port, cfg.CertFile, cfg.KeyFile, handler)
+	} else {
+		err = http.ListenAndServe(":"+port, handler)
+	}
+
+	if err != nil {
+		log.Fatal("couldn't not start server:", err)
+	}
+
+}
+
+func redirect(res http.ResponseWriter, req *http.Request) {
+	http.Redirect(res, req, "https://syncapod.com"+req.RequestURI, http.StatusMovedPermanently)
+}
+
+// runBackfill imports the back catalog of the podcast with the rss url, or every podcast if "all"
+func runBackfill(dbClient db.Database, rssURL string) (int, error) {
+	if rssURL == "all" {
+		return podcast.BackfillPodcasts(dbClient)
+	}
+	pod, err := podcast.FindPodcastByFeed(dbClient, rssURL)
+	if err != nil {
+		return 0, err
+	}
+	return podcast.BackfillPodcast(dbClient, pod)
+}
+
+// readCredentials creates the credential store of private feeds from the base64 encoded key,
+// nil if no key is configured
+func readCredentials(dbClient db.Database, key string) (*podcast.Credentials, error) {
+	if key == "" {
+		log.Println("no credential key configured, private feeds are disabled")
+		return nil, nil
+	}
+	rawKey, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("readCredentials() error decoding key: %v", err)
+	}
+	return podcast.NewCredentials(dbClient, rawKey)
+}
+
+// readArchiveStore creates the blob store archived enclosures are kept in, an S3 bucket if an endpoint is
+// configured otherwise a local directory, nil if neither is configured
+func readArchiveStore(cfg *config.Archive) (archive.Store, error) {
+	if cfg.S3Endpoint != "" {
+		return archive.NewS3Store(cfg.S3Endpoint, cfg.S3Region, cfg.S3Bucket, cfg.S3AccessKey, cfg.S3SecretKey)
+	}
+	if cfg.Dir != "" {
+		return archive.NewLocalStore(cfg.Dir)
+	}
+	log.Println("no archive store configured, episodes are not archived")
+	return nil, nil
+}
+
+// readMailer creates the mailer sending account emails through the configured smtp server,
+// or logging them if no server is configured
+func readMailer(cfg *config.Mail) (mail.Mailer, error) {
+	if cfg.Host == "" {
+		log.Println("no smtp server configured, account emails are logged instead")
+		return mail.LogMailer{}, nil
+	}
+	port := cfg.Port
+	if port == 0 {
+		port = 587
+	}
+	return mail.NewSMTPMailer(cfg.Host, port, cfg.Username, cfg.Password, cfg.From)
+}
+
+func readConfig(path string) (*config.Config, error) {
+	cfgFile, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("readConfig() error opening file: %v", err)
+	}
+	return config.ReadConfig(cfgFile)
+}
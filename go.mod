@@ -8,6 +8,7 @@ replace github.com/sschwartz96/stockpile => C:/users/sam/go/src/github.com/sschw
 
 require (
 	github.com/golang/protobuf v1.4.3
+	github.com/hajimehoshi/go-mp3 v0.3.1
 	github.com/sschwartz96/stockpile v0.2.5
 	github.com/tcolgate/mp3 v0.0.0-20170426193717-e79c5a46d300
 	go.mongodb.org/mongo-driver v1.4.2
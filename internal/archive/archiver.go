@@ -0,0 +1,293 @@
+package archive
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/podcast"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+const (
+	// how often the subscribed podcasts are archived
+	archiveInterval = time.Hour
+	// maxEnclosureSize is the largest enclosure archived
+	maxEnclosureSize = 2 << 30
+)
+
+var downloadClient = &http.Client{Timeout: time.Hour}
+
+// FindArchivedEpisode finds the archived copy of the episode via episode id
+func FindArchivedEpisode(dbClient db.Database, epiID *protos.ObjectID) (*models.ArchivedEpisode, error) {
+	archived := &models.ArchivedEpisode{}
+	err := dbClient.FindOne(database.ColArchive, archived, &db.Filter{"episode_id": epiID}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("FindArchivedEpisode() error: %v", err)
+	}
+	return archived, nil
+}
+
+// FindRetention finds the podcast's own retention via podcast id
+func FindRetention(dbClient db.Database, podID *protos.ObjectID) (*models.Retention, error) {
+	retention := &models.Retention{}
+	err := dbClient.FindOne(database.ColRetention, retention, &db.Filter{"podcast_id": podID}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("FindRetention() error: %v", err)
+	}
+	return retention, nil
+}
+
+// Archiver keeps copies of the enclosures of subscribed podcasts in a blob store,
+// in case their publisher removes them or their host goes down
+type Archiver struct {
+	dbClient db.Database
+	store    Store
+	// retention of podcasts without their own
+	retention models.Retention
+	stop      chan struct{}
+}
+
+// NewArchiver creates an archiver storing enclosures in store, keeping the latest episodes published
+// within maxAgeDays of each podcast without its own retention
+func NewArchiver(dbClient db.Database, store Store, keepLatest, maxAgeDays int) *Archiver {
+	return &Archiver{
+		dbClient:  dbClient,
+		store:     store,
+		retention: models.Retention{KeepLatest: keepLatest, MaxAgeDays: maxAgeDays},
+		stop:      make(chan struct{}),
+	}
+}
+
+// Start archives the subscribed podcasts every archiveInterval, blocks until Stop is called
+func (a *Archiver) Start() {
+	a.archiveSubscribed()
+	ticker := time.NewTicker(archiveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			a.archiveSubscribed()
+		case <-a.stop:
+			return
+		}
+	}
+}
+
+// Stop stops the archiver, the enclosure being archived is finished
+func (a *Archiver) Stop() {
+	close(a.stop)
+}
+
+// archiveSubscribed archives every podcast with a subscriber
+func (a *Archiver) archiveSubscribed() {
+	var subs []*protos.Subscription
+	err := a.dbClient.FindAll(database.ColSubscription, &subs, nil, nil)
+	if err != nil {
+		log.Println("Archiver.archiveSubscribed() error finding subscriptions:", err)
+		return
+	}
+	archived := map[string]bool{}
+	for _, sub := range subs {
+		if sub.PodcastID == nil || archived[sub.PodcastID.GetHex()] {
+			continue
+		}
+		archived[sub.PodcastID.GetHex()] = true
+		err = a.archivePodcast(sub.PodcastID)
+		if err != nil {
+			log.Printf("Archiver.archiveSubscribed() error archiving podcast %v: %v\n", sub.PodcastID.GetHex(), err)
+		}
+		select {
+		case <-a.stop:
+			return
+		default:
+		}
+	}
+}
+
+// archivePodcast archives the episodes the podcast's retention keeps and deletes the ones it no longer does
+func (a *Archiver) archivePodcast(podID *protos.ObjectID) error {
+	retention := &a.retention
+	if r, err := FindRetention(a.dbClient, podID); err == nil {
+		retention = r
+	}
+
+	var keep []*protos.Episode
+	if retention.KeepLatest > 0 {
+		episodes, err := podcast.FindEpisodesByRange(a.dbClient, podID, 0, int64(retention.KeepLatest))
+		if err != nil {
+			return fmt.Errorf("archivePodcast() error: %v", err)
+		}
+		for _, epi := range episodes {
+			if retained(retention, epi, time.Now()) {
+				keep = append(keep, epi)
+			}
+		}
+	}
+
+	kept := map[string]bool{}
+	for _, epi := range keep {
+		kept[epi.Id.GetHex()] = true
+		if existing, err := FindArchivedEpisode(a.dbClient, epi.Id); err == nil && existing.URL == epi.MP3URL {
+			continue
+		}
+		err := a.archiveEpisode(epi)
+		if err != nil {
+			log.Printf("Archiver.archivePodcast() error archiving episode %v: %v\n", epi.Id.GetHex(), err)
+		}
+	}
+
+	var archived []*models.ArchivedEpisode
+	err := a.dbClient.FindAll(database.ColArchive, &archived, &db.Filter{"podcast_id": podID}, nil)
+	if err != nil {
+		// nothing has been archived yet
+		return nil
+	}
+	for _, arc := range archived {
+		if kept[arc.EpisodeID.GetHex()] {
+			continue
+		}
+		err = a.deleteArchived(arc)
+		if err != nil {
+			return fmt.Errorf("archivePodcast() error: %v", err)
+		}
+	}
+	return nil
+}
+
+// retained checks if the episode is recent enough to be kept by the retention
+func retained(retention *models.Retention, epi *protos.Episode, now time.Time) bool {
+	if epi.MP3URL == "" {
+		return false
+	}
+	if retention.MaxAgeDays <= 0 {
+		return true
+	}
+	pubDate, err := ptypes.Timestamp(epi.PubDate)
+	if err != nil {
+		return false
+	}
+	return now.Sub(pubDate) <= time.Duration(retention.MaxAgeDays)*24*time.Hour
+}
+
+// archiveEpisode downloads the episode's enclosure, stores it and verifies the stored copy's checksum
+func (a *Archiver) archiveEpisode(epi *protos.Episode) error {
+	tmp, err := ioutil.TempFile("", "syncapod-archive-")
+	if err != nil {
+		return fmt.Errorf("archiveEpisode() error creating file: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	size, sum, mimeType, err := download(epi.MP3URL, tmp)
+	if err != nil {
+		return fmt.Errorf("archiveEpisode() error: %v", err)
+	}
+	if epi.EnclosureType != "" {
+		mimeType = epi.EnclosureType
+	}
+	_, err = tmp.Seek(0, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("archiveEpisode() error seeking file: %v", err)
+	}
+
+	key := epi.PodcastID.GetHex() + "/" + epi.Id.GetHex()
+	err = a.store.Put(key, tmp, size)
+	if err != nil {
+		return fmt.Errorf("archiveEpisode() error storing enclosure: %v", err)
+	}
+	err = verify(a.store, key, sum)
+	if err != nil {
+		if deleteErr := a.store.Delete(key); deleteErr != nil {
+			log.Println("archiveEpisode() error deleting corrupt blob:", deleteErr)
+		}
+		return fmt.Errorf("archiveEpisode() error: %v", err)
+	}
+
+	archived := &models.ArchivedEpisode{
+		EpisodeID:  epi.Id,
+		PodcastID:  epi.PodcastID,
+		Key:        key,
+		URL:        epi.MP3URL,
+		Type:       mimeType,
+		Size:       size,
+		SHA256:     sum,
+		ArchivedAt: time.Now(),
+	}
+	err = a.dbClient.Upsert(database.ColArchive, archived, &db.Filter{"episode_id": epi.Id})
+	if err != nil {
+		return fmt.Errorf("archiveEpisode() error upserting archived episode: %v", err)
+	}
+	return nil
+}
+
+// deleteArchived deletes the archived copy from the store & database
+func (a *Archiver) deleteArchived(archived *models.ArchivedEpisode) error {
+	err := a.store.Delete(archived.Key)
+	if err != nil {
+		return fmt.Errorf("deleteArchived() error: %v", err)
+	}
+	err = a.dbClient.Delete(database.ColArchive, &db.Filter{"episode_id": archived.EpisodeID})
+	if err != nil {
+		return fmt.Errorf("deleteArchived() error: %v", err)
+	}
+	return nil
+}
+
+// download writes the file at url to w, returns its size, hex encoded SHA-256 checksum and mime type.
+// A body shorter than the Content-Length the server sent is an error
+func download(url string, w io.Writer) (int64, string, string, error) {
+	resp, err := downloadClient.Get(url)
+	if err != nil {
+		return 0, "", "", fmt.Errorf("download() error: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, "", "", fmt.Errorf("download() server responded: %s", resp.Status)
+	}
+	if resp.ContentLength > maxEnclosureSize {
+		return 0, "", "", fmt.Errorf("download() error: enclosure of %d bytes is too large", resp.ContentLength)
+	}
+
+	hash := sha256.New()
+	n, err := io.Copy(io.MultiWriter(w, hash), io.LimitReader(resp.Body, maxEnclosureSize+1))
+	if err != nil {
+		return 0, "", "", fmt.Errorf("download() error reading body: %v", err)
+	}
+	if n > maxEnclosureSize {
+		return 0, "", "", errors.New("download() error: enclosure is too large")
+	}
+	if resp.ContentLength >= 0 && n != resp.ContentLength {
+		return 0, "", "", fmt.Errorf("download() error: read %d of %d bytes", n, resp.ContentLength)
+	}
+	return n, hex.EncodeToString(hash.Sum(nil)), resp.Header.Get("Content-Type"), nil
+}
+
+// verify checks the SHA-256 checksum of the stored blob
+func verify(store Store, key, sum string) error {
+	blob, err := store.Open(key)
+	if err != nil {
+		return fmt.Errorf("verify() error opening blob: %v", err)
+	}
+	defer blob.Close()
+	hash := sha256.New()
+	_, err = io.Copy(hash, blob)
+	if err != nil {
+		return fmt.Errorf("verify() error reading blob: %v", err)
+	}
+	if got := hex.EncodeToString(hash.Sum(nil)); got != sum {
+		return fmt.Errorf("verify() error: stored checksum %s, expected %s", got, sum)
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package archive
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/stockpile/mock"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// corruptStore flips the first byte of every blob put in the store
+type corruptStore struct {
+	Store
+}
+
+func (c corruptStore) Put(key string, r io.Reader, size int64) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	data[0] ^= 0xff
+	return c.Store.Put(key, bytes.NewReader(data), size)
+}
+
+func insertOrFail(t *testing.T, mockDB db.Database, collection string, object interface{}) {
+	err := mockDB.Insert(collection, object)
+	if err != nil {
+		t.Fatalf("insertOrFail() error inserting: %v", err)
+	}
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestArchiver(t *testing.T) {
+	enclosures := map[string][]byte{
+		"/1.mp3": bytes.Repeat([]byte("episode 1 "), 1000),
+		"/2.mp3": bytes.Repeat([]byte("episode 2 "), 2000),
+		"/3.mp3": bytes.Repeat([]byte("episode 3 "), 3000),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		enclosure, ok := enclosures[req.URL.Path]
+		if !ok {
+			http.NotFound(res, req)
+			return
+		}
+		res.Header().Set("Content-Type", "audio/mpeg")
+		res.Write(enclosure)
+	}))
+	defer server.Close()
+
+	mockDB := mock.CreateDB()
+	podID := protos.NewObjectID()
+	now := time.Now()
+	episodes := []*protos.Episode{}
+	// newest first
+	for i, path := range []string{"/1.mp3", "/2.mp3", "/3.mp3"} {
+		pubDate, _ := ptypes.TimestampProto(now.Add(-time.Duration(i) * 48 * time.Hour))
+		epi := &protos.Episode{Id: protos.NewObjectID(), PodcastID: podID, MP3URL: server.URL + path, PubDate: pubDate}
+		insertOrFail(t, mockDB, database.ColEpisode, epi)
+		episodes = append(episodes, epi)
+	}
+	insertOrFail(t, mockDB, database.ColSubscription, &protos.Subscription{Id: protos.NewObjectID(), PodcastID: podID})
+	insertOrFail(t, mockDB, database.ColSubscription, &protos.Subscription{Id: protos.NewObjectID(), PodcastID: podID})
+	insertOrFail(t, mockDB, database.ColRetention, &models.Retention{PodcastID: protos.NewObjectID()})
+
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("TestArchiver() error creating store: %v", err)
+	}
+	archiver := NewArchiver(mockDB, store, 2, 0)
+
+	tests := []struct {
+		name         string
+		retention    *models.Retention
+		wantArchived []int
+	}{
+		{name: "default_retention", wantArchived: []int{0, 1}},
+		{name: "podcast_retention", retention: &models.Retention{PodcastID: podID, KeepLatest: 3, MaxAgeDays: 3}, wantArchived: []int{0, 1}},
+		{name: "fewer_kept", retention: &models.Retention{PodcastID: podID, KeepLatest: 1}, wantArchived: []int{0}},
+		{name: "none_kept", retention: &models.Retention{PodcastID: podID, KeepLatest: 0}, wantArchived: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.retention != nil {
+				err := mockDB.Upsert(database.ColRetention, tt.retention, &db.Filter{"podcast_id": podID})
+				if err != nil {
+					t.Fatalf("TestArchiver() error upserting retention: %v", err)
+				}
+			}
+			archiver.archiveSubscribed()
+
+			archived := map[int]bool{}
+			for _, i := range tt.wantArchived {
+				archived[i] = true
+			}
+			for i, epi := range episodes {
+				arc, err := FindArchivedEpisode(mockDB, epi.Id)
+				if (err == nil) != archived[i] {
+					t.Errorf("episode %d archived = %v, want %v", i, err == nil, archived[i])
+				}
+				if err != nil {
+					continue
+				}
+				blob, err := store.Open(arc.Key)
+				if err != nil {
+					t.Fatalf("TestArchiver() error opening blob: %v", err)
+				}
+				data, _ := ioutil.ReadAll(blob)
+				blob.Close()
+				want := enclosures["/"+strconv.Itoa(i+1)+".mp3"]
+				if !bytes.Equal(data, want) || arc.SHA256 != checksum(want) || arc.Size != int64(len(want)) || arc.Type != "audio/mpeg" {
+					t.Errorf("episode %d archived = %v, stored %v bytes", i, arc, len(data))
+				}
+			}
+		})
+	}
+}
+
+func TestArchiver_archiveEpisode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/truncated.mp3" {
+			res.Header().Set("Content-Length", strconv.Itoa(10000))
+			res.Write(make([]byte, 100))
+			return
+		}
+		res.Write(bytes.Repeat([]byte("episode"), 1000))
+	}))
+	defer server.Close()
+	mockDB := mock.CreateDB()
+	insertOrFail(t, mockDB, database.ColArchive, &models.ArchivedEpisode{EpisodeID: protos.NewObjectID()})
+	local, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("TestArchiver_archiveEpisode() error creating store: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		store   Store
+		path    string
+		wantErr bool
+	}{
+		{name: "archived", store: local, path: "/episode.mp3"},
+		{name: "truncated", store: local, path: "/truncated.mp3", wantErr: true},
+		{name: "corrupted", store: corruptStore{local}, path: "/episode.mp3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			epi := &protos.Episode{Id: protos.NewObjectID(), PodcastID: protos.NewObjectID(), MP3URL: server.URL + tt.path, EnclosureType: "audio/mp3"}
+			archiver := NewArchiver(mockDB, tt.store, 1, 0)
+			err := archiver.archiveEpisode(epi)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("archiveEpisode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			arc, err := FindArchivedEpisode(mockDB, epi.Id)
+			if (err == nil) == tt.wantErr {
+				t.Errorf("archiveEpisode() recorded = %v, want %v", err == nil, !tt.wantErr)
+			}
+			// failed archives leave nothing in the store
+			_, err = local.Open(epi.PodcastID.GetHex() + "/" + epi.Id.GetHex())
+			if (err == nil) == tt.wantErr {
+				t.Errorf("archiveEpisode() stored = %v, want %v", err == nil, !tt.wantErr)
+			}
+			if !tt.wantErr && arc.Type != "audio/mp3" {
+				t.Errorf("archiveEpisode() type = %v, want the feed's type", arc.Type)
+			}
+		})
+	}
+}
+
+func Test_retained(t *testing.T) {
+	now := time.Now()
+	pubDate := func(ago time.Duration) *protos.Episode {
+		ts, _ := ptypes.TimestampProto(now.Add(-ago))
+		return &protos.Episode{MP3URL: "https://example.com/1.mp3", PubDate: ts}
+	}
+	tests := []struct {
+		name      string
+		retention *models.Retention
+		epi       *protos.Episode
+		want      bool
+	}{
+		{name: "no_max_age", retention: &models.Retention{KeepLatest: 1}, epi: pubDate(1000 * 24 * time.Hour), want: true},
+		{name: "within_max_age", retention: &models.Retention{KeepLatest: 1, MaxAgeDays: 7}, epi: pubDate(6 * 24 * time.Hour), want: true},
+		{name: "past_max_age", retention: &models.Retention{KeepLatest: 1, MaxAgeDays: 7}, epi: pubDate(8 * 24 * time.Hour), want: false},
+		{name: "no_enclosure", retention: &models.Retention{KeepLatest: 1}, epi: &protos.Episode{}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retained(tt.retention, tt.epi, now); got != tt.want {
+				t.Errorf("retained() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
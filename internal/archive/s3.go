@@ -0,0 +1,280 @@
+package archive
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// emptySHA256 is the hex encoded checksum of an empty payload
+const emptySHA256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// S3Store stores blobs in a bucket of an S3 compatible api, e.g. AWS or MinIO.
+// Requests are signed with AWS Signature Version 4 and use path style urls
+type S3Store struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+}
+
+// NewS3Store creates a store in the bucket at the endpoint, region defaults to us-east-1
+func NewS3Store(endpoint, region, bucket, accessKey, secretKey string) (*S3Store, error) {
+	u, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("NewS3Store() error: invalid endpoint %q", endpoint)
+	}
+	if bucket == "" {
+		return nil, errors.New("NewS3Store() error: no bucket")
+	}
+	if region == "" {
+		region = "us-east-1"
+	}
+	return &S3Store{
+		endpoint:  u,
+		region:    region,
+		bucket:    bucket,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		client:    &http.Client{},
+	}, nil
+}
+
+// objectURL returns the path style url of the object at key
+func (s *S3Store) objectURL(key string) string {
+	u := *s.endpoint
+	u.Path += "/" + s.bucket + "/" + key
+	u.RawPath = s.endpoint.Path + "/" + uriEncode(s.bucket, false) + "/" + uriEncode(key, true)
+	return u.String()
+}
+
+// do signs & sends a request for the object at key
+func (s *S3Store) do(method, key string, body io.Reader, size int64, header http.Header) (*http.Response, error) {
+	if err := validKey(key); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(method, s.objectURL(key), body)
+	if err != nil {
+		return nil, fmt.Errorf("do() error creating request: %v", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	payloadHash := emptySHA256
+	if body != nil {
+		// the blob is streamed so it isn't hashed up front, the archiver verifies it once stored
+		req.ContentLength = size
+		if size == 0 {
+			req.Body = http.NoBody
+		}
+		payloadHash = "UNSIGNED-PAYLOAD"
+	}
+	signV4(req, payloadHash, time.Now(), s.region, s.accessKey, s.secretKey)
+	return s.client.Do(req)
+}
+
+// Put uploads the blob with a PUT request
+func (s *S3Store) Put(key string, r io.Reader, size int64) error {
+	resp, err := s.do(http.MethodPut, key, r, size, nil)
+	if err != nil {
+		return fmt.Errorf("S3Store.Put() error: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("S3Store.Put() error: %v", s3Error(resp))
+	}
+	return nil
+}
+
+// Open finds the size of the blob with a HEAD request, the returned blob requests what's read from it
+func (s *S3Store) Open(key string) (Blob, error) {
+	resp, err := s.do(http.MethodHead, key, nil, 0, nil)
+	if err != nil {
+		return nil, fmt.Errorf("S3Store.Open() error: %v", err)
+	}
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("S3Store.Open() error: %v", s3Error(resp))
+	case resp.ContentLength < 0:
+		return nil, errors.New("S3Store.Open() error: unknown blob size")
+	}
+	return &s3Blob{store: s, key: key, size: resp.ContentLength}, nil
+}
+
+// Delete deletes the blob with a DELETE request
+func (s *S3Store) Delete(key string) error {
+	resp, err := s.do(http.MethodDelete, key, nil, 0, nil)
+	if err != nil {
+		return fmt.Errorf("S3Store.Delete() error: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("S3Store.Delete() error: %v", s3Error(resp))
+	}
+	return nil
+}
+
+// s3Error describes an error response
+func s3Error(resp *http.Response) error {
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<10))
+	return fmt.Errorf("server responded %s: %s", resp.Status, strings.TrimSpace(string(body)))
+}
+
+// s3Blob reads a blob with ranged GET requests, starting a new request whenever it seeks
+type s3Blob struct {
+	store  *S3Store
+	key    string
+	size   int64
+	offset int64
+	body   io.ReadCloser
+}
+
+func (b *s3Blob) Read(p []byte) (int, error) {
+	if b.offset >= b.size {
+		return 0, io.EOF
+	}
+	if b.body == nil {
+		header := http.Header{"Range": {fmt.Sprintf("bytes=%d-", b.offset)}}
+		resp, err := b.store.do(http.MethodGet, b.key, nil, 0, header)
+		if err != nil {
+			return 0, fmt.Errorf("s3Blob.Read() error: %v", err)
+		}
+		if resp.StatusCode != http.StatusPartialContent && !(resp.StatusCode == http.StatusOK && b.offset == 0) {
+			defer resp.Body.Close()
+			return 0, fmt.Errorf("s3Blob.Read() error: %v", s3Error(resp))
+		}
+		b.body = resp.Body
+	}
+	n, err := b.body.Read(p)
+	b.offset += int64(n)
+	if err == io.EOF && b.offset < b.size {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+func (b *s3Blob) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += b.offset
+	case io.SeekEnd:
+		offset += b.size
+	}
+	if offset < 0 {
+		return 0, errors.New("s3Blob.Seek() error: negative offset")
+	}
+	if offset != b.offset && b.body != nil {
+		b.body.Close()
+		b.body = nil
+	}
+	b.offset = offset
+	return offset, nil
+}
+
+func (b *s3Blob) Close() error {
+	if b.body == nil {
+		return nil
+	}
+	return b.body.Close()
+}
+
+// signV4 signs the request with AWS Signature Version 4 for the s3 service,
+// signing its host, range & x-amz-* headers
+func signV4(req *http.Request, payloadHash string, now time.Time, region, accessKey, secretKey string) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		k = strings.ToLower(k)
+		if k == "range" || strings.HasPrefix(k, "x-amz-") {
+			headers[k] = strings.TrimSpace(strings.Join(v, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := date + "/" + region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, scope, signedHeaders, signature))
+}
+
+// canonicalQuery encodes the query sorted by key
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var params []string
+	for _, k := range keys {
+		values := query[k]
+		sort.Strings(values)
+		for _, v := range values {
+			params = append(params, uriEncode(k, false)+"="+uriEncode(v, false))
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+// uriEncode percent encodes everything but unreserved characters, and slashes if keepSlash
+func uriEncode(s string, keepSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || (c == '/' && keepSlash) {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
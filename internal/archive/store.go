@@ -0,0 +1,124 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotFound is returned opening a blob that isn't stored
+var ErrNotFound = errors.New("blob not found")
+
+// Store is a blob store the archived enclosures are kept in
+type Store interface {
+	// Put stores size bytes read from r as the blob at key, replacing any existing blob
+	Put(key string, r io.Reader, size int64) error
+	// Open opens the blob at key for reading, ErrNotFound if there is none
+	Open(key string) (Blob, error)
+	// Delete deletes the blob at key, deleting a missing blob is not an error
+	Delete(key string) error
+}
+
+// Blob is a stored blob, seeking lets it be served with Range requests
+type Blob interface {
+	io.ReadSeeker
+	io.Closer
+}
+
+// validKey checks the key is a relative slash separated path that stays within the store
+func validKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || strings.HasPrefix(key, "../") || key == ".." {
+		return fmt.Errorf("validKey() error: invalid key %q", key)
+	}
+	return nil
+}
+
+// LocalStore stores blobs as files within a directory
+type LocalStore struct {
+	dir string
+}
+
+// NewLocalStore creates a store in the directory, creating it if needed
+func NewLocalStore(dir string) (*LocalStore, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("NewLocalStore() error creating directory: %v", err)
+	}
+	return &LocalStore{dir: dir}, nil
+}
+
+func (l *LocalStore) path(key string) (string, error) {
+	if err := validKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(key)), nil
+}
+
+// Put writes the blob to a temporary file that replaces the blob's file once complete,
+// so a partly written blob is never opened
+func (l *LocalStore) Put(key string, r io.Reader, size int64) error {
+	p, err := l.path(key)
+	if err != nil {
+		return fmt.Errorf("LocalStore.Put() error: %v", err)
+	}
+	err = os.MkdirAll(filepath.Dir(p), 0700)
+	if err != nil {
+		return fmt.Errorf("LocalStore.Put() error creating directory: %v", err)
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(p), ".put-")
+	if err != nil {
+		return fmt.Errorf("LocalStore.Put() error creating file: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := io.Copy(tmp, r)
+	closeErr := tmp.Close()
+	if err != nil {
+		return fmt.Errorf("LocalStore.Put() error writing blob: %v", err)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("LocalStore.Put() error closing file: %v", closeErr)
+	}
+	if n != size {
+		return fmt.Errorf("LocalStore.Put() error: wrote %d bytes, expected %d", n, size)
+	}
+	err = os.Rename(tmp.Name(), p)
+	if err != nil {
+		return fmt.Errorf("LocalStore.Put() error renaming file: %v", err)
+	}
+	return nil
+}
+
+// Open opens the blob's file
+func (l *LocalStore) Open(key string) (Blob, error) {
+	p, err := l.path(key)
+	if err != nil {
+		return nil, fmt.Errorf("LocalStore.Open() error: %v", err)
+	}
+	file, err := os.Open(p)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("LocalStore.Open() error: %v", err)
+	}
+	return file, nil
+}
+
+// Delete removes the blob's file
+func (l *LocalStore) Delete(key string) error {
+	p, err := l.path(key)
+	if err != nil {
+		return fmt.Errorf("LocalStore.Delete() error: %v", err)
+	}
+	err = os.Remove(p)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("LocalStore.Delete() error: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/database"
+	sMail "github.com/sschwartz96/syncapod/internal/mail"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/protos"
+	"github.com/sschwartz96/syncapod/internal/user"
+)
+
+const (
+	// verifyTokenTTL is how long an email verification link is valid
+	verifyTokenTTL = 48 * time.Hour
+	// resetTokenTTL is how long a password reset link is valid
+	resetTokenTTL = time.Hour
+	// minPasswordLength is the shortest password accepted
+	minPasswordLength = 8
+)
+
+// usernameRegex matches valid usernames, which can't contain @ as FindUser looks those up by email
+var usernameRegex = regexp.MustCompile(`^[a-zA-Z0-9_.-]{3,32}$`)
+
+// Account errors, their messages are safe to show to the user
+var (
+	ErrInvalidUsername = errors.New("username must be 3 to 32 letters, numbers, dots, dashes or underscores")
+	ErrInvalidEmail    = errors.New("invalid email address")
+	ErrWeakPassword    = fmt.Errorf("password must be at least %d characters", minPasswordLength)
+	ErrUsernameTaken   = errors.New("username is taken")
+	ErrEmailTaken      = errors.New("email is already registered")
+	ErrInvalidToken    = errors.New("link is invalid or has expired")
+)
+
+// IsAccountError returns whether err is one of the account errors shown to the user
+func IsAccountError(err error) bool {
+	switch err {
+	case ErrInvalidUsername, ErrInvalidEmail, ErrWeakPassword, ErrUsernameTaken, ErrEmailTaken, ErrInvalidToken:
+		return true
+	}
+	return false
+}
+
+// Accounts registers users and emails them the links verifying their email and resetting their password
+type Accounts struct {
+	dbClient db.Database
+	mailer   sMail.Mailer
+	baseURL  string
+}
+
+// NewAccounts creates the account flows, linking to the pages served at baseURL
+func NewAccounts(dbClient db.Database, mailer sMail.Mailer, baseURL string) *Accounts {
+	return &Accounts{dbClient: dbClient, mailer: mailer, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// Register creates an unverified user and emails them a verification link
+func (a *Accounts) Register(username, email, password string, dob *timestamp.Timestamp) (*protos.User, error) {
+	username = strings.TrimSpace(username)
+	if !usernameRegex.MatchString(username) {
+		return nil, ErrInvalidUsername
+	}
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	if len(password) < minPasswordLength {
+		return nil, ErrWeakPassword
+	}
+	// the unique indexes catch concurrent registrations
+	if _, err = user.FindUser(a.dbClient, username); err == nil {
+		return nil, ErrUsernameTaken
+	}
+	if _, err = user.FindUser(a.dbClient, email); err == nil {
+		return nil, ErrEmailTaken
+	}
+
+	hash, err := Hash(password)
+	if err != nil {
+		return nil, fmt.Errorf("Register() error hashing password: %v", err)
+	}
+	u := &protos.User{
+		Id:       protos.NewObjectID(),
+		Username: username,
+		Email:    email,
+		Password: hash,
+		DOB:      dob,
+	}
+	err = user.InsertUser(a.dbClient, u)
+	if err != nil {
+		return nil, fmt.Errorf("Register() error: %v", err)
+	}
+
+	// the account exists either way, a failed email is logged rather than failing the registration
+	err = a.sendLink(u, models.PurposeVerifyEmail, verifyTokenTTL, "/account/verify", "Verify your syncapod email",
+		"Welcome to syncapod, %s!\n\nVerify your email by opening this link within 48 hours:\n\n%s\n")
+	if err != nil {
+		log.Println("Register() error sending verification email:", err)
+	}
+	return u, nil
+}
+
+// VerifyEmail redeems the verification token, marking its user's email verified
+func (a *Accounts) VerifyEmail(token string) (*protos.User, error) {
+	tok, err := a.redeemToken(token, models.PurposeVerifyEmail)
+	if err != nil {
+		return nil, err
+	}
+	u, err := user.FindUserByID(a.dbClient, tok.UserID)
+	// the email may have changed since it was sent
+	if err != nil || u.Email != tok.Email {
+		return nil, ErrInvalidToken
+	}
+	u.EmailVerified = true
+	err = user.UpdateUser(a.dbClient, u)
+	if err != nil {
+		return nil, fmt.Errorf("VerifyEmail() error: %v", err)
+	}
+	return u, nil
+}
+
+// RequestPasswordReset emails the user with the username or email a password reset link,
+// neither unknown users nor failing to email are errors so the response doesn't reveal who is registered
+func (a *Accounts) RequestPasswordReset(username string) error {
+	u, err := user.FindUser(a.dbClient, strings.TrimSpace(username))
+	if err != nil {
+		return nil
+	}
+	err = a.sendLink(u, models.PurposeResetPassword, resetTokenTTL, "/account/reset", "Reset your syncapod password",
+		"Hi %s,\n\nReset your password by opening this link within an hour:\n\n%s\n\nIf you didn't ask to reset it you can ignore this email.\n")
+	if err != nil {
+		log.Println("RequestPasswordReset() error sending reset email:", err)
+	}
+	return nil
+}
+
+// ResetPassword redeems the reset token, setting its user's password and logging them out everywhere
+func (a *Accounts) ResetPassword(token, password string) (*protos.User, error) {
+	// checked first so a weak password doesn't use up the token
+	if len(password) < minPasswordLength {
+		return nil, ErrWeakPassword
+	}
+	tok, err := a.redeemToken(token, models.PurposeResetPassword)
+	if err != nil {
+		return nil, err
+	}
+	u, err := user.FindUserByID(a.dbClient, tok.UserID)
+	if err != nil || u.Email != tok.Email {
+		return nil, ErrInvalidToken
+	}
+	u.Password, err = Hash(password)
+	if err != nil {
+		return nil, fmt.Errorf("ResetPassword() error hashing password: %v", err)
+	}
+	// the link was emailed, so following it verifies the email too
+	u.EmailVerified = true
+	err = user.UpdateUser(a.dbClient, u)
+	if err != nil {
+		return nil, fmt.Errorf("ResetPassword() error: %v", err)
+	}
+	err = user.DeleteUserSessions(a.dbClient, u.Id)
+	if err != nil {
+		return nil, fmt.Errorf("ResetPassword() error: %v", err)
+	}
+	return u, nil
+}
+
+// sendLink stores a new token and emails the user a link to the page at path redeeming it,
+// body is formatted with the username and link
+func (a *Accounts) sendLink(u *protos.User, purpose string, ttl time.Duration, path, subject, body string) error {
+	token, err := CreateKey(43)
+	if err != nil {
+		return fmt.Errorf("sendLink() error: %v", err)
+	}
+	tok := &models.UserToken{
+		Hash:    hashToken(token),
+		UserID:  u.Id,
+		Purpose: purpose,
+		Email:   u.Email,
+		Expires: time.Now().Add(ttl),
+	}
+	err = a.dbClient.Insert(database.ColUserToken, tok)
+	if err != nil {
+		return fmt.Errorf("sendLink() error inserting token: %v", err)
+	}
+	link := a.baseURL + path + "?token=" + url.QueryEscape(token)
+	err = a.mailer.Send(u.Email, subject, fmt.Sprintf(body, u.Username, link))
+	if err != nil {
+		return fmt.Errorf("sendLink() error: %v", err)
+	}
+	return nil
+}
+
+// redeemToken finds & deletes the unexpired token for the purpose, only the first redemption succeeds
+func (a *Accounts) redeemToken(token, purpose string) (*models.UserToken, error) {
+	filter := &db.Filter{"hash": hashToken(token)}
+	tok := &models.UserToken{}
+	err := a.dbClient.FindOne(database.ColUserToken, tok, filter, nil)
+	if err != nil || tok.Purpose != purpose {
+		return nil, ErrInvalidToken
+	}
+	// deleting fails if a concurrent redemption deleted it first
+	err = a.dbClient.Delete(database.ColUserToken, filter)
+	if err != nil || time.Now().After(tok.Expires) {
+		return nil, ErrInvalidToken
+	}
+	return tok, nil
+}
+
+// hashToken returns the hex encoded sha256 of the token, which is stored instead of the token itself
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeEmail validates the bare email address, returning it lowercased as FindUser looks it up
+func normalizeEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return "", ErrInvalidEmail
+	}
+	return strings.ToLower(email), nil
+}
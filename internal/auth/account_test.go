@@ -0,0 +1,234 @@
+package auth
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/stockpile/mock"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/protos"
+	"github.com/sschwartz96/syncapod/internal/user"
+)
+
+// sentMail is an email sent by fakeMailer
+type sentMail struct {
+	to, subject, body string
+}
+
+// fakeMailer records the emails sent instead of sending them, failing with err if set
+type fakeMailer struct {
+	sent []sentMail
+	err  error
+}
+
+func (m *fakeMailer) Send(to, subject, body string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, sentMail{to: to, subject: subject, body: body})
+	return nil
+}
+
+var linkRegex = regexp.MustCompile(`https://syncapod\.com(/account/\w+)\?token=(\S+)`)
+
+// lastLink returns the path & token of the link in the last email sent, failing if there is none
+func (m *fakeMailer) lastLink(t *testing.T) (string, string) {
+	if len(m.sent) == 0 {
+		t.Fatalf("fakeMailer no email sent")
+	}
+	match := linkRegex.FindStringSubmatch(m.sent[len(m.sent)-1].body)
+	if match == nil {
+		t.Fatalf("fakeMailer no link in email: %q", m.sent[len(m.sent)-1].body)
+	}
+	token, _ := url.QueryUnescape(match[2])
+	return match[1], token
+}
+
+func createAccountsMockDB(t *testing.T) db.Database {
+	dbClient := mock.CreateDB()
+	err := dbClient.Insert(database.ColUser, &protos.User{
+		Id:       protos.ObjectIDFromHex("user_id"),
+		Username: "user",
+		Email:    "user@example.com",
+		Password: "$2a$04$Rxbh4f5cUjABPp2RE8o8PuvOafWNeYRsvYI/2t1lSL/DD/IYmWsfe",
+	})
+	if err != nil {
+		t.Fatalf("createAccountsMockDB() error inserting user: %v", err)
+	}
+	// the collection must exist to be searched
+	err = dbClient.Insert(database.ColUserToken, &models.UserToken{Hash: "unused"})
+	if err != nil {
+		t.Fatalf("createAccountsMockDB() error inserting token: %v", err)
+	}
+	return dbClient
+}
+
+func TestAccounts_Register(t *testing.T) {
+	mockDB := createAccountsMockDB(t)
+	mailer := &fakeMailer{}
+	accounts := NewAccounts(mockDB, mailer, "https://syncapod.com/")
+
+	tests := []struct {
+		name     string
+		username string
+		email    string
+		password string
+		wantErr  error
+	}{
+		{name: "valid", username: "new_user", email: "New@Example.com", password: "password"},
+		{name: "username_taken", username: "user", email: "other@example.com", password: "password", wantErr: ErrUsernameTaken},
+		{name: "email_taken", username: "other", email: "USER@example.com", password: "password", wantErr: ErrEmailTaken},
+		{name: "invalid_username", username: "us@er", email: "other@example.com", password: "password", wantErr: ErrInvalidUsername},
+		{name: "short_username", username: "us", email: "other@example.com", password: "password", wantErr: ErrInvalidUsername},
+		{name: "invalid_email", username: "other", email: "Other <other@example.com>", password: "password", wantErr: ErrInvalidEmail},
+		{name: "weak_password", username: "other", email: "other@example.com", password: "short", wantErr: ErrWeakPassword},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := len(mailer.sent)
+			got, err := accounts.Register(tt.username, tt.email, tt.password, ptypes.TimestampNow())
+			if err != tt.wantErr {
+				t.Fatalf("Accounts.Register() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if len(mailer.sent) != sent {
+					t.Errorf("Accounts.Register() sent an email on error")
+				}
+				return
+			}
+			if got.Email != "new@example.com" || got.EmailVerified || !Compare(got.Password, tt.password) {
+				t.Errorf("Accounts.Register() = %v", got)
+			}
+			if _, err = user.FindUser(mockDB, tt.username); err != nil {
+				t.Errorf("Accounts.Register() user not stored: %v", err)
+			}
+			if len(mailer.sent) != sent+1 || mailer.sent[sent].to != "new@example.com" {
+				t.Fatalf("Accounts.Register() sent %v", mailer.sent[sent:])
+			}
+			if path, _ := mailer.lastLink(t); path != "/account/verify" {
+				t.Errorf("Accounts.Register() sent link to %v", path)
+			}
+		})
+	}
+}
+
+func TestAccounts_VerifyEmail(t *testing.T) {
+	mockDB := createAccountsMockDB(t)
+	mailer := &fakeMailer{}
+	accounts := NewAccounts(mockDB, mailer, "https://syncapod.com")
+	_, err := accounts.Register("new_user", "new@example.com", "password", nil)
+	if err != nil {
+		t.Fatalf("TestAccounts_VerifyEmail() error registering: %v", err)
+	}
+	_, token := mailer.lastLink(t)
+
+	// a reset token can't verify
+	err = accounts.RequestPasswordReset("new_user")
+	if err != nil {
+		t.Fatalf("TestAccounts_VerifyEmail() error requesting reset: %v", err)
+	}
+	_, resetToken := mailer.lastLink(t)
+
+	expired := "expired_token"
+	err = mockDB.Insert(database.ColUserToken, &models.UserToken{
+		Hash:    hashToken(expired),
+		UserID:  protos.ObjectIDFromHex("user_id"),
+		Purpose: models.PurposeVerifyEmail,
+		Email:   "user@example.com",
+		Expires: time.Now().Add(-time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("TestAccounts_VerifyEmail() error inserting token: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "wrong_purpose", token: resetToken, wantErr: ErrInvalidToken},
+		{name: "expired", token: expired, wantErr: ErrInvalidToken},
+		{name: "unknown", token: "unknown_token", wantErr: ErrInvalidToken},
+		{name: "valid", token: token},
+		{name: "reused", token: token, wantErr: ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := accounts.VerifyEmail(tt.token)
+			if err != tt.wantErr {
+				t.Fatalf("Accounts.VerifyEmail() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			stored, err := user.FindUser(mockDB, "new_user")
+			if err != nil || !got.EmailVerified || !stored.EmailVerified {
+				t.Errorf("Accounts.VerifyEmail() = %v, stored %v", got, stored)
+			}
+		})
+	}
+}
+
+func TestAccounts_ResetPassword(t *testing.T) {
+	mockDB := createAccountsMockDB(t)
+	mailer := &fakeMailer{}
+	accounts := NewAccounts(mockDB, mailer, "https://syncapod.com")
+	key, err := CreateSession(mockDB, protos.ObjectIDFromHex("user_id"), "test", true)
+	if err != nil {
+		t.Fatalf("TestAccounts_ResetPassword() error creating session: %v", err)
+	}
+
+	// unknown users are not revealed
+	err = accounts.RequestPasswordReset("nobody@example.com")
+	if err != nil || len(mailer.sent) != 0 {
+		t.Fatalf("Accounts.RequestPasswordReset() unknown user error = %v, sent %v", err, mailer.sent)
+	}
+	// nor by failing to email them
+	mailer.err = errors.New("mail server down")
+	err = accounts.RequestPasswordReset("user@example.com")
+	if err != nil || len(mailer.sent) != 0 {
+		t.Fatalf("Accounts.RequestPasswordReset() failed email error = %v, sent %v", err, mailer.sent)
+	}
+	mailer.err = nil
+	err = accounts.RequestPasswordReset("user@example.com")
+	if err != nil {
+		t.Fatalf("Accounts.RequestPasswordReset() error = %v", err)
+	}
+	path, token := mailer.lastLink(t)
+	if path != "/account/reset" || mailer.sent[0].to != "user@example.com" {
+		t.Fatalf("Accounts.RequestPasswordReset() sent %v", mailer.sent)
+	}
+
+	tests := []struct {
+		name     string
+		token    string
+		password string
+		wantErr  error
+	}{
+		{name: "weak_password", token: token, password: "short", wantErr: ErrWeakPassword},
+		{name: "valid", token: token, password: "new_password"},
+		{name: "reused", token: token, password: "other_password", wantErr: ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := accounts.ResetPassword(tt.token, tt.password)
+			if err != tt.wantErr {
+				t.Fatalf("Accounts.ResetPassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	stored, err := user.FindUserByID(mockDB, protos.ObjectIDFromHex("user_id"))
+	if err != nil || !Compare(stored.Password, "new_password") || !stored.EmailVerified {
+		t.Errorf("Accounts.ResetPassword() stored %v, error %v", stored, err)
+	}
+	if _, err = user.FindSession(mockDB, key); err == nil {
+		t.Errorf("Accounts.ResetPassword() kept the user's session")
+	}
+}
@@ -18,6 +18,33 @@ type Config struct {
 	AlexaClientID string  `json:"alexa_client_id"`
 	AlexaSecret   string  `json:"alexa_secret"`
 	GRPCPort      int     `json:"grpc_port"`
+	BaseURL       string  `json:"base_url"`       // public url of the server, e.g. https://syncapod.com
+	CredentialKey string  `json:"credential_key"` // base64 encoded 32 byte key encrypting private feed credentials
+	StreamKey     string  `json:"stream_key"`     // base64 encoded key signing stream proxy urls, random on each start if empty
+	Archive       Archive `json:"archive"`
+	Mail          Mail    `json:"mail"`
+}
+
+// Archive configures archiving the enclosures of subscribed podcasts,
+// disabled unless a directory or S3 endpoint is set
+type Archive struct {
+	Dir         string `json:"dir"`           // local directory the enclosures are stored in
+	S3Endpoint  string `json:"s3_endpoint"`   // S3 compatible api the enclosures are stored in instead, e.g. http://localhost:9000
+	S3Region    string `json:"s3_region"`     // defaults to us-east-1
+	S3Bucket    string `json:"s3_bucket"`     // bucket the enclosures are stored in
+	S3AccessKey string `json:"s3_access_key"` // access key id
+	S3SecretKey string `json:"s3_secret_key"` // secret access key
+	KeepLatest  int    `json:"keep_latest"`   // latest episodes of each podcast kept unless it has its own retention
+	MaxAgeDays  int    `json:"max_age_days"`  // episodes published longer ago are not kept, 0 for no limit
+}
+
+// Mail configures the smtp server account emails are sent through, they're logged instead if no host is set
+type Mail struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`     // defaults to 587
+	Username string `json:"username"` // smtp auth is only used if set
+	Password string `json:"password"`
+	From     string `json:"from"` // e.g. syncapod <noreply@syncapod.com>
 }
 
 // ReadConfig reads the config file encoded in JSON
@@ -1,8 +1,14 @@
 package database
 
 import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/sschwartz96/stockpile/mongodb"
 	"github.com/sschwartz96/syncapod/internal/config"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -21,6 +27,26 @@ const (
 	ColSubscription = "subscription"
 	ColAuthCode     = "oauth_auth_code"
 	ColAccessToken  = "oauth_access_token"
+	ColFetchState   = "podcast_fetch_state"
+	ColFeedSchedule = "podcast_schedule"
+	ColFeedHistory  = "podcast_feed_history"
+	ColFeedPage     = "podcast_feed_page"
+	ColFeedHealth   = "podcast_feed_health"
+	ColWebSub       = "podcast_websub"
+	ColCredential   = "subscription_credential"
+	ColChapterJob   = "podcast_chapter_job"
+	ColArchive      = "episode_archive"
+	ColRetention    = "episode_archive_retention"
+	ColVariant      = "episode_variant"
+	ColFingerprint  = "podcast_fingerprint_job"
+	ColWaveform     = "episode_waveform"
+	ColWaveformJob  = "podcast_waveform_job"
+	ColUserToken    = "user_token"
+	ColOPMLImport   = "opml_import"
+	ColQueue        = "user_queue"
+	ColPlaylist     = "user_playlist"
+	ColDevice       = "user_device"
+	ColChange       = "user_change"
 )
 
 var (
@@ -33,6 +59,26 @@ var (
 		ColSubscription,
 		ColAuthCode,
 		ColAccessToken,
+		ColFetchState,
+		ColFeedSchedule,
+		ColFeedHistory,
+		ColFeedPage,
+		ColFeedHealth,
+		ColWebSub,
+		ColCredential,
+		ColChapterJob,
+		ColArchive,
+		ColRetention,
+		ColVariant,
+		ColFingerprint,
+		ColWaveform,
+		ColWaveformJob,
+		ColUserToken,
+		ColOPMLImport,
+		ColQueue,
+		ColPlaylist,
+		ColDevice,
+		ColChange,
 	}
 )
 
@@ -56,6 +102,46 @@ func NewMongoClient(cfg *config.Config) (*mongodb.MongoClient, error) {
 	return client, nil
 }
 
+// CreateIndexes creates the unique indexes, failing if existing documents violate them
+// so the migrations removing the duplicates are run first
+func CreateIndexes(client *mongodb.MongoClient) error {
+	db := client.Database(DBsyncapod)
+	// each index is unique across its keys together
+	unique := map[string][][]string{
+		ColUser:        {{"username"}, {"email"}},
+		ColUserToken:   {{"hash"}},
+		ColUserEpisode: {{"userid", "episodeid"}},
+		ColChange:      {{"userid", "key"}, {"userid", "seq"}},
+	}
+	// partial indexes are only unique among the documents matching their filter,
+	// users created before registration required an email may have none
+	partial := map[string]bson.M{
+		ColUser + ".email": {"email": bson.M{"$gt": ""}},
+	}
+	for collection, indexes := range unique {
+		for _, keys := range indexes {
+			index := bson.D{}
+			for _, key := range keys {
+				index = append(index, bson.E{Key: key, Value: 1})
+			}
+			opts := options.Index().SetUnique(true)
+			if filter, ok := partial[collection+"."+strings.Join(keys, ".")]; ok {
+				opts.SetPartialFilterExpression(filter)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			_, err := db.Collection(collection).Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    index,
+				Options: opts,
+			})
+			cancel()
+			if err != nil {
+				return fmt.Errorf("CreateIndexes() error creating unique index on %s.%v: %v", collection, keys, err)
+			}
+		}
+	}
+	return nil
+}
+
 // createCollectionMap creates a map of mongo collections so the program doesn't
 // reallocate space for a collection every time a request is called
 func createCollectionMap(db *mongo.Database) map[string]*mongo.Collection {
@@ -1,88 +1,117 @@
-package grpc
-
-import (
-	"context"
-	"errors"
-	"fmt"
-	"log"
-	"net"
-	"strings"
-
-	"github.com/sschwartz96/stockpile/db"
-	"github.com/sschwartz96/syncapod/internal/auth"
-	"github.com/sschwartz96/syncapod/internal/config"
-	"github.com/sschwartz96/syncapod/internal/protos"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/metadata"
-	"google.golang.org/grpc/reflection"
-)
-
-// Server is truly needed for its Intercept method which authenticates users before accessing services,
-// but also useful to have all the grpc server boilerplate contained within NewServer function
-type Server struct {
-	server *grpc.Server
-	db     db.Database
-}
-
-func NewServer(cfg *config.Config, dbClient db.Database, aS protos.AuthServer, pS protos.PodServer) *Server {
-	var grpcServer *grpc.Server
-	s := &Server{db: dbClient}
-	// setup server
-	gOptCreds := getTransportCreds(cfg)
-	gOptInter := grpc.UnaryInterceptor(s.Intercept())
-	grpcServer = grpc.NewServer(gOptCreds, gOptInter)
-	s.server = grpcServer
-	// register services
-	reflection.Register(grpcServer)
-	protos.RegisterAuthServer(s.server, aS)
-	protos.RegisterPodServer(s.server, pS)
-	return s
-}
-
-func (s *Server) Start(lis net.Listener) error {
-	return s.server.Serve(lis)
-}
-
-func getTransportCreds(config *config.Config) grpc.ServerOption {
-	var creds credentials.TransportCredentials
-	var err error
-	// whether or not we are running tls
-	if config.CertFile != "" {
-		creds, err = credentials.NewServerTLSFromFile(config.CertFile, config.KeyFile)
-		if err != nil {
-			log.Fatal("error setting up creds for grpc:", creds)
-		}
-	}
-	return grpc.Creds(creds)
-}
-
-func (s *Server) Intercept() grpc.UnaryServerInterceptor {
-	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
-		// if this is going to the Auth service allow through
-		if strings.Contains(info.FullMethod, "protos.Auth") {
-			return handler(ctx, req)
-		}
-
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, errors.New("invalid metadata")
-		}
-		token := md.Get("token")
-		if len(token) == 0 {
-			return nil, errors.New("no access token sent")
-		}
-
-		user, err := auth.ValidateSession(s.db, token[0])
-		if err != nil {
-			return nil, fmt.Errorf("invalid access token: %v", err)
-		}
-
-		//md.Set("user_id", user.Id.Hex) // causes errors
-		newMD := md.Copy()
-		newMD.Set("user_id", user.Id.Hex)
-		newCtx := metadata.NewIncomingContext(ctx, newMD)
-
-		return handler(newCtx, req)
-	}
-}
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/auth"
+	"github.com/sschwartz96/syncapod/internal/config"
+	"github.com/sschwartz96/syncapod/internal/protos"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+)
+
+// Server is truly needed for its Intercept methods which authenticate users before accessing services,
+// but also useful to have all the grpc server boilerplate contained within NewServer function
+type Server struct {
+	server *grpc.Server
+	db     db.Database
+}
+
+func NewServer(cfg *config.Config, dbClient db.Database, aS protos.AuthServer, pS protos.PodServer) *Server {
+	var grpcServer *grpc.Server
+	s := &Server{db: dbClient}
+	// setup server
+	gOptCreds := getTransportCreds(cfg)
+	gOptInter := grpc.UnaryInterceptor(s.Intercept())
+	gOptStreamInter := grpc.StreamInterceptor(s.InterceptStream())
+	grpcServer = grpc.NewServer(gOptCreds, gOptInter, gOptStreamInter)
+	s.server = grpcServer
+	// register services
+	reflection.Register(grpcServer)
+	protos.RegisterAuthServer(s.server, aS)
+	protos.RegisterPodServer(s.server, pS)
+	return s
+}
+
+func (s *Server) Start(lis net.Listener) error {
+	return s.server.Serve(lis)
+}
+
+func getTransportCreds(config *config.Config) grpc.ServerOption {
+	var creds credentials.TransportCredentials
+	var err error
+	// whether or not we are running tls
+	if config.CertFile != "" {
+		creds, err = credentials.NewServerTLSFromFile(config.CertFile, config.KeyFile)
+		if err != nil {
+			log.Fatal("error setting up creds for grpc:", creds)
+		}
+	}
+	return grpc.Creds(creds)
+}
+
+func (s *Server) Intercept() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		newCtx, err := s.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(newCtx, req)
+	}
+}
+
+// InterceptStream authenticates streaming calls, which the unary interceptor doesn't cover
+func (s *Server) InterceptStream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		newCtx, err := s.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authStream{ServerStream: ss, ctx: newCtx})
+	}
+}
+
+// authStream is a server stream whose context holds the authenticated user's id
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (a *authStream) Context() context.Context {
+	return a.ctx
+}
+
+// authenticate validates the access token of the call, returning its context with the user's id
+func (s *Server) authenticate(ctx context.Context, method string) (context.Context, error) {
+	// if this is going to the Auth service allow through
+	if strings.Contains(method, "protos.Auth") {
+		return ctx, nil
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, errors.New("invalid metadata")
+	}
+	token := md.Get("token")
+	if len(token) == 0 {
+		return nil, errors.New("no access token sent")
+	}
+
+	user, err := auth.ValidateSession(s.db, token[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid access token: %v", err)
+	}
+
+	//md.Set("user_id", user.Id.Hex) // causes errors
+	newMD := md.Copy()
+	newMD.Set("user_id", user.Id.Hex)
+	return metadata.NewIncomingContext(ctx, newMD), nil
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+
+	"github.com/sschwartz96/syncapod/internal/auth"
+)
+
+// accountPage is the data the account templates are executed with
+type accountPage struct {
+	Token   string
+	Message string
+	Done    bool
+}
+
+// AccountHandler serves the pages registering accounts, verifying their email and resetting their password
+type AccountHandler struct {
+	accounts  *auth.Accounts
+	templates map[string]*template.Template
+}
+
+// CreateAccountHandler parses the account templates
+func CreateAccountHandler(accounts *auth.Accounts) (*AccountHandler, error) {
+	h := &AccountHandler{accounts: accounts, templates: map[string]*template.Template{}}
+	for _, page := range []string{"register", "verify", "forgot", "reset"} {
+		t, err := template.ParseFiles("templates/account/" + page + ".gohtml")
+		if err != nil {
+			return nil, fmt.Errorf("CreateAccountHandler() error parsing %s template: %v", page, err)
+		}
+		h.templates[page] = t
+	}
+	return h, nil
+}
+
+// ServeHTTP serves the pages, the emailed links only show a form so link scanners don't use up their tokens
+func (h *AccountHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	// path: /account/*
+	var head string
+	head, req.URL.Path = ShiftPath(req.URL.Path)
+	if _, ok := h.templates[head]; !ok {
+		http.NotFound(res, req)
+		return
+	}
+
+	page := &accountPage{Token: req.URL.Query().Get("token")}
+	switch req.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		h.post(head, page, req)
+	default:
+		res.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	err := h.templates[head].Execute(res, page)
+	if err != nil {
+		fmt.Println("error executing template: ", err)
+	}
+}
+
+// post submits the page's form, filling in the result
+func (h *AccountHandler) post(head string, page *accountPage, req *http.Request) {
+	err := req.ParseForm()
+	if err != nil {
+		page.Message = "Couldn't read the form, please try again"
+		return
+	}
+	page.Token = req.PostFormValue("token")
+
+	switch head {
+	case "register":
+		_, err = h.accounts.Register(req.PostFormValue("uname"), req.PostFormValue("email"), req.PostFormValue("pass"), nil)
+	case "verify":
+		_, err = h.accounts.VerifyEmail(page.Token)
+	case "forgot":
+		err = h.accounts.RequestPasswordReset(req.PostFormValue("uname"))
+	case "reset":
+		_, err = h.accounts.ResetPassword(page.Token, req.PostFormValue("pass"))
+	}
+	if err != nil {
+		if auth.IsAccountError(err) {
+			page.Message = err.Error()
+		} else {
+			fmt.Printf("error submitting %s form: %v\n", head, err)
+			page.Message = "Something went wrong, please try again"
+		}
+		return
+	}
+	page.Done = true
+}
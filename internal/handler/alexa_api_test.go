@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sschwartz96/stockpile/mock"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/protos"
+	"github.com/sschwartz96/syncapod/internal/queue"
+	"github.com/sschwartz96/syncapod/internal/user"
+)
+
+func TestAPIHandler_AudioEvent(t *testing.T) {
+	mockDB := mock.CreateDB()
+	userID := protos.NewObjectID()
+	pod := &protos.Podcast{Id: protos.NewObjectID(), Title: "Podcast", Image: &protos.Image{Url: "https://example.com/art.jpg"}}
+	playing := &protos.Episode{Id: protos.NewObjectID(), PodcastID: pod.Id, Title: "Playing", Image: &protos.Image{}}
+	next := &protos.Episode{Id: protos.NewObjectID(), PodcastID: pod.Id, Title: "Next", Image: &protos.Image{}, MP3URL: "https://example.com/next.mp3"}
+	for collection, object := range map[string]interface{}{
+		database.ColUser:        &protos.User{Id: userID, Username: "user"},
+		database.ColAccessToken: &models.AccessToken{Token: "access", UserID: userID, Created: time.Now(), Expires: 3600},
+		database.ColPodcast:     pod,
+		database.ColEpisode:     next,
+		database.ColUserEpisode: &protos.UserEpisode{Id: protos.NewObjectID(), UserID: userID, PodcastID: pod.Id, EpisodeID: next.Id, Offset: 5000},
+	} {
+		if err := mockDB.Insert(collection, object); err != nil {
+			t.Fatalf("TestAPIHandler_AudioEvent() error inserting: %v", err)
+		}
+	}
+	// the playing episode was started from the queue
+	for _, epi := range []*protos.Episode{playing, next} {
+		if _, err := queue.Enqueue(mockDB, userID, epi, false); err != nil {
+			t.Fatalf("TestAPIHandler_AudioEvent() error queueing: %v", err)
+		}
+	}
+	h, _ := CreateAPIHandler(mockDB, nil)
+	token := userID.GetHex() + "-" + pod.Id.GetHex() + "-" + playing.Id.GetHex()
+
+	tests := []struct {
+		name        string
+		accessToken string
+		wantURL     string
+	}{
+		{name: "other_account", accessToken: "other"},
+		{name: "enqueue_next", accessToken: "access", wantURL: next.MP3URL},
+		{name: "queue_empty", accessToken: "access"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aData := &AlexaData{Request: AlexaRequest{Type: PlaybackNearlyFinished, Token: token}}
+			aData.Context.System.User.AccessToken = tt.accessToken
+			body, _ := json.Marshal(aData)
+			rec := httptest.NewRecorder()
+			h.Alexa(rec, httptest.NewRequest(http.MethodPost, "/api/alexa", bytes.NewReader(body)))
+
+			got := &AlexaResponseData{}
+			if err := json.Unmarshal(rec.Body.Bytes(), got); err != nil {
+				t.Fatalf("APIHandler.AudioEvent() invalid response %q: %v", rec.Body.String(), err)
+			}
+			// audioplayer requests can't be answered with speech
+			if got.Response.OutputSpeech != nil || got.Response.ShouldEndSession {
+				t.Errorf("APIHandler.AudioEvent() = %s", rec.Body.String())
+			}
+			if tt.wantURL == "" {
+				if len(got.Response.Directives) != 0 {
+					t.Errorf("APIHandler.AudioEvent() directives = %v, want none", got.Response.Directives)
+				}
+				return
+			}
+			if len(got.Response.Directives) != 1 {
+				t.Fatalf("APIHandler.AudioEvent() directives = %v", got.Response.Directives)
+			}
+			dir := got.Response.Directives[0]
+			stream := dir.AudioItem.Stream
+			if dir.Type != DirPlay || dir.PlayBehavior != PlayEnqueue || stream.URL != tt.wantURL ||
+				stream.ExpectedPreviousToken != token || stream.OffsetInMilliseconds != 5000 {
+				t.Errorf("APIHandler.AudioEvent() directive = %+v", dir)
+			}
+		})
+	}
+
+	// stopping on the device stores the offset and publishes it to the user's watchers
+	updates, stop, err := user.WatchPlayback(userID)
+	if err != nil {
+		t.Fatalf("WatchPlayback() error = %v", err)
+	}
+	defer stop()
+	aData := &AlexaData{Request: AlexaRequest{Type: PlaybackStopped, Token: token, OffsetInMilliseconds: 1234}}
+	aData.Context.System.User.AccessToken = "access"
+	body, _ := json.Marshal(aData)
+	h.Alexa(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/alexa", bytes.NewReader(body)))
+	select {
+	case got := <-updates:
+		if got.EpisodeID.GetHex() != playing.Id.GetHex() || got.Offset != 1234 {
+			t.Errorf("APIHandler.AudioEvent() published %v", got)
+		}
+	default:
+		t.Errorf("APIHandler.AudioEvent() published nothing")
+	}
+}
+
+func Test_createAudioResponse(t *testing.T) {
+	pod := &protos.Podcast{Id: protos.ObjectIDFromHex("pod_id"), Image: &protos.Image{}}
+	epi := &protos.Episode{Id: protos.ObjectIDFromHex("epi_id"), Title: "Episode", Image: &protos.Image{Url: "https://example.com/epi.jpg"}}
+	for _, directive := range []string{DirPlay, DirStop} {
+		got := createAudioResponse(directive, "user_id", "Playing", pod, epi, 1000, "https://example.com/epi.mp3")
+		dir := got.Response.Directives[0]
+		if dir.Type != directive || dir.PlayBehavior != PlayReplaceAll || dir.AudioItem.Stream.Token != "user_id-pod_id-epi_id" ||
+			dir.AudioItem.Stream.OffsetInMilliseconds != 1000 || dir.AudioItem.Metadata.Art.Sources[0].URL != epi.Image.Url {
+			t.Errorf("createAudioResponse(%s) = %v", directive, dir)
+		}
+	}
+}
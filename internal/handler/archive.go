@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/archive"
+	"github.com/sschwartz96/syncapod/internal/auth"
+	"github.com/sschwartz96/syncapod/internal/podcast"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// ArchiveHandler serves the archived copies of episodes' enclosures through /archive/{episodeID}
+type ArchiveHandler struct {
+	dbClient db.Database
+	store    archive.Store
+}
+
+// CreateArchiveHandler instatiates an ArchiveHandler, every request is not found if store is nil
+func CreateArchiveHandler(dbClient db.Database, store archive.Store) (*ArchiveHandler, error) {
+	return &ArchiveHandler{dbClient: dbClient, store: store}, nil
+}
+
+// ServeHTTP serves the archived enclosure with Range support to users with a valid session,
+// whose key is sent as a bearer token or the token query parameter for players that can't set headers
+func (h *ArchiveHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		res.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if h.store == nil {
+		http.NotFound(res, req)
+		return
+	}
+	head, _ := ShiftPath(req.URL.Path)
+	if head == "" {
+		http.NotFound(res, req)
+		return
+	}
+
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		token = req.URL.Query().Get("token")
+	}
+	user, err := auth.ValidateSession(h.dbClient, token)
+	if err != nil {
+		res.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(res, "Not authorized, please provide valid token", http.StatusUnauthorized)
+		return
+	}
+
+	epi, err := podcast.FindEpisodeByID(h.dbClient, protos.ObjectIDFromHex(head))
+	if err != nil {
+		http.NotFound(res, req)
+		return
+	}
+	_, err = podcast.FindPodcastForUser(h.dbClient, epi.PodcastID, user.Id)
+	if err != nil {
+		http.NotFound(res, req)
+		return
+	}
+	archived, err := archive.FindArchivedEpisode(h.dbClient, epi.Id)
+	if err != nil {
+		http.NotFound(res, req)
+		return
+	}
+	blob, err := h.store.Open(archived.Key)
+	if err != nil {
+		log.Println("ArchiveHandler.ServeHTTP() error opening blob:", err)
+		http.NotFound(res, req)
+		return
+	}
+	defer blob.Close()
+
+	if archived.Type != "" {
+		res.Header().Set("Content-Type", archived.Type)
+	}
+	// the checksum identifies the stored copy, so If-Range requests resume the same one
+	res.Header().Set("ETag", `"`+archived.SHA256+`"`)
+	http.ServeContent(res, req, "", archived.ArchivedAt, blob)
+}
@@ -1,84 +1,148 @@
-package handler
-
-import (
-	"encoding/json"
-	"net/http"
-	"path"
-	"strings"
-
-	"github.com/sschwartz96/stockpile/db"
-	"github.com/sschwartz96/syncapod/internal/config"
-)
-
-// Handler is the main handler for syncapod, all routes go through it
-type Handler struct {
-	db           *db.Database
-	oauthHandler *OauthHandler
-	apiHandler   *APIHandler
-}
-
-// CreateHandler sets up the main handler
-func CreateHandler(dbClient db.Database, config *config.Config) (*Handler, error) {
-	handler := &Handler{}
-	var err error
-
-	handler.oauthHandler, err = CreateOauthHandler(dbClient, config.AlexaClientID, config.AlexaSecret)
-	if err != nil {
-		return nil, err
-	}
-
-	handler.apiHandler, err = CreateAPIHandler(dbClient)
-	if err != nil {
-		return nil, err
-	}
-
-	return handler, nil
-}
-
-// ServeHTTP handles all requests
-func (h *Handler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
-	var head string
-	head, req.URL.Path = ShiftPath(req.URL.Path)
-
-	switch head {
-	case "oauth":
-		h.oauthHandler.ServeHTTP(res, req)
-	case "api":
-		h.apiHandler.ServeHTTP(res, req)
-	}
-}
-
-// sendMessageJSON is a helper method in which it decodes an object and sends
-// via http.ResponseWriter given, returns error if decode fails
-func sendObjectJSON(res http.ResponseWriter, object interface{}) error {
-	jsonRes, err := json.Marshal(object)
-	if err != nil {
-		return err
-	}
-	res.Header().Add("Content-Type", "application/json")
-	_, err = res.Write(jsonRes)
-	return err
-}
-
-func sendMessageJSON(res http.ResponseWriter, message string) error {
-	type Response struct {
-		Message string `json:"message"`
-	}
-	response := Response{Message: message}
-	// jsonRes, _ := json.Marshal(&response)
-	// res.Header().Add("Content-Type", "application/json")
-	// res.Write(jsonRes)
-	return sendObjectJSON(res, &response)
-}
-
-// ShiftPath splits off the first component of p, which will be cleaned of
-// relative components before processing. head will never contain a slash and
-// tail will always be a rooted path without trailing slash.
-func ShiftPath(p string) (head, tail string) {
-	p = path.Clean("/" + p)
-	i := strings.Index(p[1:], "/") + 1
-	if i <= 0 {
-		return p[1:], "/"
-	}
-	return p[1:i], p[i:]
-}
+package handler
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/archive"
+	"github.com/sschwartz96/syncapod/internal/auth"
+	"github.com/sschwartz96/syncapod/internal/config"
+	"github.com/sschwartz96/syncapod/internal/podcast"
+)
+
+// Handler is the main handler for syncapod, all routes go through it
+type Handler struct {
+	db             *db.Database
+	oauthHandler   *OauthHandler
+	accountHandler *AccountHandler
+	apiHandler     *APIHandler
+	webSubHandler  *WebSubHandler
+	archiveHandler *ArchiveHandler
+	streamHandler  *StreamHandler
+}
+
+// CreateHandler sets up the main handler, archived enclosures are served from archiveStore if it isn't nil
+func CreateHandler(dbClient db.Database, config *config.Config, webSub *podcast.WebSub, archiveStore archive.Store, accounts *auth.Accounts) (*Handler, error) {
+	handler := &Handler{}
+	var err error
+
+	handler.oauthHandler, err = CreateOauthHandler(dbClient, config.AlexaClientID, config.AlexaSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	handler.accountHandler, err = CreateAccountHandler(accounts)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := createStreamSigner(config)
+	if err != nil {
+		return nil, err
+	}
+
+	handler.apiHandler, err = CreateAPIHandler(dbClient, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	handler.webSubHandler, err = CreateWebSubHandler(webSub)
+	if err != nil {
+		return nil, err
+	}
+
+	handler.archiveHandler, err = CreateArchiveHandler(dbClient, archiveStore)
+	if err != nil {
+		return nil, err
+	}
+
+	handler.streamHandler, err = CreateStreamHandler(dbClient, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler, nil
+}
+
+// createStreamSigner creates the signer of stream proxy urls with the configured key, or a random one
+func createStreamSigner(config *config.Config) (*StreamSigner, error) {
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = "https://syncapod.com"
+	}
+	if config.StreamKey != "" {
+		key, err := base64.StdEncoding.DecodeString(config.StreamKey)
+		if err != nil {
+			return nil, fmt.Errorf("createStreamSigner() error decoding key: %v", err)
+		}
+		return NewStreamSigner(key, baseURL), nil
+	}
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	if err != nil {
+		return nil, fmt.Errorf("createStreamSigner() error generating key: %v", err)
+	}
+	return NewStreamSigner(key, baseURL), nil
+}
+
+// ServeHTTP handles all requests
+func (h *Handler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	var head string
+	head, req.URL.Path = ShiftPath(req.URL.Path)
+
+	switch head {
+	case "oauth":
+		h.oauthHandler.ServeHTTP(res, req)
+	case "account":
+		h.accountHandler.ServeHTTP(res, req)
+	case "api":
+		h.apiHandler.ServeHTTP(res, req)
+	case "websub":
+		h.webSubHandler.ServeHTTP(res, req)
+	case "archive":
+		h.archiveHandler.ServeHTTP(res, req)
+	case "stream":
+		h.streamHandler.ServeHTTP(res, req)
+	}
+}
+
+// sendMessageJSON is a helper method in which it decodes an object and sends
+// via http.ResponseWriter given, returns error if decode fails
+func sendObjectJSON(res http.ResponseWriter, object interface{}) error {
+	jsonRes, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+	res.Header().Add("Content-Type", "application/json")
+	_, err = res.Write(jsonRes)
+	return err
+}
+
+func sendMessageJSON(res http.ResponseWriter, message string) error {
+	type Response struct {
+		Message string `json:"message"`
+	}
+	response := Response{Message: message}
+	// jsonRes, _ := json.Marshal(&response)
+	// res.Header().Add("Content-Type", "application/json")
+	// res.Write(jsonRes)
+	return sendObjectJSON(res, &response)
+}
+
+// ShiftPath splits off the first component of p, which will be cleaned of
+// relative components before processing. head will never contain a slash and
+// tail will always be a rooted path without trailing slash.
+func ShiftPath(p string) (head, tail string) {
+	p = path.Clean("/" + p)
+	i := strings.Index(p[1:], "/") + 1
+	if i <= 0 {
+		return p[1:], "/"
+	}
+	return p[1:i], p[i:]
+}
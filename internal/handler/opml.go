@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/sschwartz96/syncapod/internal/auth"
+	"github.com/sschwartz96/syncapod/internal/opml"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// OPML imports and exports the user's subscriptions through /api/opml, authorized by a session key sent as
+// a bearer token or the token query parameter. GET downloads the export, POST imports the document sent as
+// the body or the "opml" file of a form, streaming the import's progress as a json object per line
+func (h *APIHandler) OPML(res http.ResponseWriter, req *http.Request) {
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		token = req.URL.Query().Get("token")
+	}
+	user, err := auth.ValidateSession(h.dbClient, token)
+	if err != nil {
+		res.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(res, "Not authorized, please provide valid token", http.StatusUnauthorized)
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		doc, err := opml.Export(h.dbClient, user.Id)
+		if err != nil {
+			log.Println("APIHandler.OPML() error exporting:", err)
+			http.Error(res, "Couldn't export subscriptions", http.StatusInternalServerError)
+			return
+		}
+		res.Header().Set("Content-Type", "text/x-opml; charset=utf-8")
+		res.Header().Set("Content-Disposition", `attachment; filename="syncapod.opml"`)
+		res.Write(doc)
+	case http.MethodPost:
+		var body io.Reader = req.Body
+		if strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/form-data") {
+			file, _, err := req.FormFile("opml")
+			if err != nil {
+				http.Error(res, "No opml file sent", http.StatusBadRequest)
+				return
+			}
+			defer file.Close()
+			body = file
+		}
+		feeds, err := opml.Parse(body)
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusBadRequest)
+			return
+		}
+		res.Header().Set("Content-Type", "application/x-ndjson")
+		flusher, _ := res.(http.Flusher)
+		enc := json.NewEncoder(res)
+		opml.Import(h.dbClient, user.Id, feeds, func(p *protos.ImportProgress) {
+			if err := enc.Encode(p); err != nil {
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		})
+	default:
+		res.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
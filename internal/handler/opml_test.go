@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sschwartz96/stockpile/mock"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/protos"
+	"github.com/sschwartz96/syncapod/internal/util"
+)
+
+func TestAPIHandler_OPML(t *testing.T) {
+	mockDB := mock.CreateDB()
+	userID := protos.NewObjectID()
+	pod := &protos.Podcast{Id: protos.NewObjectID(), Title: "Known", Rss: "https://example.com/known.rss"}
+	for collection, object := range map[string]interface{}{
+		database.ColUser:         &protos.User{Id: userID, Username: "user"},
+		database.ColSession:      &protos.Session{Id: protos.NewObjectID(), UserID: userID, SessionKey: "secret", Expires: util.AddToTimestamp(ptypes.TimestampNow(), time.Hour)},
+		database.ColPodcast:      pod,
+		database.ColSubscription: &protos.Subscription{Id: protos.NewObjectID(), UserID: protos.NewObjectID(), PodcastID: pod.Id},
+	} {
+		if err := mockDB.Insert(collection, object); err != nil {
+			t.Fatalf("TestAPIHandler_OPML() error inserting: %v", err)
+		}
+	}
+	h, _ := CreateAPIHandler(mockDB, nil)
+
+	doc := `<opml version="2.0"><body><outline text="Known" type="rss" xmlUrl="https://example.com/known.rss" /></body></opml>`
+	form := &bytes.Buffer{}
+	w := multipart.NewWriter(form)
+	file, _ := w.CreateFormFile("opml", "podcasts.opml")
+	file.Write([]byte(doc))
+	w.Close()
+
+	tests := []struct {
+		name        string
+		method      string
+		token       string
+		contentType string
+		body        string
+		wantStatus  int
+		wantBody    string
+	}{
+		{name: "unauthorized", method: http.MethodGet, token: "invalid", wantStatus: http.StatusUnauthorized},
+		{name: "export_empty", method: http.MethodGet, token: "secret", wantStatus: http.StatusOK, wantBody: "<body></body>"},
+		{name: "import_invalid", method: http.MethodPost, token: "secret", body: "not opml", wantStatus: http.StatusBadRequest},
+		{name: "import_form", method: http.MethodPost, token: "secret", contentType: w.FormDataContentType(), body: form.String(), wantStatus: http.StatusOK, wantBody: `"finished":true`},
+		{name: "import_again", method: http.MethodPost, token: "secret", body: doc, wantStatus: http.StatusOK, wantBody: `"finished":true`},
+		{name: "export", method: http.MethodGet, token: "secret", wantStatus: http.StatusOK, wantBody: `xmlUrl="https://example.com/known.rss"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/opml", strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			res := httptest.NewRecorder()
+			h.ServeHTTP(res, req)
+			if res.Code != tt.wantStatus || !strings.Contains(res.Body.String(), tt.wantBody) {
+				t.Fatalf("APIHandler.OPML() = %v %s, want %v %s", res.Code, res.Body.String(), tt.wantStatus, tt.wantBody)
+			}
+			if tt.method != http.MethodPost || res.Code != http.StatusOK {
+				return
+			}
+			// a progress object per line, the last finished
+			lines := strings.Split(strings.TrimSpace(res.Body.String()), "\n")
+			last := &protos.ImportProgress{}
+			if len(lines) != 3 || json.Unmarshal([]byte(lines[2]), last) != nil || last.Subscribed != 1 {
+				t.Errorf("APIHandler.OPML() progress = %v", lines)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/podcast"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// streamURLTTL is how long a signed stream url is valid for, long enough to finish any episode
+const streamURLTTL = 24 * time.Hour
+
+// streamHeaders are the headers of the enclosure's response passed through to the client
+var streamHeaders = []string{"Content-Type", "Content-Length", "Content-Range", "Accept-Ranges", "Last-Modified", "ETag"}
+
+// streamClient follows redirects, through tracking prefixes to the enclosure's host.
+// It has no timeout as the response is streamed for as long as the episode plays
+var streamClient = &http.Client{
+	Transport: &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		ResponseHeaderTimeout: 30 * time.Second,
+	},
+}
+
+// StreamSigner signs the urls of the stream proxy with expiring HMAC tokens,
+// so they can be given to players that can't authenticate such as Alexa
+type StreamSigner struct {
+	key     []byte
+	baseURL string
+}
+
+// NewStreamSigner creates a signer of urls under baseURL/stream/
+func NewStreamSigner(key []byte, baseURL string) *StreamSigner {
+	return &StreamSigner{key: key, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// URL returns the signed url streaming the episode's enclosure, valid until streamURLTTL from now
+func (s *StreamSigner) URL(epiID *protos.ObjectID, now time.Time) string {
+	expires := strconv.FormatInt(now.Add(streamURLTTL).Unix(), 10)
+	query := url.Values{"expires": {expires}, "sig": {s.sign(epiID.GetHex(), expires)}}
+	return s.baseURL + "/stream/" + epiID.GetHex() + "?" + query.Encode()
+}
+
+// Verify checks the signature of the episode's url and that it hasn't expired
+func (s *StreamSigner) Verify(epiHex, expires, sig string, now time.Time) bool {
+	exp, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || now.Unix() > exp {
+		return false
+	}
+	return hmac.Equal([]byte(sig), []byte(s.sign(epiHex, expires)))
+}
+
+func (s *StreamSigner) sign(epiHex, expires string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(epiHex + "." + expires))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// StreamHandler proxies episodes' enclosures through /stream/{episodeID}, so they are served over
+// our TLS endpoint whatever their host supports
+type StreamHandler struct {
+	dbClient db.Database
+	signer   *StreamSigner
+}
+
+// CreateStreamHandler instatiates a StreamHandler accepting urls signed by signer
+func CreateStreamHandler(dbClient db.Database, signer *StreamSigner) (*StreamHandler, error) {
+	return &StreamHandler{dbClient: dbClient, signer: signer}, nil
+}
+
+// ServeHTTP streams the enclosure of the episode of a signed url, passing through Range requests
+func (h *StreamHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		res.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	head, _ := ShiftPath(req.URL.Path)
+	query := req.URL.Query()
+	if head == "" || !h.signer.Verify(head, query.Get("expires"), query.Get("sig"), time.Now()) {
+		http.Error(res, "Invalid or expired url", http.StatusForbidden)
+		return
+	}
+	epi, err := podcast.FindEpisodeByID(h.dbClient, protos.ObjectIDFromHex(head))
+	if err != nil || epi.MP3URL == "" {
+		http.NotFound(res, req)
+		return
+	}
+
+	upstream, err := http.NewRequestWithContext(req.Context(), req.Method, epi.MP3URL, nil)
+	if err != nil {
+		log.Println("StreamHandler.ServeHTTP() error creating request:", err)
+		http.Error(res, "Invalid enclosure url", http.StatusBadGateway)
+		return
+	}
+	for _, header := range []string{"Range", "If-Range"} {
+		if v := req.Header.Get(header); v != "" {
+			upstream.Header.Set(header, v)
+		}
+	}
+	resp, err := streamClient.Do(upstream)
+	if err != nil {
+		log.Println("StreamHandler.ServeHTTP() error requesting enclosure:", err)
+		http.Error(res, "Enclosure unavailable", http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusPartialContent, http.StatusRequestedRangeNotSatisfiable:
+	default:
+		log.Printf("StreamHandler.ServeHTTP() enclosure %v responded: %s\n", epi.MP3URL, resp.Status)
+		http.Error(res, "Enclosure unavailable", http.StatusBadGateway)
+		return
+	}
+	for _, header := range streamHeaders {
+		if v := resp.Header.Get(header); v != "" {
+			res.Header().Set(header, v)
+		}
+	}
+	res.WriteHeader(resp.StatusCode)
+	if req.Method == http.MethodHead {
+		return
+	}
+	// the client going away cancels the request, ending the copy
+	io.Copy(res, resp.Body)
+}
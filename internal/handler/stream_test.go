@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sschwartz96/stockpile/mock"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+func TestStreamSigner(t *testing.T) {
+	signer := NewStreamSigner([]byte("secret"), "https://syncapod.com/")
+	now := time.Now()
+	epiID := protos.ObjectIDFromHex("5f150ca3519de1414331cfbe")
+	signed, err := url.Parse(signer.URL(epiID, now))
+	if err != nil {
+		t.Fatalf("StreamSigner.URL() error parsing url: %v", err)
+	}
+	if signed.Host != "syncapod.com" || signed.Path != "/stream/5f150ca3519de1414331cfbe" {
+		t.Errorf("StreamSigner.URL() = %v", signed)
+	}
+	expires, sig := signed.Query().Get("expires"), signed.Query().Get("sig")
+
+	tests := []struct {
+		name    string
+		epiHex  string
+		expires string
+		sig     string
+		now     time.Time
+		want    bool
+	}{
+		{name: "valid", epiHex: epiID.GetHex(), expires: expires, sig: sig, now: now, want: true},
+		{name: "expired", epiHex: epiID.GetHex(), expires: expires, sig: sig, now: now.Add(streamURLTTL + time.Minute), want: false},
+		{name: "other_episode", epiHex: "5f150ca3519de1414331cfbf", expires: expires, sig: sig, now: now, want: false},
+		{name: "extended", epiHex: epiID.GetHex(), expires: "99999999999", sig: sig, now: now, want: false},
+		{name: "unsigned", epiHex: epiID.GetHex(), expires: expires, sig: "", now: now, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := signer.Verify(tt.epiHex, tt.expires, tt.sig, tt.now); got != tt.want {
+				t.Errorf("StreamSigner.Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamHandler(t *testing.T) {
+	audio := bytes.Repeat([]byte("0123456789"), 1000)
+	upstream := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		switch {
+		case strings.HasPrefix(req.URL.Path, "/track/"):
+			// a tracking prefix redirecting to the enclosure
+			http.Redirect(res, req, strings.TrimPrefix(req.URL.Path, "/track"), http.StatusFound)
+		case req.URL.Path == "/audio.mp3":
+			res.Header().Set("Content-Type", "audio/mpeg")
+			res.Header().Set("Set-Cookie", "tracking=1")
+			http.ServeContent(res, req, "", time.Time{}, bytes.NewReader(audio))
+		default:
+			http.Error(res, "broken", http.StatusInternalServerError)
+		}
+	}))
+	defer upstream.Close()
+
+	mockDB := mock.CreateDB()
+	epi := &protos.Episode{Id: protos.NewObjectID(), MP3URL: upstream.URL + "/track/audio.mp3"}
+	broken := &protos.Episode{Id: protos.NewObjectID(), MP3URL: upstream.URL + "/broken.mp3"}
+	for _, e := range []*protos.Episode{epi, broken} {
+		err := mockDB.Insert(database.ColEpisode, e)
+		if err != nil {
+			t.Fatalf("TestStreamHandler() error inserting episode: %v", err)
+		}
+	}
+	signer := NewStreamSigner([]byte("secret"), "https://syncapod.com")
+	handler, _ := CreateStreamHandler(mockDB, signer)
+
+	tests := []struct {
+		name       string
+		url        string
+		rangeHdr   string
+		wantStatus int
+		wantBody   []byte
+		wantRange  string
+	}{
+		{name: "whole", url: signer.URL(epi.Id, time.Now()), wantStatus: http.StatusOK, wantBody: audio},
+		{name: "range", url: signer.URL(epi.Id, time.Now()), rangeHdr: "bytes=5-14", wantStatus: http.StatusPartialContent,
+			wantBody: []byte("5678901234"), wantRange: "bytes 5-14/10000"},
+		{name: "unsatisfiable", url: signer.URL(epi.Id, time.Now()), rangeHdr: "bytes=20000-", wantStatus: http.StatusRequestedRangeNotSatisfiable},
+		{name: "expired", url: signer.URL(epi.Id, time.Now().Add(-2*streamURLTTL)), wantStatus: http.StatusForbidden},
+		{name: "unsigned", url: "https://syncapod.com/stream/" + epi.Id.GetHex(), wantStatus: http.StatusForbidden},
+		{name: "unknown_episode", url: signer.URL(protos.NewObjectID(), time.Now()), wantStatus: http.StatusNotFound},
+		{name: "enclosure_unavailable", url: signer.URL(broken.Id, time.Now()), wantStatus: http.StatusBadGateway},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.rangeHdr != "" {
+				req.Header.Set("Range", tt.rangeHdr)
+			}
+			// the main handler shifts off /stream
+			_, req.URL.Path = ShiftPath(req.URL.Path)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("StreamHandler.ServeHTTP() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+			if tt.wantBody != nil && !bytes.Equal(rec.Body.Bytes(), tt.wantBody) {
+				t.Errorf("StreamHandler.ServeHTTP() body of %v bytes, want %v bytes", rec.Body.Len(), len(tt.wantBody))
+			}
+			if got := rec.Header().Get("Content-Range"); tt.wantRange != "" && got != tt.wantRange {
+				t.Errorf("StreamHandler.ServeHTTP() Content-Range = %v, want %v", got, tt.wantRange)
+			}
+			if rec.Header().Get("Set-Cookie") != "" {
+				t.Errorf("StreamHandler.ServeHTTP() passed through the enclosure's cookies")
+			}
+		})
+	}
+}
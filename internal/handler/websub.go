@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/sschwartz96/syncapod/internal/podcast"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// WebSubHandler handles the callbacks of WebSub hubs through /websub/{podcastID}
+type WebSubHandler struct {
+	webSub *podcast.WebSub
+}
+
+// CreateWebSubHandler instatiates a WebSubHandler
+func CreateWebSubHandler(webSub *podcast.WebSub) (*WebSubHandler, error) {
+	return &WebSubHandler{webSub: webSub}, nil
+}
+
+// ServeHTTP handles the hubs' verifications of intent (GET) and pushed content (POST)
+func (h *WebSubHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	var head string
+	head, req.URL.Path = ShiftPath(req.URL.Path)
+	if head == "" {
+		res.WriteHeader(http.StatusNotFound)
+		return
+	}
+	podID := protos.ObjectIDFromHex(head)
+
+	switch req.Method {
+	case http.MethodGet:
+		h.verify(res, req, podID)
+	case http.MethodPost:
+		h.receive(res, req, podID)
+	default:
+		res.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *WebSubHandler) verify(res http.ResponseWriter, req *http.Request, podID *protos.ObjectID) {
+	query := req.URL.Query()
+	lease, _ := strconv.Atoi(query.Get("hub.lease_seconds"))
+	challenge, err := h.webSub.Verify(podID, query.Get("hub.mode"), query.Get("hub.topic"), query.Get("hub.challenge"), lease)
+	if err != nil {
+		log.Println("WebSubHandler.verify() error:", err)
+		res.WriteHeader(http.StatusNotFound)
+		return
+	}
+	res.Write([]byte(challenge))
+}
+
+func (h *WebSubHandler) receive(res http.ResponseWriter, req *http.Request, podID *protos.ObjectID) {
+	body, err := ioutil.ReadAll(io.LimitReader(req.Body, podcast.MaxPushBody))
+	if err != nil {
+		log.Println("WebSubHandler.receive() error reading body:", err)
+		res.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = h.webSub.Receive(podID, body, req.Header.Get("X-Hub-Signature"))
+	switch err {
+	case nil:
+	case podcast.ErrWebSubNotSubscribed:
+		// tells the hub to stop pushing
+		res.WriteHeader(http.StatusGone)
+		return
+	default:
+		// acknowledged either way, the feed is still polled as a fallback
+		log.Printf("WebSubHandler.receive() error receiving podcast %v: %v\n", podID.GetHex(), err)
+	}
+	res.WriteHeader(http.StatusOK)
+}
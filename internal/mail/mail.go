@@ -0,0 +1,20 @@
+package mail
+
+import (
+	"log"
+)
+
+// Mailer sends plain text emails
+type Mailer interface {
+	// Send sends the email to the address
+	Send(to, subject, body string) error
+}
+
+// LogMailer logs the emails instead of sending them, used when no smtp server is configured
+type LogMailer struct{}
+
+// Send logs the email
+func (LogMailer) Send(to, subject, body string) error {
+	log.Printf("email to %s: %s\n%s\n", to, subject, body)
+	return nil
+}
@@ -0,0 +1,62 @@
+package mail
+
+import (
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SMTPMailer sends emails through an smtp server, upgrading to tls if the server supports it
+type SMTPMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPMailer creates a mailer sending from the address through the smtp server at host:port,
+// authenticating if a username is given
+func NewSMTPMailer(host string, port int, username, password, from string) (*SMTPMailer, error) {
+	if _, err := mail.ParseAddress(from); err != nil {
+		return nil, fmt.Errorf("NewSMTPMailer() error parsing from address: %v", err)
+	}
+	m := &SMTPMailer{addr: net.JoinHostPort(host, strconv.Itoa(port)), from: from}
+	if username != "" {
+		m.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return m, nil
+}
+
+// Send sends the email
+func (m *SMTPMailer) Send(to, subject, body string) error {
+	toAddr, err := mail.ParseAddress(to)
+	if err != nil {
+		return fmt.Errorf("Send() error parsing to address: %v", err)
+	}
+	fromAddr, _ := mail.ParseAddress(m.from)
+	err = smtp.SendMail(m.addr, m.auth, fromAddr.Address, []string{toAddr.Address}, m.message(toAddr, subject, body))
+	if err != nil {
+		return fmt.Errorf("Send() error: %v", err)
+	}
+	return nil
+}
+
+// message formats the email with its headers and crlf line endings
+func (m *SMTPMailer) message(to *mail.Address, subject, body string) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + m.from + "\r\n")
+	b.WriteString("To: " + to.String() + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
@@ -0,0 +1,175 @@
+package mail
+
+import (
+	"encoding/base64"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// fakeMessage is an email received by the fake smtp server
+type fakeMessage struct {
+	auth string
+	from string
+	to   []string
+	data string
+}
+
+// fakeSMTPServer starts an smtp server on localhost that accepts every email, advertising auth if withAuth,
+// returning its address and the emails it received once each connection closes
+func fakeSMTPServer(t *testing.T, withAuth bool) (string, <-chan *fakeMessage) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("fakeSMTPServer() error listening: %v", err)
+	}
+	t.Cleanup(func() { l.Close() })
+	messages := make(chan *fakeMessage, 10)
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go serveFakeSMTP(conn, withAuth, messages)
+		}
+	}()
+	return l.Addr().String(), messages
+}
+
+func serveFakeSMTP(conn net.Conn, withAuth bool, messages chan<- *fakeMessage) {
+	defer conn.Close()
+	text := textproto.NewConn(conn)
+	msg := &fakeMessage{}
+	text.PrintfLine("220 localhost fake smtp")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch cmd {
+		case "EHLO", "HELO":
+			if withAuth {
+				text.PrintfLine("250-localhost")
+				text.PrintfLine("250 AUTH PLAIN")
+			} else {
+				text.PrintfLine("250 localhost")
+			}
+		case "AUTH":
+			// AUTH PLAIN <base64 \x00user\x00pass>
+			fields := strings.Fields(line)
+			if len(fields) == 3 {
+				creds, _ := base64.StdEncoding.DecodeString(fields[2])
+				msg.auth = string(creds)
+			}
+			text.PrintfLine("235 authenticated")
+		case "MAIL":
+			msg.from = angleAddr(line)
+			text.PrintfLine("250 ok")
+		case "RCPT":
+			msg.to = append(msg.to, angleAddr(line))
+			text.PrintfLine("250 ok")
+		case "DATA":
+			text.PrintfLine("354 go ahead")
+			lines, err := text.ReadDotLines()
+			if err != nil {
+				return
+			}
+			msg.data = strings.Join(lines, "\n")
+			text.PrintfLine("250 queued")
+			messages <- msg
+			msg = &fakeMessage{}
+		case "QUIT":
+			text.PrintfLine("221 bye")
+			return
+		default:
+			text.PrintfLine("250 ok")
+		}
+	}
+}
+
+// angleAddr returns the address between the angle brackets of a MAIL or RCPT command
+func angleAddr(line string) string {
+	start, end := strings.Index(line, "<"), strings.Index(line, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return line[start+1 : end]
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		to       string
+		subject  string
+		body     string
+		wantAuth string
+		wantData []string
+		wantErr  bool
+	}{
+		{
+			name:     "no_auth",
+			to:       "user@example.com",
+			subject:  "Verify your email",
+			body:     "line one\nline two",
+			wantData: []string{"From: syncapod <noreply@syncapod.com>", "To: <user@example.com>", "Subject: Verify your email", "\nline one\nline two"},
+		},
+		{
+			name:     "auth",
+			username: "smtp_user",
+			to:       "Some User <user@example.com>",
+			subject:  "Reset your password",
+			body:     "reset",
+			wantAuth: "\x00smtp_user\x00smtp_pass",
+			wantData: []string{"To: \"Some User\" <user@example.com>", "Subject: Reset your password"},
+		},
+		{
+			name:     "header_injection",
+			to:       "user@example.com",
+			subject:  "hi\r\nBcc: victim@example.com",
+			body:     "body",
+			wantData: []string{"Subject: =?utf-8?q?hi=0D=0ABcc:_victim@example.com?="},
+		},
+		{
+			name:    "invalid_to",
+			to:      "not an address",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, messages := fakeSMTPServer(t, tt.username != "")
+			host, port, _ := net.SplitHostPort(addr)
+			portNum, _ := strconv.Atoi(port)
+			m, err := NewSMTPMailer(host, portNum, tt.username, "smtp_pass", "syncapod <noreply@syncapod.com>")
+			if err != nil {
+				t.Fatalf("NewSMTPMailer() error = %v", err)
+			}
+			err = m.Send(tt.to, tt.subject, tt.body)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SMTPMailer.Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			msg := <-messages
+			if msg.from != "noreply@syncapod.com" || len(msg.to) != 1 || msg.to[0] != "user@example.com" {
+				t.Errorf("SMTPMailer.Send() from = %v, to = %v", msg.from, msg.to)
+			}
+			if msg.auth != tt.wantAuth {
+				t.Errorf("SMTPMailer.Send() auth = %q, want %q", msg.auth, tt.wantAuth)
+			}
+			for _, want := range tt.wantData {
+				if !strings.Contains(msg.data, want) {
+					t.Errorf("SMTPMailer.Send() data = %q, want it to contain %q", msg.data, want)
+				}
+			}
+			if strings.Contains(msg.data, "\nBcc:") {
+				t.Errorf("SMTPMailer.Send() data = %q, injected a header", msg.data)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// Purposes of user tokens
+const (
+	PurposeVerifyEmail   = "verify_email"
+	PurposeResetPassword = "reset_password"
+)
+
+// UserToken is a single use token emailed to a user, only its hash is stored
+type UserToken struct {
+	Hash    string           `json:"hash" bson:"hash"` // hex encoded sha256 of the token
+	UserID  *protos.ObjectID `json:"user_id" bson:"user_id"`
+	Purpose string           `json:"purpose" bson:"purpose"`
+	Email   string           `json:"email" bson:"email"` // the address it was sent to
+	Expires time.Time        `json:"expires" bson:"expires"`
+}
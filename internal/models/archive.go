@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// ArchivedEpisode is the copy of an episode's enclosure kept in the archive's blob store
+type ArchivedEpisode struct {
+	EpisodeID  *protos.ObjectID `json:"episode_id" bson:"episode_id"`
+	PodcastID  *protos.ObjectID `json:"podcast_id" bson:"podcast_id"`
+	Key        string           `json:"key" bson:"key"` // key of the blob
+	URL        string           `json:"url" bson:"url"` // url of the enclosure archived
+	Type       string           `json:"type" bson:"type"`
+	Size       int64            `json:"size" bson:"size"`
+	SHA256     string           `json:"sha256" bson:"sha256"` // hex encoded checksum of the blob
+	ArchivedAt time.Time        `json:"archived_at" bson:"archived_at"`
+}
+
+// Retention is which episodes of a podcast are kept in the archive
+type Retention struct {
+	PodcastID  *protos.ObjectID `json:"podcast_id" bson:"podcast_id"`
+	KeepLatest int              `json:"keep_latest" bson:"keep_latest"`   // number of latest episodes kept, 0 keeps none
+	MaxAgeDays int              `json:"max_age_days" bson:"max_age_days"` // episodes published longer ago are not kept, 0 for no limit
+}
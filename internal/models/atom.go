@@ -0,0 +1,50 @@
+package models
+
+// AtomFeed is the container of an Atom 1.0 feed
+type AtomFeed struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Subtitle   string         `xml:"subtitle"`
+	Updated    string         `xml:"updated"`
+	Icon       string         `xml:"icon"`
+	Logo       string         `xml:"logo"`
+	Authors    []AtomPerson   `xml:"author"`
+	Links      []AtomLink     `xml:"link"`
+	Categories []AtomCategory `xml:"category"`
+	Entries    []AtomEntry    `xml:"entry"`
+}
+
+// AtomEntry is a single entry of an Atom feed
+type AtomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published"`
+	Summary    string         `xml:"summary"`
+	Content    string         `xml:"content"`
+	Authors    []AtomPerson   `xml:"author"`
+	Links      []AtomLink     `xml:"link"`
+	Categories []AtomCategory `xml:"category"`
+	Duration   string         `xml:"duration"` // itunes:duration is often included by podcast feeds
+}
+
+// AtomLink is an Atom link, episodes' media are links with rel="enclosure"
+type AtomLink struct {
+	Rel    string `xml:"rel,attr"`
+	Href   string `xml:"href,attr"`
+	Type   string `xml:"type,attr"`
+	Length string `xml:"length,attr"`
+}
+
+// AtomPerson is an author or contributor of an Atom feed or entry
+type AtomPerson struct {
+	Name  string `xml:"name"`
+	Email string `xml:"email"`
+	URI   string `xml:"uri"`
+}
+
+// AtomCategory is a category of an Atom feed or entry
+type AtomCategory struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr"`
+}
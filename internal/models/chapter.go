@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// ChapterJob is a queued extraction of the chapters embedded in an episode's enclosure
+type ChapterJob struct {
+	EpisodeID *protos.ObjectID `json:"episode_id" bson:"episode_id"`
+	URL       string           `json:"url" bson:"url"`
+	Type      string           `json:"type" bson:"type"` // mime type of the enclosure given by the feed
+	Attempts  int              `json:"attempts" bson:"attempts"`
+	Due       time.Time        `json:"due" bson:"due"`
+}
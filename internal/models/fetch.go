@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// FetchState holds the http caching information of the last time a podcast's feed was fetched
+type FetchState struct {
+	PodcastID    *protos.ObjectID `json:"podcast_id" bson:"podcast_id"`
+	ETag         string           `json:"etag" bson:"etag"`
+	LastModified string           `json:"last_modified" bson:"last_modified"`
+	LastStatus   int              `json:"last_status" bson:"last_status"`
+	ContentHash  string           `json:"content_hash" bson:"content_hash"`
+	LastSuccess  time.Time        `json:"last_success" bson:"last_success"`
+	MovedTo      string           `json:"moved_to" bson:"moved_to"` // location of the last fetch's permanent redirect
+	// refresh hints given by the feed itself
+	TTL             int    `json:"ttl" bson:"ttl"`
+	UpdatePeriod    string `json:"update_period" bson:"update_period"`
+	UpdateFrequency int    `json:"update_frequency" bson:"update_frequency"`
+}
+
+// PreviousFeed is a feed url a podcast has moved away from
+type PreviousFeed struct {
+	URL       string           `json:"url" bson:"url"`
+	PodcastID *protos.ObjectID `json:"podcast_id" bson:"podcast_id"`
+	MovedAt   time.Time        `json:"moved_at" bson:"moved_at"`
+}
+
+// FeedPage is a page of a paged or archived feed (RFC 5005) whose episodes have been imported
+type FeedPage struct {
+	URL        string           `json:"url" bson:"url"`
+	PodcastID  *protos.ObjectID `json:"podcast_id" bson:"podcast_id"`
+	Archive    bool             `json:"archive" bson:"archive"`
+	ConsumedAt time.Time        `json:"consumed_at" bson:"consumed_at"`
+}
+
+// FeedCredential is the encrypted url & credentials a user fetches their private feed with
+type FeedCredential struct {
+	UserID    *protos.ObjectID `json:"user_id" bson:"user_id"`
+	PodcastID *protos.ObjectID `json:"podcast_id" bson:"podcast_id"`
+	Sealed    []byte           `json:"sealed" bson:"sealed"` // AES-GCM nonce followed by the encrypted FeedAuth
+	CreatedAt time.Time        `json:"created_at" bson:"created_at"`
+}
+
+// FeedAuth is the decrypted url & optional basic auth of a private feed, it is never stored as is
+type FeedAuth struct {
+	URL      string `json:"url"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
@@ -0,0 +1,49 @@
+package models
+
+// JSONFeed is the container of a JSON Feed 1.1 feed
+type JSONFeed struct {
+	Version     string           `json:"version"`
+	Title       string           `json:"title"`
+	HomePageURL string           `json:"home_page_url"`
+	FeedURL     string           `json:"feed_url"`
+	Description string           `json:"description"`
+	Icon        string           `json:"icon"`
+	Favicon     string           `json:"favicon"`
+	Language    string           `json:"language"`
+	Authors     []JSONFeedAuthor `json:"authors"`
+	Author      *JSONFeedAuthor  `json:"author"` // deprecated in 1.1 in favor of authors
+	Items       []JSONFeedItem   `json:"items"`
+}
+
+// JSONFeedItem is a single item of a JSON Feed
+type JSONFeedItem struct {
+	ID            string               `json:"id"`
+	URL           string               `json:"url"`
+	Title         string               `json:"title"`
+	ContentHTML   string               `json:"content_html"`
+	ContentText   string               `json:"content_text"`
+	Summary       string               `json:"summary"`
+	Image         string               `json:"image"`
+	DatePublished string               `json:"date_published"`
+	DateModified  string               `json:"date_modified"`
+	Authors       []JSONFeedAuthor     `json:"authors"`
+	Author        *JSONFeedAuthor      `json:"author"`
+	Tags          []string             `json:"tags"`
+	Attachments   []JSONFeedAttachment `json:"attachments"`
+}
+
+// JSONFeedAuthor is the author of a JSON Feed or item
+type JSONFeedAuthor struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Avatar string `json:"avatar"`
+}
+
+// JSONFeedAttachment is a related resource of an item, podcasts' episodes are audio attachments
+type JSONFeedAttachment struct {
+	URL               string  `json:"url"`
+	MimeType          string  `json:"mime_type"`
+	Title             string  `json:"title"`
+	SizeInBytes       int64   `json:"size_in_bytes"`
+	DurationInSeconds float64 `json:"duration_in_seconds"`
+}
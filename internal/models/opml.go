@@ -0,0 +1,59 @@
+package models
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// OPML is an outline document, used to move subscriptions between podcast apps
+type OPML struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    OPMLHead `xml:"head"`
+	Body    OPMLBody `xml:"body"`
+}
+
+// OPMLHead holds the metadata of an OPML document
+type OPMLHead struct {
+	Title       string `xml:"title,omitempty"`
+	DateCreated string `xml:"dateCreated,omitempty"` // RFC 822
+}
+
+// OPMLBody holds the top level outlines
+type OPMLBody struct {
+	Outlines []OPMLOutline `xml:"outline"`
+}
+
+// OPMLOutline is a feed if it has an xmlUrl, otherwise a folder of nested outlines
+type OPMLOutline struct {
+	Text     string        `xml:"text,attr"`
+	Title    string        `xml:"title,attr,omitempty"`
+	Type     string        `xml:"type,attr,omitempty"`
+	XMLURL   string        `xml:"xmlUrl,attr,omitempty"`
+	HTMLURL  string        `xml:"htmlUrl,attr,omitempty"`
+	Outlines []OPMLOutline `xml:"outline"`
+}
+
+// UnmarshalXML decodes the outline's attributes case insensitively, as some apps write xmlURL or xmlurl
+func (o *OPMLOutline) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		switch strings.ToLower(attr.Name.Local) {
+		case "text":
+			o.Text = attr.Value
+		case "title":
+			o.Title = attr.Value
+		case "type":
+			o.Type = attr.Value
+		case "xmlurl":
+			o.XMLURL = attr.Value
+		case "htmlurl":
+			o.HTMLURL = attr.Value
+		}
+	}
+	var children struct {
+		Outlines []OPMLOutline `xml:"outline"`
+	}
+	err := d.DecodeElement(&children, &start)
+	o.Outlines = children.Outlines
+	return err
+}
@@ -1,79 +1,200 @@
-package models
-
-import (
-	"go.mongodb.org/mongo-driver/bson/primitive"
-)
-
-// RSSFeed is the container to hold all the podcast info
-type RSSFeed struct {
-	RSSPodcast RSSPodcast `xml:"channel"`
-}
-
-// RSSPodcast holds podcast info from the rss feed
-type RSSPodcast struct {
-	ID            primitive.ObjectID `json:"_id"  bson:"_id"`
-	Title         string             `json:"title"  bson:"title"  xml:"title"`
-	Author        string             `json:"author"  bson:"author"  xml:"author"`
-	Type          string             `json:"type"  bson:"type"  xml:"type"`
-	Subtitle      string             `json:"subtitle"  bson:"subtitle"  xml:"subtitle"`
-	Summary       string             `json:"summary"  bson:"summary"  xml:"summary"`
-	Link          string             `json:"link"  bson:"link"  xml:"Default link"`
-	Image         Image              `json:"image"  bson:"image"  xml:"image"`
-	Explicit      string             `json:"explicit"  bson:"explicit"  xml:"explicit"`
-	Language      string             `json:"locale"  bson:"locale"  xml:"language"`
-	Keywords      string             `json:"keywords"  bson:"keywords"  xml:"keywords"`
-	Category      []Category         `json:"category"  bson:"category"  xml:"category"`
-	PubDate       string             `json:"pubdate"  bson:"pubdate"  xml:"pubDate"`
-	LastBuildDate string             `json:"last_build_date"  bson:"last_build_date"  xml:"lastBuildDate"`
-	RSSEpisodes   []RSSEpisode       `json:"episodes"  bson:"episodes"  xml:"item"`
-	NewFeedURL    string             `json:"new_feed_url"  bson:"new_feed_url"  xml:"new-feed-url"`
-	RSS           string             `json:"rss"  bson:"rss"`
-}
-
-// RSSEpisode holds information about a single episode of a podcast within the rss feed
-type RSSEpisode struct {
-	ID          primitive.ObjectID `json:"_id"  bson:"_id"  xml:"id"`
-	PodcastID   primitive.ObjectID `json:"podcast_id"  bson:"podcast_id"`
-	Title       string             `json:"title"  bson:"title"  xml:"title"`
-	Subtitle    string             `json:"subtitle" bson:"subtitle" xml:"subtitle"`
-	Author      string             `json:"author"  bson:"author"  xml:"author"`
-	Type        string             `json:"type"  bson:"type"  xml:"type"`
-	Image       EpiImage           `json:"image"  bson:"image"  xml:"image"`
-	Thumbnail   EpiThumbnail       `json:"thumbnail"  bson:"thumbnail"  xml:"content>thumbnail"`
-	PubDate     string             `json:"pub_date"  bson:"pub_date"  xml:"pubDate"`
-	Description string             `json:"description"  bson:"description"  xml:"description"`
-	Summary     string             `json:"summary"  bson:"summary"  xml:"summary"`
-	Season      int                `json:"season"  bson:"season"  xml:"season"`
-	Episode     int                `json:"episode"  bson:"episode"  xml:"episode"`
-	Category    []Category         `json:"category"  bson:"category"  xml:"category"`
-	Explicit    string             `json:"explicit"  bson:"explicit"  xml:"explicit"`
-	Enclosure   Enclosure          `json:"enclosure" bson:"enclosure" xml:"enclosure"`
-	Duration    string             `json:"duration" bson:"duration" xml:"duration"`
-}
-
-// Enclosure represents enclosure xml object that contains mp3 data
-type Enclosure struct {
-	MP3 string `json:"mp3" bson:"mp3" xml:"url,attr"`
-}
-
-// Category contains the main category and secondary categories
-type Category struct {
-	Text     string     `xml:"text,attr" json:"text"`
-	Category []Category `xml:"category" json:"category"`
-}
-
-// Image is the RSS image container
-type Image struct {
-	Title string `json:"title"  bson:"title"  xml:"title"`
-	URL   string `json:"url"  bson:"url"  xml:"url"`
-}
-
-// EpiImage is the image container for episodes
-type EpiImage struct {
-	HREF string `xml:"href,attr"`
-}
-
-// EpiThumbnail is the thumbnail container for rss episodes
-type EpiThumbnail struct {
-	URL string `xml:"url,attr"`
-}
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RSSFeed is the container to hold all the podcast info
+type RSSFeed struct {
+	RSSPodcast RSSPodcast `xml:"channel"`
+}
+
+// RSSPodcast holds podcast info from the rss feed
+type RSSPodcast struct {
+	ID            primitive.ObjectID `json:"_id"  bson:"_id"`
+	Title         string             `json:"title"  bson:"title"  xml:"title"`
+	Author        string             `json:"author"  bson:"author"  xml:"author"`
+	Type          string             `json:"type"  bson:"type"  xml:"type"`
+	Subtitle      string             `json:"subtitle"  bson:"subtitle"  xml:"subtitle"`
+	Summary       string             `json:"summary"  bson:"summary"  xml:"summary"`
+	Link          string             `json:"link"  bson:"link"  xml:"Default link"`
+	Image         Image              `json:"image"  bson:"image"  xml:"image"`
+	Explicit      string             `json:"explicit"  bson:"explicit"  xml:"explicit"`
+	Language      string             `json:"locale"  bson:"locale"  xml:"language"`
+	Keywords      string             `json:"keywords"  bson:"keywords"  xml:"keywords"`
+	Category      []Category         `json:"category"  bson:"category"  xml:"category"`
+	PubDate       string             `json:"pubdate"  bson:"pubdate"  xml:"pubDate"`
+	LastBuildDate string             `json:"last_build_date"  bson:"last_build_date"  xml:"lastBuildDate"`
+	RSSEpisodes   []RSSEpisode       `json:"episodes"  bson:"episodes"  xml:"item"`
+	NewFeedURL    string             `json:"new_feed_url"  bson:"new_feed_url"  xml:"new-feed-url"`
+	AtomLinks     []AtomLink         `json:"atom_links"  bson:"atom_links"  xml:"http://www.w3.org/2005/Atom link"` // paged & archived feed links (RFC 5005)
+	RSS           string             `json:"rss"  bson:"rss"`
+	// refresh hints: rss <ttl> in minutes & syndication module <sy:updatePeriod>/<sy:updateFrequency>
+	TTL             int    `json:"ttl"  bson:"ttl"  xml:"ttl"`
+	UpdatePeriod    string `json:"update_period"  bson:"update_period"  xml:"updatePeriod"`
+	UpdateFrequency int    `json:"update_frequency"  bson:"update_frequency"  xml:"updateFrequency"`
+	// podcasting 2.0 namespace
+	GUID     string    `json:"guid"  bson:"guid"  xml:"https://podcastindex.org/namespace/1.0 guid"`
+	Locked   Locked    `json:"locked"  bson:"locked"  xml:"https://podcastindex.org/namespace/1.0 locked"`
+	Persons  []Person  `json:"persons"  bson:"persons"  xml:"https://podcastindex.org/namespace/1.0 person"`
+	Funding  []Funding `json:"funding"  bson:"funding"  xml:"https://podcastindex.org/namespace/1.0 funding"`
+	Location *Location `json:"location"  bson:"location"  xml:"https://podcastindex.org/namespace/1.0 location"`
+	Value    *Value    `json:"value"  bson:"value"  xml:"https://podcastindex.org/namespace/1.0 value"`
+}
+
+// RSSEpisode holds information about a single episode of a podcast within the rss feed
+type RSSEpisode struct {
+	ID          primitive.ObjectID `json:"_id"  bson:"_id"  xml:"id"`
+	PodcastID   primitive.ObjectID `json:"podcast_id"  bson:"podcast_id"`
+	Title       string             `json:"title"  bson:"title"  xml:"title"`
+	Subtitle    string             `json:"subtitle" bson:"subtitle" xml:"subtitle"`
+	Author      string             `json:"author"  bson:"author"  xml:"author"`
+	Type        string             `json:"type"  bson:"type"  xml:"type"`
+	Image       EpiImage           `json:"image"  bson:"image"  xml:"image"`
+	Thumbnail   EpiThumbnail       `json:"thumbnail"  bson:"thumbnail"  xml:"content>thumbnail"`
+	PubDate     string             `json:"pub_date"  bson:"pub_date"  xml:"pubDate"`
+	Description string             `json:"description"  bson:"description"  xml:"description"`
+	Summary     string             `json:"summary"  bson:"summary"  xml:"summary"`
+	PodSeason   *Season            `json:"pod_season"  bson:"pod_season"  xml:"https://podcastindex.org/namespace/1.0 season"` // must come before Season to receive <podcast:season>
+	Season      int                `json:"season"  bson:"season"  xml:"season"`
+	Episode     int                `json:"episode"  bson:"episode"  xml:"episode"`
+	Category    []Category         `json:"category"  bson:"category"  xml:"category"`
+	Explicit    string             `json:"explicit"  bson:"explicit"  xml:"explicit"`
+	Enclosure   Enclosure          `json:"enclosure" bson:"enclosure" xml:"enclosure"`
+	Duration    string             `json:"duration" bson:"duration" xml:"duration"`
+	GUID        string             `json:"guid" bson:"guid" xml:"guid"`
+	// podcasting 2.0 namespace
+	Transcripts         []Transcript         `json:"transcripts"  bson:"transcripts"  xml:"https://podcastindex.org/namespace/1.0 transcript"`
+	Chapters            *Chapters            `json:"chapters"  bson:"chapters"  xml:"https://podcastindex.org/namespace/1.0 chapters"`
+	Persons             []Person             `json:"persons"  bson:"persons"  xml:"https://podcastindex.org/namespace/1.0 person"`
+	Soundbites          []Soundbite          `json:"soundbites"  bson:"soundbites"  xml:"https://podcastindex.org/namespace/1.0 soundbite"`
+	Location            *Location            `json:"location"  bson:"location"  xml:"https://podcastindex.org/namespace/1.0 location"`
+	Value               *Value               `json:"value"  bson:"value"  xml:"https://podcastindex.org/namespace/1.0 value"`
+	AlternateEnclosures []AlternateEnclosure `json:"alternate_enclosures"  bson:"alternate_enclosures"  xml:"https://podcastindex.org/namespace/1.0 alternateEnclosure"`
+}
+
+// Enclosure represents enclosure xml object that contains mp3 data
+type Enclosure struct {
+	MP3    string `json:"mp3" bson:"mp3" xml:"url,attr"`
+	Type   string `json:"type" bson:"type" xml:"type,attr"`
+	Length string `json:"length" bson:"length" xml:"length,attr"`
+}
+
+// Category contains the main category and secondary categories
+type Category struct {
+	Text     string     `xml:"text,attr" json:"text"`
+	Category []Category `xml:"category" json:"category"`
+}
+
+// Image is the RSS image container
+type Image struct {
+	Title string `json:"title"  bson:"title"  xml:"title"`
+	URL   string `json:"url"  bson:"url"  xml:"url"`
+}
+
+// EpiImage is the image container for episodes
+type EpiImage struct {
+	HREF string `xml:"href,attr"`
+}
+
+// EpiThumbnail is the thumbnail container for rss episodes
+type EpiThumbnail struct {
+	URL string `xml:"url,attr"`
+}
+
+// PodcastNamespace is the xml namespace of the podcasting 2.0 tags
+const PodcastNamespace = "https://podcastindex.org/namespace/1.0"
+
+// Locked is <podcast:locked>, "yes" if the podcast may not be imported to other platforms
+type Locked struct {
+	Owner  string `json:"owner" bson:"owner" xml:"owner,attr"`
+	Locked string `json:"locked" bson:"locked" xml:",chardata"`
+}
+
+// Person is <podcast:person>, someone involved with the podcast or episode
+type Person struct {
+	Name  string `json:"name" bson:"name" xml:",chardata"`
+	Role  string `json:"role" bson:"role" xml:"role,attr"`
+	Group string `json:"group" bson:"group" xml:"group,attr"`
+	Img   string `json:"img" bson:"img" xml:"img,attr"`
+	HREF  string `json:"href" bson:"href" xml:"href,attr"`
+}
+
+// Funding is <podcast:funding>, a link to donate or support the podcast
+type Funding struct {
+	URL  string `json:"url" bson:"url" xml:"url,attr"`
+	Text string `json:"text" bson:"text" xml:",chardata"`
+}
+
+// Location is <podcast:location>, what the podcast or episode is about or where it was recorded
+type Location struct {
+	Name string `json:"name" bson:"name" xml:",chardata"`
+	Geo  string `json:"geo" bson:"geo" xml:"geo,attr"`
+	OSM  string `json:"osm" bson:"osm" xml:"osm,attr"`
+}
+
+// Value is <podcast:value>, how listeners can pay the recipients while listening
+type Value struct {
+	Type       string           `json:"type" bson:"type" xml:"type,attr"`
+	Method     string           `json:"method" bson:"method" xml:"method,attr"`
+	Suggested  string           `json:"suggested" bson:"suggested" xml:"suggested,attr"`
+	Recipients []ValueRecipient `json:"recipients" bson:"recipients" xml:"https://podcastindex.org/namespace/1.0 valueRecipient"`
+}
+
+// ValueRecipient is <podcast:valueRecipient>, a single recipient of a payment split
+type ValueRecipient struct {
+	Name        string `json:"name" bson:"name" xml:"name,attr"`
+	CustomKey   string `json:"custom_key" bson:"custom_key" xml:"customKey,attr"`
+	CustomValue string `json:"custom_value" bson:"custom_value" xml:"customValue,attr"`
+	Type        string `json:"type" bson:"type" xml:"type,attr"`
+	Address     string `json:"address" bson:"address" xml:"address,attr"`
+	Split       string `json:"split" bson:"split" xml:"split,attr"`
+	Fee         string `json:"fee" bson:"fee" xml:"fee,attr"`
+}
+
+// Transcript is <podcast:transcript>, a link to the episode's transcript
+type Transcript struct {
+	URL      string `json:"url" bson:"url" xml:"url,attr"`
+	Type     string `json:"type" bson:"type" xml:"type,attr"`
+	Language string `json:"language" bson:"language" xml:"language,attr"`
+	Rel      string `json:"rel" bson:"rel" xml:"rel,attr"`
+}
+
+// Chapters is <podcast:chapters>, a link to the episode's json chapters file
+type Chapters struct {
+	URL  string `json:"url" bson:"url" xml:"url,attr"`
+	Type string `json:"type" bson:"type" xml:"type,attr"`
+}
+
+// Season is <podcast:season>, the season number and its name
+type Season struct {
+	Number int    `json:"number" bson:"number" xml:",chardata"`
+	Name   string `json:"name" bson:"name" xml:"name,attr"`
+}
+
+// Soundbite is <podcast:soundbite>, a highlight of the episode, times are in seconds
+type Soundbite struct {
+	StartTime string `json:"start_time" bson:"start_time" xml:"startTime,attr"`
+	Duration  string `json:"duration" bson:"duration" xml:"duration,attr"`
+	Title     string `json:"title" bson:"title" xml:",chardata"`
+}
+
+// AlternateEnclosure is <podcast:alternateEnclosure>, another version of the episode's media
+type AlternateEnclosure struct {
+	Type    string   `json:"type" bson:"type" xml:"type,attr"`
+	Length  string   `json:"length" bson:"length" xml:"length,attr"`
+	Bitrate string   `json:"bitrate" bson:"bitrate" xml:"bitrate,attr"`
+	Height  string   `json:"height" bson:"height" xml:"height,attr"`
+	Lang    string   `json:"lang" bson:"lang" xml:"lang,attr"`
+	Title   string   `json:"title" bson:"title" xml:"title,attr"`
+	Rel     string   `json:"rel" bson:"rel" xml:"rel,attr"`
+	Codecs  string   `json:"codecs" bson:"codecs" xml:"codecs,attr"`
+	Default string   `json:"default" bson:"default" xml:"default,attr"`
+	Sources []Source `json:"sources" bson:"sources" xml:"https://podcastindex.org/namespace/1.0 source"`
+}
+
+// Source is <podcast:source>, a uri the alternate enclosure can be downloaded from
+type Source struct {
+	URI         string `json:"uri" bson:"uri" xml:"uri,attr"`
+	ContentType string `json:"content_type" bson:"content_type" xml:"contentType,attr"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// EnclosureVariant is one version of an episode's enclosure, publishers using dynamic ad insertion
+// serve different bytes with different ads to each fetch
+type EnclosureVariant struct {
+	EpisodeID      *protos.ObjectID   `json:"episode_id" bson:"episode_id"`
+	Length         int64              `json:"length" bson:"length"` // bytes, identifies the variant
+	SHA256         string             `json:"sha256" bson:"sha256"`
+	DurationMillis int64              `json:"duration_millis" bson:"duration_millis"`
+	Fingerprint    []uint32           `json:"fingerprint" bson:"fingerprint"` // acoustic sub-fingerprints, one every fingerprintHop samples
+	Reference      bool               `json:"reference" bson:"reference"`     // the first variant fetched, whose timeline offsets are stored in
+	Segments       []VariantSegment   `json:"segments" bson:"segments"`       // content shared with the reference, empty for the reference itself
+	Ads            []*protos.AdMarker `json:"ads" bson:"ads"`
+	FetchedAt      time.Time          `json:"fetched_at" bson:"fetched_at"`
+}
+
+// VariantSegment is a run of content a variant shares with the reference variant
+type VariantSegment struct {
+	RefStartMillis int64 `json:"ref_start_millis" bson:"ref_start_millis"`
+	StartMillis    int64 `json:"start_millis" bson:"start_millis"`
+	LengthMillis   int64 `json:"length_millis" bson:"length_millis"`
+}
+
+// FingerprintJob is a queued fingerprinting of an episode's enclosure, which is fetched
+// twice apart to find the ads inserted into either
+type FingerprintJob struct {
+	EpisodeID *protos.ObjectID `json:"episode_id" bson:"episode_id"`
+	URL       string           `json:"url" bson:"url"`
+	Fetches   int              `json:"fetches" bson:"fetches"`
+	Attempts  int              `json:"attempts" bson:"attempts"`
+	Due       time.Time        `json:"due" bson:"due"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// WaveformJob is a queued generation of the waveform & silence map of an episode's enclosure
+type WaveformJob struct {
+	EpisodeID *protos.ObjectID `json:"episode_id" bson:"episode_id"`
+	URL       string           `json:"url" bson:"url"`
+	Attempts  int              `json:"attempts" bson:"attempts"`
+	Due       time.Time        `json:"due" bson:"due"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// WebSubSubscription is the subscription to the WebSub hub advertised by a podcast's feed
+type WebSubSubscription struct {
+	PodcastID    *protos.ObjectID `json:"podcast_id" bson:"podcast_id"`
+	Hub          string           `json:"hub" bson:"hub"`
+	Topic        string           `json:"topic" bson:"topic"`
+	Secret       string           `json:"secret" bson:"secret"` // key of the X-Hub-Signature HMAC of pushed content
+	State        string           `json:"state" bson:"state"`
+	RequestedAt  time.Time        `json:"requested_at" bson:"requested_at"`
+	VerifiedAt   time.Time        `json:"verified_at" bson:"verified_at"`
+	LeaseExpires time.Time        `json:"lease_expires" bson:"lease_expires"`
+}
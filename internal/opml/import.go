@@ -0,0 +1,109 @@
+package opml
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/podcast"
+	"github.com/sschwartz96/syncapod/internal/protos"
+	"github.com/sschwartz96/syncapod/internal/user"
+)
+
+// importWorkers is how many feeds are added at once
+const importWorkers = 4
+
+// Import subscribes the user to the feeds, adding the podcasts that are new concurrently.
+// progress is called, one call at a time, when the import starts and after each feed
+func Import(dbClient db.Database, userID *protos.ObjectID, feeds []Feed, progress func(*protos.ImportProgress)) *protos.ImportProgress {
+	p := &protos.ImportProgress{
+		Id:       protos.NewObjectID(),
+		UserID:   userID,
+		Total:    int32(len(feeds)),
+		Failures: []*protos.ImportFailure{},
+		Started:  ptypes.TimestampNow(),
+	}
+	var mutex sync.Mutex
+	report := func() {
+		if progress != nil {
+			progress(p)
+		}
+	}
+	report()
+
+	queue := make(chan Feed)
+	var wg sync.WaitGroup
+	for i := 0; i < importWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for feed := range queue {
+				pod, err := findFeed(dbClient, userID, feed.URL)
+				mutex.Lock()
+				// subscribed one at a time, different urls may be the same podcast
+				if err == nil {
+					_, err = user.Subscribe(dbClient, userID, pod.Id)
+				}
+				if err != nil {
+					p.Failures = append(p.Failures, &protos.ImportFailure{Url: feed.URL, Title: feed.Title, Error: err.Error()})
+				} else {
+					p.Subscribed++
+				}
+				p.Done++
+				report()
+				mutex.Unlock()
+			}
+		}()
+	}
+	for _, feed := range feeds {
+		queue <- feed
+	}
+	close(queue)
+	wg.Wait()
+
+	p.Finished = true
+	report()
+	return p
+}
+
+// findFeed finds the podcast of the feed url, adding it if it's new
+func findFeed(dbClient db.Database, userID *protos.ObjectID, feedURL string) (*protos.Podcast, error) {
+	pod, err := podcast.FindOrAddPodcast(dbClient, feedURL)
+	if err != nil {
+		return nil, err
+	}
+	if !podcast.CanAccessPodcast(pod, userID) {
+		return nil, errors.New("podcast not found")
+	}
+	return pod, nil
+}
+
+// StartImport imports the feeds in the background, storing its progress as it goes,
+// and returns the progress it starts with
+func StartImport(dbClient db.Database, userID *protos.ObjectID, feeds []Feed) *protos.ImportProgress {
+	started := make(chan *protos.ImportProgress, 1)
+	go Import(dbClient, userID, feeds, func(p *protos.ImportProgress) {
+		err := dbClient.Upsert(database.ColOPMLImport, p, &db.Filter{"_id": p.Id})
+		if err != nil {
+			log.Println("StartImport() error storing progress:", err)
+		}
+		if p.Done == 0 && !p.Finished {
+			started <- &protos.ImportProgress{Id: p.Id, UserID: p.UserID, Total: p.Total, Failures: []*protos.ImportFailure{}, Started: p.Started}
+		}
+	})
+	return <-started
+}
+
+// FindImport finds the progress of the user's import
+func FindImport(dbClient db.Database, id, userID *protos.ObjectID) (*protos.ImportProgress, error) {
+	p := &protos.ImportProgress{}
+	err := dbClient.FindOne(database.ColOPMLImport, p, &db.Filter{"_id": id, "userid": userID}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("FindImport() error: %v", err)
+	}
+	return p, nil
+}
@@ -0,0 +1,131 @@
+package opml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/podcast"
+	"github.com/sschwartz96/syncapod/internal/protos"
+	"github.com/sschwartz96/syncapod/internal/user"
+)
+
+const (
+	// MaxSize is the largest OPML document parsed
+	MaxSize = 5 << 20
+	// maxFeeds is the most feeds imported from one document
+	maxFeeds = 2000
+)
+
+// Feed is a podcast feed listed in an OPML document
+type Feed struct {
+	URL   string
+	Title string
+}
+
+// Parse parses an OPML 1.0 or 2.0 document, returning the feeds of its outlines and nested outlines
+// without duplicates, in document order
+func Parse(r io.Reader) ([]Feed, error) {
+	body, err := ioutil.ReadAll(io.LimitReader(r, MaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("Parse() error reading document: %v", err)
+	}
+	if len(body) > MaxSize {
+		return nil, fmt.Errorf("Parse() error: document is larger than %d bytes", MaxSize)
+	}
+	doc := &models.OPML{}
+	dec := xml.NewDecoder(bytes.NewReader(body))
+	dec.CharsetReader = charsetReader
+	err = dec.Decode(doc)
+	if err != nil {
+		return nil, fmt.Errorf("Parse() error decoding document: %v", err)
+	}
+
+	var feeds []Feed
+	seen := map[string]bool{}
+	var walk func(outlines []models.OPMLOutline)
+	walk = func(outlines []models.OPMLOutline) {
+		for _, o := range outlines {
+			feedURL := strings.TrimSpace(o.XMLURL)
+			if feedURL != "" && !seen[feedURL] && validURL(feedURL) {
+				seen[feedURL] = true
+				title := o.Title
+				if title == "" {
+					title = o.Text
+				}
+				feeds = append(feeds, Feed{URL: feedURL, Title: strings.TrimSpace(title)})
+			}
+			walk(o.Outlines)
+		}
+	}
+	walk(doc.Body.Outlines)
+	if len(feeds) > maxFeeds {
+		return nil, fmt.Errorf("Parse() error: more than %d feeds", maxFeeds)
+	}
+	return feeds, nil
+}
+
+// validURL returns whether the feed url is an absolute http(s) url
+func validURL(feedURL string) bool {
+	u, err := url.Parse(feedURL)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// charsetReader decodes the latin-1 documents some apps export, utf-8 needs no decoding
+func charsetReader(label string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(label) {
+	case "utf-8", "utf8", "us-ascii", "ascii":
+		return input, nil
+	case "iso-8859-1", "latin1", "latin-1":
+		body, err := ioutil.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		runes := make([]rune, len(body))
+		for i, b := range body {
+			runes[i] = rune(b)
+		}
+		return strings.NewReader(string(runes)), nil
+	}
+	return nil, errors.New("unsupported charset " + label)
+}
+
+// Export renders the user's subscriptions as an OPML 2.0 document, private podcasts are left out
+// as their feeds can't be fetched without the user's stored credentials
+func Export(dbClient db.Database, userID *protos.ObjectID) ([]byte, error) {
+	subs, err := user.FindSubscriptions(dbClient, userID)
+	if err != nil {
+		return nil, fmt.Errorf("Export() error: %v", err)
+	}
+	doc := &models.OPML{
+		Version: "2.0",
+		Head:    models.OPMLHead{Title: "syncapod subscriptions", DateCreated: time.Now().UTC().Format(time.RFC1123Z)},
+		Body:    models.OPMLBody{Outlines: []models.OPMLOutline{}},
+	}
+	for _, sub := range subs {
+		pod, err := podcast.FindPodcastByID(dbClient, sub.PodcastID)
+		if err != nil || pod.Private || pod.Rss == "" {
+			continue
+		}
+		doc.Body.Outlines = append(doc.Body.Outlines, models.OPMLOutline{
+			Text:    pod.Title,
+			Title:   pod.Title,
+			Type:    "rss",
+			XMLURL:  pod.Rss,
+			HTMLURL: pod.Link,
+		})
+	}
+	body, err := xml.MarshalIndent(doc, "", "\t")
+	if err != nil {
+		return nil, fmt.Errorf("Export() error encoding document: %v", err)
+	}
+	return append([]byte(xml.Header), body...), nil
+}
@@ -0,0 +1,204 @@
+package opml
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/stockpile/mock"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/protos"
+	"github.com/sschwartz96/syncapod/internal/user"
+)
+
+func insertOrFail(t *testing.T, mockDB db.Database, collection string, object interface{}) {
+	err := mockDB.Insert(collection, object)
+	if err != nil {
+		t.Fatalf("insertOrFail() error inserting: %v", err)
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		want    []Feed
+		wantErr bool
+	}{
+		{
+			name: "opml_1",
+			doc: `<?xml version="1.0" encoding="utf-8"?>
+<opml version="1.0"><head><title>Podcasts</title></head><body>
+<outline text="Show One" type="rss" xmlUrl="https://example.com/one.rss" />
+<outline text="Show Two" type="rss" xmlurl="https://example.com/two.rss" />
+</body></opml>`,
+			want: []Feed{{URL: "https://example.com/one.rss", Title: "Show One"}, {URL: "https://example.com/two.rss", Title: "Show Two"}},
+		},
+		{
+			name: "opml_2_nested",
+			doc: `<?xml version="1.0"?>
+<opml version="2.0"><head><title>Podcasts</title></head><body>
+<outline text="feeds"><outline text="News">
+	<outline text="one" title="Show One" type="rss" xmlUrl=" https://example.com/one.rss " htmlUrl="https://example.com" />
+</outline>
+<outline text="Show Two" type="rss" xmlUrl="https://example.com/two.rss" />
+<outline text="duplicate" type="rss" xmlUrl="https://example.com/one.rss" />
+<outline text="not a feed" type="link" url="https://example.com" />
+<outline text="bad scheme" type="rss" xmlUrl="file:///etc/passwd" />
+</outline></body></opml>`,
+			want: []Feed{{URL: "https://example.com/one.rss", Title: "Show One"}, {URL: "https://example.com/two.rss", Title: "Show Two"}},
+		},
+		{
+			name: "latin1",
+			doc:  "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?>\n<opml version=\"1.0\"><body><outline text=\"Caf\xe9\" xmlUrl=\"https://example.com/cafe.rss\" /></body></opml>",
+			want: []Feed{{URL: "https://example.com/cafe.rss", Title: "Café"}},
+		},
+		{
+			name: "empty",
+			doc:  `<opml version="2.0"><head/><body/></opml>`,
+			want: nil,
+		},
+		{
+			name:    "not_opml",
+			doc:     `<rss version="2.0"><channel><title>Show</title></channel></rss>`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid",
+			doc:     `<opml version="2.0"><body><outline`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tt.doc))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	mockDB := mock.CreateDB()
+	userID := protos.NewObjectID()
+	public := &protos.Podcast{Id: protos.NewObjectID(), Title: "Show & Tell", Rss: "https://example.com/show.rss", Link: "https://example.com"}
+	private := &protos.Podcast{Id: protos.NewObjectID(), Title: "Private", Rss: "private:feed", Private: true, OwnerID: userID}
+	insertOrFail(t, mockDB, database.ColPodcast, public)
+	insertOrFail(t, mockDB, database.ColPodcast, private)
+	for _, pod := range []*protos.Podcast{public, private} {
+		insertOrFail(t, mockDB, database.ColSubscription, &protos.Subscription{Id: protos.NewObjectID(), UserID: userID, PodcastID: pod.Id})
+	}
+	// another user's subscription
+	insertOrFail(t, mockDB, database.ColSubscription, &protos.Subscription{Id: protos.NewObjectID(), UserID: protos.NewObjectID(), PodcastID: public.Id})
+
+	doc, err := Export(mockDB, userID)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if !bytes.HasPrefix(doc, []byte(`<?xml version="1.0" encoding="UTF-8"?>`)) || !bytes.Contains(doc, []byte(`<opml version="2.0">`)) {
+		t.Errorf("Export() = %s", doc)
+	}
+	// the export imports back
+	got, err := Parse(bytes.NewReader(doc))
+	if err != nil {
+		t.Fatalf("Export() produced an invalid document: %v\n%s", err, doc)
+	}
+	want := []Feed{{URL: "https://example.com/show.rss", Title: "Show & Tell"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Export() feeds = %v, want %v", got, want)
+	}
+}
+
+func TestImport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/missing.rss" {
+			http.NotFound(res, req)
+			return
+		}
+		fmt.Fprintf(res, `<?xml version="1.0" encoding="UTF-8"?><rss version="2.0"><channel><title>Show %s</title>`+
+			`<item><title>Episode</title><guid>%s-1</guid><enclosure url="https://example.com/1.mp3" type="audio/mpeg" /></item></channel></rss>`,
+			req.URL.Path, req.URL.Path)
+	}))
+	defer server.Close()
+
+	mockDB := mock.CreateDB()
+	userID := protos.NewObjectID()
+	known := &protos.Podcast{Id: protos.NewObjectID(), Title: "Known", Rss: server.URL + "/known.rss"}
+	insertOrFail(t, mockDB, database.ColPodcast, known)
+	insertOrFail(t, mockDB, database.ColEpisode, &protos.Episode{Id: protos.NewObjectID(), PodcastID: known.Id})
+	// already subscribed to the known podcast
+	insertOrFail(t, mockDB, database.ColSubscription, &protos.Subscription{Id: protos.NewObjectID(), UserID: userID, PodcastID: known.Id})
+
+	feeds := []Feed{
+		{URL: server.URL + "/known.rss", Title: "Known"},
+		{URL: server.URL + "/a.rss", Title: "A"},
+		{URL: server.URL + "/b.rss", Title: "B"},
+		{URL: server.URL + "/missing.rss", Title: "Missing"},
+		{URL: server.URL + "/c.rss", Title: "C"},
+	}
+	var reports []*protos.ImportProgress
+	got := Import(mockDB, userID, feeds, func(p *protos.ImportProgress) {
+		reports = append(reports, &protos.ImportProgress{Done: p.Done, Subscribed: p.Subscribed, Finished: p.Finished})
+	})
+
+	if got.Total != 5 || got.Done != 5 || got.Subscribed != 4 || !got.Finished {
+		t.Errorf("Import() = %v", got)
+	}
+	if len(got.Failures) != 1 || got.Failures[0].Title != "Missing" || got.Failures[0].Error == "" {
+		t.Errorf("Import() failures = %v", got.Failures)
+	}
+	// started, each feed, then finished
+	if len(reports) != 7 || reports[0].Done != 0 || reports[5].Done != 5 || reports[5].Finished || !reports[6].Finished {
+		t.Errorf("Import() reported %v", reports)
+	}
+	for i := 1; i < len(reports); i++ {
+		if reports[i].Done < reports[i-1].Done {
+			t.Errorf("Import() progress went backwards: %v", reports)
+		}
+	}
+
+	subs, err := user.FindSubscriptions(mockDB, userID)
+	if err != nil || len(subs) != 4 {
+		t.Fatalf("Import() subscriptions = %v, error = %v", subs, err)
+	}
+}
+
+func TestStartImport(t *testing.T) {
+	mockDB := mock.CreateDB()
+	userID := protos.NewObjectID()
+	pod := &protos.Podcast{Id: protos.NewObjectID(), Title: "Known", Rss: "https://example.com/known.rss"}
+	insertOrFail(t, mockDB, database.ColPodcast, pod)
+
+	started := StartImport(mockDB, userID, []Feed{{URL: pod.Rss}})
+	if started.Total != 1 || started.Finished {
+		t.Fatalf("StartImport() = %v", started)
+	}
+	// the other user can't see it
+	if _, err := FindImport(mockDB, started.Id, protos.NewObjectID()); err == nil {
+		t.Errorf("FindImport() found another user's import")
+	}
+	for i := 0; i < 100; i++ {
+		p, err := FindImport(mockDB, started.Id, userID)
+		if err != nil {
+			t.Fatalf("FindImport() error = %v", err)
+		}
+		if p.Finished {
+			if p.Subscribed != 1 {
+				t.Errorf("FindImport() = %v", p)
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Errorf("StartImport() never finished")
+}
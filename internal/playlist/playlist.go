@@ -0,0 +1,236 @@
+package playlist
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/podcast"
+	"github.com/sschwartz96/syncapod/internal/protos"
+	"github.com/sschwartz96/syncapod/internal/user"
+)
+
+const (
+	// maxNameLength is the longest playlist name accepted
+	maxNameLength = 100
+	// maxPlaylists is the most playlists a user can have
+	maxPlaylists = 100
+	// defaultPageSize is how many episodes are evaluated if no end is given
+	defaultPageSize = 50
+	// maxPageSize is the most episodes evaluated at once
+	maxPageSize = 200
+)
+
+// Playlist errors
+var (
+	ErrInvalidName  = fmt.Errorf("playlist name must be 1 to %d characters", maxNameLength)
+	ErrTooMany      = fmt.Errorf("can't have more than %d playlists", maxPlaylists)
+	ErrNotFound     = errors.New("playlist not found")
+	ErrInvalidRange = errors.New("invalid range of episodes")
+)
+
+// FindPlaylists returns the user's playlists
+func FindPlaylists(dbClient db.Database, userID *protos.ObjectID) ([]*protos.Playlist, error) {
+	var playlists []*protos.Playlist
+	err := dbClient.FindAll(database.ColPlaylist, &playlists, &db.Filter{"userid": userID}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("FindPlaylists() error: %v", err)
+	}
+	return playlists, nil
+}
+
+// FindPlaylist finds the user's playlist with the id
+func FindPlaylist(dbClient db.Database, id, userID *protos.ObjectID) (*protos.Playlist, error) {
+	p := &protos.Playlist{}
+	err := dbClient.FindOne(database.ColPlaylist, p, &db.Filter{"_id": id, "userid": userID}, nil)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	return p, nil
+}
+
+// Create validates the user's new playlist and stores it
+func Create(dbClient db.Database, userID *protos.ObjectID, name, query string) (*protos.Playlist, error) {
+	p := &protos.Playlist{
+		Id:      protos.NewObjectID(),
+		UserID:  userID,
+		Name:    strings.TrimSpace(name),
+		Query:   strings.TrimSpace(query),
+		Created: ptypes.TimestampNow(),
+	}
+	err := validate(p)
+	if err != nil {
+		return nil, err
+	}
+	playlists, err := FindPlaylists(dbClient, userID)
+	if err != nil {
+		return nil, fmt.Errorf("Create() error: %v", err)
+	}
+	if len(playlists) >= maxPlaylists {
+		return nil, ErrTooMany
+	}
+	err = dbClient.Insert(database.ColPlaylist, p)
+	if err != nil {
+		return nil, fmt.Errorf("Create() error: %v", err)
+	}
+	return p, nil
+}
+
+// Update replaces the name and query of the user's playlist
+func Update(dbClient db.Database, id, userID *protos.ObjectID, name, query string) (*protos.Playlist, error) {
+	p, err := FindPlaylist(dbClient, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	p.Name, p.Query = strings.TrimSpace(name), strings.TrimSpace(query)
+	err = validate(p)
+	if err != nil {
+		return nil, err
+	}
+	err = dbClient.Update(database.ColPlaylist, p, &db.Filter{"_id": p.Id})
+	if err != nil {
+		return nil, fmt.Errorf("Update() error: %v", err)
+	}
+	return p, nil
+}
+
+// Delete deletes the user's playlist
+func Delete(dbClient db.Database, id, userID *protos.ObjectID) error {
+	err := dbClient.Delete(database.ColPlaylist, &db.Filter{"_id": id, "userid": userID})
+	if err != nil {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Evaluate returns the episodes from start to end of those of the user's subscriptions matching
+// the playlist's query, and whether more match after them. The database filters, sorts & pages the episodes,
+// unless the query has conditions or a sort it can't evaluate, then only the podcasts' episodes it can filter are found
+func Evaluate(dbClient db.Database, p *protos.Playlist, start, end int64) ([]*protos.Episode, bool, error) {
+	if start < 0 || (end != 0 && end < start) {
+		return nil, false, ErrInvalidRange
+	}
+	if end == 0 {
+		end = start + defaultPageSize
+	}
+	if end-start > maxPageSize {
+		end = start + maxPageSize
+	}
+
+	q, err := Parse(p.Query)
+	if err != nil {
+		return nil, false, fmt.Errorf("Evaluate() error parsing query: %v", err)
+	}
+	subs, err := user.FindSubscriptions(dbClient, p.UserID)
+	if err != nil {
+		return nil, false, fmt.Errorf("Evaluate() error: %v", err)
+	}
+	if len(subs) == 0 {
+		return []*protos.Episode{}, false, nil
+	}
+	subIDs := []*protos.ObjectID{}
+	for _, sub := range subs {
+		subIDs = append(subIDs, sub.PodcastID)
+	}
+	pods, err := podcast.FindPodcastsByIDs(dbClient, subIDs)
+	if err != nil {
+		return nil, false, fmt.Errorf("Evaluate() error: %v", err)
+	}
+	podcasts := map[string]*protos.Podcast{}
+	podIDs := []*protos.ObjectID{}
+	for _, pod := range pods {
+		if q.matchPodcast(pod) {
+			podcasts[pod.Id.GetHex()] = pod
+			podIDs = append(podIDs, pod.Id)
+		}
+	}
+	if len(podIDs) == 0 {
+		return []*protos.Episode{}, false, nil
+	}
+	var userEpis []*protos.UserEpisode
+	if q.usesPlayback() {
+		err = dbClient.FindAll(database.ColUserEpisode, &userEpis, &db.Filter{"userid": p.UserID}, nil)
+		if err != nil {
+			return nil, false, fmt.Errorf("Evaluate() error finding playbacks: %v", err)
+		}
+	}
+
+	now := time.Now()
+	filter, rest := q.episodeFilter(podIDs, userEpis, now)
+	if opts := q.pageOptions(rest, start, end); opts != nil {
+		episodes := []*protos.Episode{}
+		err = dbClient.FindAll(database.ColEpisode, &episodes, &filter, opts)
+		if err != nil {
+			return nil, false, fmt.Errorf("Evaluate() error finding episodes: %v", err)
+		}
+		if int64(len(episodes)) > end-start {
+			return episodes[:end-start], true, nil
+		}
+		return episodes, false, nil
+	}
+	var episodes []*protos.Episode
+	err = dbClient.FindAll(database.ColEpisode, &episodes, &filter, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("Evaluate() error finding episodes: %v", err)
+	}
+	page, more := q.page(episodes, podcasts, userEpis, rest, start, end, now)
+	return page, more, nil
+}
+
+// pageOptions returns the options the database sorts & pages the episodes from start to end by,
+// finding one more to know whether there are more, nil if conditions are left or it can't sort them
+func (q *Query) pageOptions(rest []*condition, start, end int64) *db.Options {
+	key, order, ok := q.dbSort()
+	if !ok || len(rest) > 0 {
+		return nil
+	}
+	return db.CreateOptions().SetSort(key, order).SetSkip(start).SetLimit(end - start + 1)
+}
+
+// page returns the episodes from start to end of those matching the conditions sorted by the query,
+// and whether more match after them
+func (q *Query) page(episodes []*protos.Episode, podcasts map[string]*protos.Podcast, userEpis []*protos.UserEpisode,
+	conditions []*condition, start, end int64, now time.Time) ([]*protos.Episode, bool) {
+	playbacks := map[string]*protos.UserEpisode{}
+	for _, userEpi := range userEpis {
+		playbacks[userEpi.EpisodeID.GetHex()] = userEpi
+	}
+	var records []*record
+	for _, epi := range episodes {
+		r := &record{epi: epi, pod: podcasts[epi.PodcastID.GetHex()], userEpi: playbacks[epi.Id.GetHex()], now: now}
+		if matchAll(conditions, r) {
+			records = append(records, r)
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool { return q.less(records[i], records[j]) })
+
+	total := int64(len(records))
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+	page := []*protos.Episode{}
+	for _, r := range records[start:end] {
+		page = append(page, r.epi)
+	}
+	return page, total > end
+}
+
+// validate validates the playlist's name and query
+func validate(p *protos.Playlist) error {
+	if p.Name == "" || len(p.Name) > maxNameLength {
+		return ErrInvalidName
+	}
+	_, err := Parse(p.Query)
+	if err != nil {
+		return fmt.Errorf("invalid query: %v", err)
+	}
+	return nil
+}
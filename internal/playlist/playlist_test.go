@@ -0,0 +1,292 @@
+package playlist
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/stockpile/mock"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+func insertOrFail(t *testing.T, mockDB db.Database, collection string, object interface{}) {
+	err := mockDB.Insert(collection, object)
+	if err != nil {
+		t.Fatalf("insertOrFail() error inserting: %v", err)
+	}
+}
+
+// createPlaylistFixture creates the episodes of a technology & a comedy podcast, the podcasts by id,
+// and the user's playbacks of the technology podcast's episodes
+func createPlaylistFixture(userID *protos.ObjectID) ([]*protos.Episode, map[string]*protos.Podcast, []*protos.UserEpisode) {
+	tech := &protos.Podcast{Id: protos.NewObjectID(), Title: "Tech", Author: "Tech Author",
+		Category: []*protos.Category{{Text: "Technology", Category: []*protos.Category{{Text: "Podcasting"}}}}}
+	comedy := &protos.Podcast{Id: protos.NewObjectID(), Title: "Comedy", Author: "Comedy Author",
+		Category: []*protos.Category{{Text: "Comedy"}}}
+	podcasts := map[string]*protos.Podcast{tech.Id.GetHex(): tech, comedy.Id.GetHex(): comedy}
+
+	var episodes []*protos.Episode
+	episode := func(pod *protos.Podcast, title string, season int32, minutes int, daysAgo int) *protos.Episode {
+		pubDate, _ := ptypes.TimestampProto(time.Now().Add(-time.Duration(daysAgo) * 24 * time.Hour))
+		epi := &protos.Episode{Id: protos.NewObjectID(), PodcastID: pod.Id, Title: title, Season: season,
+			DurationMillis: int64(minutes) * 60000, PubDate: pubDate}
+		episodes = append(episodes, epi)
+		return epi
+	}
+	episode(tech, "Tech 1", 1, 20, 30)
+	tech2 := episode(tech, "Tech 2", 1, 45, 20)
+	tech3 := episode(tech, "Tech 3", 2, 25, 10)
+	episode(tech, "Tech 4", 2, 15, 1)
+	episode(comedy, "Comedy 1", 1, 10, 5)
+
+	// tech 3 was played, tech 2 is nearly finished
+	userEpis := []*protos.UserEpisode{
+		{Id: protos.NewObjectID(), UserID: userID, PodcastID: tech.Id, EpisodeID: tech3.Id, Played: true},
+		{Id: protos.NewObjectID(), UserID: userID, PodcastID: tech.Id, EpisodeID: tech2.Id, Offset: 40 * 60000},
+	}
+	return episodes, podcasts, userEpis
+}
+
+// createPlaylistMockDB creates a database of the user's subscriptions to the fixture's podcasts,
+// an unsubscribed technology podcast and another user's playlist
+func createPlaylistMockDB(t *testing.T, userID *protos.ObjectID) db.Database {
+	mockDB := mock.CreateDB()
+	episodes, podcasts, userEpis := createPlaylistFixture(userID)
+	for _, pod := range podcasts {
+		insertOrFail(t, mockDB, database.ColPodcast, pod)
+		insertOrFail(t, mockDB, database.ColSubscription, &protos.Subscription{Id: protos.NewObjectID(), UserID: userID, PodcastID: pod.Id})
+	}
+	other := &protos.Podcast{Id: protos.NewObjectID(), Title: "Other", Category: []*protos.Category{{Text: "Technology"}}}
+	insertOrFail(t, mockDB, database.ColPodcast, other)
+	episodes = append(episodes, &protos.Episode{Id: protos.NewObjectID(), PodcastID: other.Id, Title: "Other 1"})
+	for _, epi := range episodes {
+		insertOrFail(t, mockDB, database.ColEpisode, epi)
+	}
+	for _, userEpi := range userEpis {
+		insertOrFail(t, mockDB, database.ColUserEpisode, userEpi)
+	}
+	insertOrFail(t, mockDB, database.ColPlaylist, &protos.Playlist{Id: protos.NewObjectID(), UserID: protos.NewObjectID(), Name: "Other"})
+	return mockDB
+}
+
+func TestEvaluate(t *testing.T) {
+	userID := protos.NewObjectID()
+	mockDB := createPlaylistMockDB(t, userID)
+
+	tests := []struct {
+		name    string
+		userID  *protos.ObjectID
+		query   string
+		start   int64
+		end     int64
+		wantErr bool
+	}{
+		{name: "no_subscriptions", userID: protos.NewObjectID(), query: "played = false"},
+		{name: "invalid_range", userID: userID, start: 3, end: 1, wantErr: true},
+		{name: "negative_start", userID: userID, start: -1, wantErr: true},
+		{name: "invalid_query", userID: userID, query: "played", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &protos.Playlist{Id: protos.NewObjectID(), UserID: tt.userID, Name: tt.name, Query: tt.query}
+			got, more, err := Evaluate(mockDB, p, tt.start, tt.end)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Evaluate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (len(got) != 0 || more) {
+				t.Errorf("Evaluate() = %v, more %v", got, more)
+			}
+		})
+	}
+}
+
+func TestQuery_pageOptions(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  *db.Options
+	}{
+		{
+			name:  "newest_first",
+			query: "played = false",
+			want:  &db.Options{Skip: 10, Limit: 11, Sort: &db.SortOption{Key: "pubdate", Value: -1}},
+		},
+		{
+			name:  "oldest_by_age",
+			query: "sort age desc",
+			want:  &db.Options{Skip: 10, Limit: 11, Sort: &db.SortOption{Key: "pubdate", Value: 1}},
+		},
+		{name: "conditions_left", query: "remaining < 5m"},
+		{name: "sort_on_podcast", query: "sort podcast.title"},
+		{name: "sort_on_playback", query: "sort lastseen desc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			_, rest := q.episodeFilter(nil, nil, time.Now())
+			if got := q.pageOptions(rest, 10, 20); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Query.pageOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuery_page(t *testing.T) {
+	episodes, podcasts, userEpis := createPlaylistFixture(protos.NewObjectID())
+	yesterday := time.Now().Add(-24 * time.Hour).UTC().Format("2006-01-02")
+
+	tests := []struct {
+		name     string
+		query    string
+		start    int64
+		end      int64
+		want     []string
+		wantMore bool
+	}{
+		{
+			name:  "all_newest_first",
+			query: "",
+			want:  []string{"Tech 4", "Comedy 1", "Tech 3", "Tech 2", "Tech 1"},
+		},
+		{
+			name:  "unplayed_short_technology",
+			query: `played = false and duration < 30m and podcast.category = "Technology" sort pubdate desc`,
+			want:  []string{"Tech 4", "Tech 1"},
+		},
+		{
+			name:  "played",
+			query: `played = true`,
+			want:  []string{"Tech 3"},
+		},
+		{
+			name:  "nearly_finished",
+			query: `inprogress = true and remaining <= 5m`,
+			want:  []string{"Tech 2"},
+		},
+		{
+			name:  "podcast_and_equality",
+			query: `podcast.author = "Tech Author" and season = 2 sort duration`,
+			want:  []string{"Tech 4", "Tech 3"},
+		},
+		{
+			name:  "recent_sorted_by_title",
+			query: `age < 2w sort title asc`,
+			want:  []string{"Comedy 1", "Tech 3", "Tech 4"},
+		},
+		{
+			name:  "contains_and_not_equal",
+			query: `title ~ "tech" and season != 1`,
+			want:  []string{"Tech 4", "Tech 3"},
+		},
+		{
+			name:  "published_on_day",
+			query: "pubdate = " + yesterday,
+			want:  []string{"Tech 4"},
+		},
+		{
+			name:     "page",
+			query:    `sort duration desc`,
+			start:    1,
+			end:      3,
+			want:     []string{"Tech 3", "Tech 1"},
+			wantMore: true,
+		},
+		{
+			name:     "page_of_matching",
+			query:    `remaining > 0m sort podcast.title`,
+			end:      2,
+			want:     []string{"Comedy 1", "Tech 1"},
+			wantMore: true,
+		},
+		{
+			name:  "last_page",
+			query: `sort duration desc`,
+			start: 3,
+			end:   5,
+			want:  []string{"Tech 4", "Comedy 1"},
+		},
+		{
+			name:  "page_past_end",
+			query: "",
+			start: 10,
+			end:   20,
+			want:  []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			end := tt.end
+			if end == 0 {
+				end = defaultPageSize
+			}
+			got, more := q.page(episodes, podcasts, userEpis, q.conditions, tt.start, end, time.Now())
+			titles := []string{}
+			for _, epi := range got {
+				titles = append(titles, epi.Title)
+			}
+			if !reflect.DeepEqual(titles, tt.want) || more != tt.wantMore {
+				t.Errorf("Query.page() = %v, more %v, want %v, more %v", titles, more, tt.want, tt.wantMore)
+			}
+		})
+	}
+}
+
+func TestPlaylistCRUD(t *testing.T) {
+	userID, otherID := protos.NewObjectID(), protos.NewObjectID()
+	mockDB := createPlaylistMockDB(t, userID)
+
+	if _, err := Create(mockDB, userID, " ", "played = false"); err != ErrInvalidName {
+		t.Errorf("Create() error = %v, want %v", err, ErrInvalidName)
+	}
+	if _, err := Create(mockDB, userID, "Short", "duration <"); err == nil {
+		t.Errorf("Create() invalid query error = nil")
+	}
+	p, err := Create(mockDB, userID, " Short ", " duration < 30m ")
+	if err != nil || p.Name != "Short" || p.Query != "duration < 30m" || p.Created == nil {
+		t.Fatalf("Create() = %v, error = %v", p, err)
+	}
+
+	// other users can't see, change or delete it
+	if _, err = FindPlaylist(mockDB, p.Id, otherID); err != ErrNotFound {
+		t.Errorf("FindPlaylist() other user error = %v", err)
+	}
+	if _, err = Update(mockDB, p.Id, otherID, "Mine", ""); err != ErrNotFound {
+		t.Errorf("Update() other user error = %v", err)
+	}
+	if err = Delete(mockDB, p.Id, otherID); err != ErrNotFound {
+		t.Errorf("Delete() other user error = %v", err)
+	}
+
+	if _, err = Update(mockDB, p.Id, userID, "Long", "duration >"); err == nil {
+		t.Errorf("Update() invalid query error = nil")
+	}
+	updated, err := Update(mockDB, p.Id, userID, "Long", "duration > 30m")
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	stored, err := FindPlaylist(mockDB, p.Id, userID)
+	if err != nil || stored.Name != "Long" || stored.Query != "duration > 30m" || updated.Query != stored.Query {
+		t.Errorf("Update() stored %v, error = %v", stored, err)
+	}
+	playlists, err := FindPlaylists(mockDB, userID)
+	if err != nil || len(playlists) != 1 {
+		t.Errorf("FindPlaylists() = %v, error = %v", playlists, err)
+	}
+
+	if err = Delete(mockDB, p.Id, userID); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+	if _, err = FindPlaylist(mockDB, p.Id, userID); err != ErrNotFound {
+		t.Errorf("Delete() left the playlist: %v", err)
+	}
+}
@@ -0,0 +1,583 @@
+package playlist
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// maxQueryLength is the longest query accepted
+const maxQueryLength = 1000
+
+// kind is the type of a field's values
+type kind int
+
+const (
+	kindString kind = iota
+	// kindList fields have many strings, a condition matches if any of them do
+	kindList
+	kindInt
+	kindBool
+	kindDuration
+	kindTime
+)
+
+// ops are the operators each kind of field can be compared with, ~ is a case insensitive contains
+var ops = map[kind][]string{
+	kindString:   {"=", "!=", "~"},
+	kindList:     {"=", "!=", "~"},
+	kindInt:      {"=", "!=", "<", "<=", ">", ">="},
+	kindBool:     {"=", "!="},
+	kindDuration: {"=", "!=", "<", "<=", ">", ">="},
+	kindTime:     {"=", "!=", "<", "<=", ">", ">="},
+}
+
+// source is what a field's value is from
+type source int
+
+const (
+	fromEpisode source = iota
+	fromPodcast
+	// fromPlayback fields are from the user's playback of the episode alone
+	fromPlayback
+	// fromEpisodePlayback fields are from both the episode and the user's playback of it
+	fromEpisodePlayback
+)
+
+// dbOps are the database operators of the comparison operators
+var dbOps = map[string]string{"=": "$eq", "!=": "$ne", "<": "$lt", "<=": "$lte", ">": "$gt", ">=": "$gte"}
+
+// record is an episode joined with its podcast and the user's playback of it
+type record struct {
+	epi *protos.Episode
+	pod *protos.Podcast
+	// nil if the user never played the episode
+	userEpi *protos.UserEpisode
+	now     time.Time
+}
+
+// field is a field queries filter and sort on
+type field struct {
+	kind   kind
+	source source
+	// key is the database key of the episode's field, durations are stored in millis
+	key string
+	// sinceKey fields are durations since the time of key
+	sinceKey bool
+	value    func(r *record) interface{}
+}
+
+// fields are the fields of queries, podcast fields are prefixed by "podcast."
+var fields = map[string]*field{
+	"title":       {kind: kindString, key: "title", value: func(r *record) interface{} { return r.epi.Title }},
+	"author":      {kind: kindString, key: "author", value: func(r *record) interface{} { return r.epi.Author }},
+	"subtitle":    {kind: kindString, key: "subtitle", value: func(r *record) interface{} { return r.epi.Subtitle }},
+	"description": {kind: kindString, key: "description", value: func(r *record) interface{} { return r.epi.Description }},
+	"type":        {kind: kindString, key: "type", value: func(r *record) interface{} { return r.epi.Type }},
+	"explicit":    {kind: kindString, key: "explicit", value: func(r *record) interface{} { return r.epi.Explicit }},
+	"season":      {kind: kindInt, key: "season", value: func(r *record) interface{} { return int64(r.epi.Season) }},
+	"episode":     {kind: kindInt, key: "episode", value: func(r *record) interface{} { return int64(r.epi.Episode) }},
+	"duration": {kind: kindDuration, key: "durationmillis", value: func(r *record) interface{} {
+		return time.Duration(r.epi.DurationMillis) * time.Millisecond
+	}},
+	"pubdate": {kind: kindTime, key: "pubdate", value: func(r *record) interface{} { return toTime(r.epi.PubDate) }},
+	// age is how long ago the episode was published
+	"age": {kind: kindDuration, key: "pubdate", sinceKey: true, value: func(r *record) interface{} {
+		return r.now.Sub(toTime(r.epi.PubDate))
+	}},
+
+	"podcast.title":    {kind: kindString, source: fromPodcast, value: func(r *record) interface{} { return r.pod.Title }},
+	"podcast.author":   {kind: kindString, source: fromPodcast, value: func(r *record) interface{} { return r.pod.Author }},
+	"podcast.language": {kind: kindString, source: fromPodcast, value: func(r *record) interface{} { return r.pod.Language }},
+	"podcast.explicit": {kind: kindString, source: fromPodcast, value: func(r *record) interface{} { return r.pod.Explicit }},
+	// categories include their subcategories
+	"podcast.category": {kind: kindList, source: fromPodcast, value: func(r *record) interface{} { return categoryTexts(r.pod.Category) }},
+
+	"played": {kind: kindBool, source: fromPlayback, value: func(r *record) interface{} { return r.userEpi != nil && r.userEpi.Played }},
+	"inprogress": {kind: kindBool, source: fromPlayback, value: func(r *record) interface{} {
+		return r.userEpi != nil && !r.userEpi.Played && r.userEpi.Offset > 0
+	}},
+	"offset": {kind: kindDuration, source: fromPlayback, value: func(r *record) interface{} { return offset(r) }},
+	// remaining is how much of the episode is left to play
+	"remaining": {kind: kindDuration, source: fromEpisodePlayback, value: func(r *record) interface{} {
+		return time.Duration(r.epi.DurationMillis)*time.Millisecond - offset(r)
+	}},
+	"lastseen": {kind: kindTime, source: fromPlayback, value: func(r *record) interface{} {
+		if r.userEpi == nil {
+			return time.Time{}
+		}
+		return toTime(r.userEpi.LastSeen)
+	}},
+}
+
+// condition compares a field to a value
+type condition struct {
+	field *field
+	op    string
+	value interface{}
+}
+
+// Query is a parsed playlist query: conditions joined by "and", optionally followed by
+// "sort" and the field to sort by, then "asc" or "desc". For example:
+//
+//	played = false and duration < 30m and podcast.category = "Technology" sort pubdate desc
+//
+// Strings containing spaces or operators are quoted, durations are like 90s, 1h30m, 2d or 1w
+// and times are UTC dates like 2020-12-31, so = matches the whole day and > matches after it.
+// Episodes are sorted newest first if no sort is given
+type Query struct {
+	conditions []*condition
+	sort       *field
+	desc       bool
+}
+
+// Parse parses the query, returning an error describing why it's invalid
+func Parse(query string) (*Query, error) {
+	if len(query) > maxQueryLength {
+		return nil, fmt.Errorf("query is longer than %d characters", maxQueryLength)
+	}
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &Query{sort: fields["pubdate"], desc: true}
+	i := 0
+	for i < len(tokens) && !strings.EqualFold(tokens[i], "sort") {
+		if len(q.conditions) > 0 {
+			if !strings.EqualFold(tokens[i], "and") {
+				return nil, fmt.Errorf("expected and or sort, got %q", tokens[i])
+			}
+			i++
+		}
+		if i+3 > len(tokens) {
+			return nil, errors.New("incomplete condition at the end of the query")
+		}
+		cond, err := parseCondition(tokens[i], tokens[i+1], tokens[i+2])
+		if err != nil {
+			return nil, err
+		}
+		q.conditions = append(q.conditions, cond)
+		i += 3
+	}
+
+	if i < len(tokens) {
+		i++
+		if i == len(tokens) {
+			return nil, errors.New("sort requires a field")
+		}
+		f, ok := fields[strings.ToLower(tokens[i])]
+		if !ok || f.kind == kindList {
+			return nil, fmt.Errorf("can't sort by %q", tokens[i])
+		}
+		q.sort, q.desc = f, false
+		i++
+		if i < len(tokens) && (strings.EqualFold(tokens[i], "asc") || strings.EqualFold(tokens[i], "desc")) {
+			q.desc = strings.EqualFold(tokens[i], "desc")
+			i++
+		}
+		if i < len(tokens) {
+			return nil, fmt.Errorf("unexpected %q after sort", tokens[i])
+		}
+	}
+	return q, nil
+}
+
+// parseCondition parses the condition comparing the named field with op to the value
+func parseCondition(name, op, value string) (*condition, error) {
+	f, ok := fields[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown field %q", name)
+	}
+	if op == "==" {
+		op = "="
+	}
+	valid := false
+	for _, o := range ops[f.kind] {
+		valid = valid || o == op
+	}
+	if !valid {
+		return nil, fmt.Errorf("%s can't be compared with %q", name, op)
+	}
+
+	if strings.HasPrefix(value, `"`) {
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid string %s", value)
+		}
+		value = unquoted
+	}
+	cond := &condition{field: f, op: op}
+	var err error
+	switch f.kind {
+	case kindString, kindList:
+		cond.value = value
+	case kindInt:
+		cond.value, err = strconv.ParseInt(value, 10, 64)
+	case kindBool:
+		switch strings.ToLower(value) {
+		case "true":
+			cond.value = true
+		case "false":
+			cond.value = false
+		default:
+			err = errors.New("not true or false")
+		}
+	case kindDuration:
+		cond.value, err = parseDuration(value)
+	case kindTime:
+		cond.value, err = time.Parse("2006-01-02", value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s value %q", name, value)
+	}
+	return cond, nil
+}
+
+// tokenize splits the query into words, quoted strings and operators
+func tokenize(query string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '"':
+			j := i + 1
+			for ; j < len(query) && query[j] != '"'; j++ {
+				if query[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(query) {
+				return nil, errors.New("unterminated string")
+			}
+			tokens = append(tokens, query[i:j+1])
+			i = j + 1
+		case strings.IndexByte("=!<>~", c) >= 0:
+			j := i + 1
+			if j < len(query) && query[j] == '=' {
+				j++
+			}
+			tokens = append(tokens, query[i:j])
+			i = j
+		default:
+			j := i
+			for j < len(query) && strings.IndexByte(" \t\n\r\"=!<>~", query[j]) < 0 {
+				j++
+			}
+			tokens = append(tokens, query[i:j])
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+// parseDuration parses a duration, which besides those of time.ParseDuration may be in days or weeks like 2d or 1w
+func parseDuration(s string) (time.Duration, error) {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if strings.HasSuffix(s, suffix) {
+			n, err := strconv.ParseInt(strings.TrimSuffix(s, suffix), 10, 64)
+			if err != nil {
+				return 0, err
+			}
+			return time.Duration(n) * unit, nil
+		}
+	}
+	return time.ParseDuration(s)
+}
+
+// usesPlayback returns whether the query's conditions or sort are on the user's playbacks
+func (q *Query) usesPlayback() bool {
+	for _, cond := range q.conditions {
+		if cond.field.source == fromPlayback || cond.field.source == fromEpisodePlayback {
+			return true
+		}
+	}
+	return q.sort.source == fromPlayback || q.sort.source == fromEpisodePlayback
+}
+
+// matchPodcast returns whether the podcast matches the query's conditions on podcast fields
+func (q *Query) matchPodcast(pod *protos.Podcast) bool {
+	r := &record{pod: pod}
+	for _, cond := range q.conditions {
+		if cond.field.source == fromPodcast && !cond.match(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// episodeFilter compiles the query's conditions on episode fields and the user's playbacks into the filter of
+// the podcasts' episodes matching them, returning the conditions the database can't evaluate which are matched after
+func (q *Query) episodeFilter(podIDs []*protos.ObjectID, userEpis []*protos.UserEpisode, now time.Time) (db.Filter, []*condition) {
+	filter := db.Filter{"podcastid": db.Filter{"$in": podIDs}}
+	var rest, onPlayback []*condition
+	for _, cond := range q.conditions {
+		switch cond.field.source {
+		case fromEpisode:
+			exprs, ok := cond.compile(now)
+			if ok && addExprs(filter, cond.field.key, exprs) {
+				continue
+			}
+			rest = append(rest, cond)
+		case fromPlayback:
+			onPlayback = append(onPlayback, cond)
+		case fromEpisodePlayback:
+			rest = append(rest, cond)
+		}
+	}
+
+	if len(onPlayback) > 0 {
+		// episodes the user never played match if a playback that's never been started does,
+		// so the filter is either the played episodes matching or those not matching
+		unplayed := matchAll(onPlayback, &record{now: now})
+		ids := []*protos.ObjectID{}
+		for _, userEpi := range userEpis {
+			if matchAll(onPlayback, &record{userEpi: userEpi, now: now}) != unplayed {
+				ids = append(ids, userEpi.EpisodeID)
+			}
+		}
+		if unplayed {
+			filter["_id"] = db.Filter{"$nin": ids}
+		} else {
+			filter["_id"] = db.Filter{"$in": ids}
+		}
+	}
+	return filter, rest
+}
+
+// dbSort returns the database key & order episodes are sorted by, false if the database can't sort by the field
+func (q *Query) dbSort() (string, int, bool) {
+	if q.sort.source != fromEpisode {
+		return "", 0, false
+	}
+	order := 1
+	if q.desc != q.sort.sinceKey {
+		order = -1
+	}
+	return q.sort.key, order, true
+}
+
+// addExprs adds the operator expressions to those of the key in the filter, returning false
+// without adding them if the key already has any of the operators
+func addExprs(filter db.Filter, key string, exprs db.Filter) bool {
+	existing, ok := filter[key].(db.Filter)
+	if !ok {
+		filter[key] = exprs
+		return true
+	}
+	for op := range exprs {
+		if _, ok := existing[op]; ok {
+			return false
+		}
+	}
+	for op, value := range exprs {
+		existing[op] = value
+	}
+	return true
+}
+
+// compile returns the database operator expressions of the condition on an episode field,
+// false if the database can't evaluate it
+func (c *condition) compile(now time.Time) (db.Filter, bool) {
+	switch c.field.kind {
+	case kindString:
+		if c.op == "~" {
+			return db.Filter{"$regex": regexp.QuoteMeta(c.value.(string)), "$options": "i"}, true
+		}
+		return db.Filter{dbOps[c.op]: c.value}, true
+	case kindInt:
+		return db.Filter{dbOps[c.op]: c.value}, true
+	case kindDuration:
+		d := c.value.(time.Duration)
+		if !c.field.sinceKey {
+			// durations are stored in millis
+			if d%time.Millisecond != 0 {
+				return nil, false
+			}
+			return db.Filter{dbOps[c.op]: int64(d / time.Millisecond)}, true
+		}
+		// a duration since the key is less than d if the key's time is after now minus d
+		since := map[string]string{"<": "$gt", "<=": "$gte", ">": "$lt", ">=": "$lte"}[c.op]
+		if since == "" {
+			return nil, false
+		}
+		return db.Filter{since: timestampOf(now.Add(-d))}, true
+	case kindTime:
+		day := c.value.(time.Time)
+		start, end := timestampOf(day), timestampOf(day.Add(24*time.Hour))
+		switch c.op {
+		case "=":
+			return db.Filter{"$gte": start, "$lt": end}, true
+		case "<":
+			return db.Filter{"$lt": start}, true
+		case "<=":
+			return db.Filter{"$lt": end}, true
+		case ">":
+			return db.Filter{"$gte": end}, true
+		case ">=":
+			return db.Filter{"$gte": start}, true
+		}
+	}
+	return nil, false
+}
+
+// match returns whether the record matches all of the query's conditions
+func (q *Query) match(r *record) bool {
+	return matchAll(q.conditions, r)
+}
+
+// matchAll returns whether the record matches all of the conditions
+func matchAll(conditions []*condition, r *record) bool {
+	for _, cond := range conditions {
+		if !cond.match(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// less returns whether a is sorted before b
+func (q *Query) less(a, b *record) bool {
+	c := compare(q.sort.value(a), q.sort.value(b))
+	if q.desc {
+		return c > 0
+	}
+	return c < 0
+}
+
+func (c *condition) match(r *record) bool {
+	value := c.field.value(r)
+	if c.field.kind == kindTime {
+		return matchDay(c.op, value.(time.Time), c.value.(time.Time))
+	}
+	if c.field.kind != kindList {
+		return matchOp(c.op, value, c.value)
+	}
+	// list conditions match if any value does, != if none are equal
+	op := c.op
+	if op == "!=" {
+		op = "="
+	}
+	found := false
+	for _, v := range value.([]string) {
+		found = found || matchOp(op, v, c.value)
+	}
+	return found != (c.op == "!=")
+}
+
+// matchOp returns whether the value compared with op to want is true
+func matchOp(op string, value, want interface{}) bool {
+	if op == "~" {
+		return strings.Contains(strings.ToLower(value.(string)), strings.ToLower(want.(string)))
+	}
+	cmp := compare(value, want)
+	switch op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
+
+// matchDay returns whether the time compared with op to the day is true, = if it's during the day
+func matchDay(op string, t, day time.Time) bool {
+	end := day.Add(24 * time.Hour)
+	switch op {
+	case "=":
+		return !t.Before(day) && t.Before(end)
+	case "!=":
+		return t.Before(day) || !t.Before(end)
+	case "<":
+		return t.Before(day)
+	case "<=":
+		return t.Before(end)
+	case ">":
+		return !t.Before(end)
+	case ">=":
+		return !t.Before(day)
+	}
+	return false
+}
+
+// compare returns -1, 0 or 1 if a is less than, equal to or greater than b, which are of the same kind
+func compare(a, b interface{}) int {
+	switch a := a.(type) {
+	case string:
+		return strings.Compare(a, b.(string))
+	case int64:
+		return compareInt(a, b.(int64))
+	case time.Duration:
+		return compareInt(int64(a), int64(b.(time.Duration)))
+	case time.Time:
+		return compareInt(a.UnixNano(), b.(time.Time).UnixNano())
+	case bool:
+		if a == b.(bool) {
+			return 0
+		} else if a {
+			return 1
+		}
+		return -1
+	}
+	return 0
+}
+
+func compareInt(a, b int64) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+// categoryTexts returns the texts of the categories and their subcategories
+func categoryTexts(categories []*protos.Category) []string {
+	var texts []string
+	for _, c := range categories {
+		texts = append(texts, c.Text)
+		texts = append(texts, categoryTexts(c.Category)...)
+	}
+	return texts
+}
+
+// offset returns how far into the episode the user is
+func offset(r *record) time.Duration {
+	if r.userEpi == nil {
+		return 0
+	}
+	return time.Duration(r.userEpi.Offset) * time.Millisecond
+}
+
+// timestampOf returns the timestamp of the time
+func timestampOf(t time.Time) *timestamp.Timestamp {
+	ts, _ := ptypes.TimestampProto(t)
+	return ts
+}
+
+// toTime returns the time of the timestamp, the zero time if it's nil
+func toTime(ts *timestamp.Timestamp) time.Time {
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
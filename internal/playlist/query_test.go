@@ -0,0 +1,177 @@
+package playlist
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+func TestParse(t *testing.T) {
+	podIDs := []*protos.ObjectID{protos.ObjectIDFromHex("pod_id")}
+	podcasts := db.Filter{"$in": podIDs}
+	now := time.Date(2021, 2, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		query      string
+		wantFilter db.Filter
+		wantRest   int
+		wantSort   string
+		wantOrder  int
+		wantErr    bool
+	}{
+		{
+			name:       "empty",
+			query:      "",
+			wantFilter: db.Filter{"podcastid": podcasts},
+			wantSort:   "pubdate",
+			wantOrder:  -1,
+		},
+		{
+			name:  "equality_compiled",
+			query: `podcast.author = "Sam Schwartz" and season == 2 and title = Intro and played = false`,
+			wantFilter: db.Filter{"podcastid": podcasts, "season": db.Filter{"$eq": int64(2)}, "title": db.Filter{"$eq": "Intro"},
+				"_id": db.Filter{"$nin": []*protos.ObjectID{}}},
+			wantSort:  "pubdate",
+			wantOrder: -1,
+		},
+		{
+			name:  "ranges_compiled",
+			query: `duration<30m AND season >= 2 and podcast.category = Technology and title ~ "news?" sort duration`,
+			wantFilter: db.Filter{"podcastid": podcasts, "durationmillis": db.Filter{"$lt": int64(30 * 60000)},
+				"season": db.Filter{"$gte": int64(2)}, "title": db.Filter{"$regex": `news\?`, "$options": "i"}},
+			wantSort:  "durationmillis",
+			wantOrder: 1,
+		},
+		{
+			name:  "dates_by_day",
+			query: `pubdate = 2020-01-31 and age <= 2w and lastseen != 2021-01-01 sort podcast.title desc`,
+			wantFilter: db.Filter{"podcastid": podcasts, "_id": db.Filter{"$nin": []*protos.ObjectID{}},
+				"pubdate": db.Filter{"$gte": timestampOf(time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC)),
+					"$lt": timestampOf(time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC))}},
+			// the same operator on pubdate can't be compiled twice
+			wantRest: 1,
+		},
+		{
+			name:       "age",
+			query:      `age < 2w sort age desc`,
+			wantFilter: db.Filter{"podcastid": podcasts, "pubdate": db.Filter{"$gt": timestampOf(now.Add(-14 * 24 * time.Hour))}},
+			wantSort:   "pubdate",
+			wantOrder:  1,
+		},
+		{
+			name:       "not_compiled",
+			query:      `remaining < 5m and pubdate != 2020-01-01 and duration < 1500us and age = 1d sort remaining`,
+			wantFilter: db.Filter{"podcastid": podcasts},
+			wantRest:   4,
+		},
+		{name: "unknown_field", query: `rating > 3`, wantErr: true},
+		{name: "invalid_op", query: `played < true`, wantErr: true},
+		{name: "contains_number", query: `season ~ 2`, wantErr: true},
+		{name: "invalid_duration", query: `duration < 30 minutes`, wantErr: true},
+		{name: "invalid_bool", query: `played = yes`, wantErr: true},
+		{name: "invalid_date", query: `pubdate > 01/31/2020`, wantErr: true},
+		{name: "incomplete", query: `played = false and duration <`, wantErr: true},
+		{name: "missing_and", query: `played = false duration < 30m`, wantErr: true},
+		{name: "unterminated_string", query: `title = "news`, wantErr: true},
+		{name: "sort_without_field", query: `played = false sort`, wantErr: true},
+		{name: "sort_by_list", query: `sort podcast.category`, wantErr: true},
+		{name: "after_sort", query: `sort pubdate asc played = false`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			filter, rest := got.episodeFilter(podIDs, nil, now)
+			if !reflect.DeepEqual(filter, tt.wantFilter) || len(rest) != tt.wantRest {
+				t.Errorf("Query.episodeFilter() = %v, %d conditions left, want %v, %d", filter, len(rest), tt.wantFilter, tt.wantRest)
+			}
+			if key, order, _ := got.dbSort(); key != tt.wantSort || order != tt.wantOrder {
+				t.Errorf("Query.dbSort() = %s %d, want %s %d", key, order, tt.wantSort, tt.wantOrder)
+			}
+		})
+	}
+}
+
+func TestQuery_episodeFilter(t *testing.T) {
+	played, started, unstarted := protos.ObjectIDFromHex("played"), protos.ObjectIDFromHex("started"), protos.ObjectIDFromHex("unstarted")
+	userEpis := []*protos.UserEpisode{
+		{EpisodeID: played, Played: true},
+		{EpisodeID: started, Offset: 60000},
+		{EpisodeID: unstarted},
+	}
+	tests := []struct {
+		query string
+		want  db.Filter
+	}{
+		{query: `played = true`, want: db.Filter{"$in": []*protos.ObjectID{played}}},
+		// episodes never played match, so those played are left out
+		{query: `played = false`, want: db.Filter{"$nin": []*protos.ObjectID{played}}},
+		{query: `inprogress = true`, want: db.Filter{"$in": []*protos.ObjectID{started}}},
+		{query: `offset < 30s`, want: db.Filter{"$nin": []*protos.ObjectID{started}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			filter, rest := q.episodeFilter(nil, userEpis, time.Now())
+			if !reflect.DeepEqual(filter["_id"], tt.want) || len(rest) != 0 {
+				t.Errorf("Query.episodeFilter() _id = %v, %d conditions left, want %v", filter["_id"], len(rest), tt.want)
+			}
+		})
+	}
+}
+
+func TestQuery_match(t *testing.T) {
+	now := time.Now()
+	r := &record{
+		epi: &protos.Episode{Title: "Weekly News", Season: 2, DurationMillis: int64(40 * time.Minute / time.Millisecond),
+			PubDate: timestampOf(time.Date(2020, 3, 10, 15, 0, 0, 0, time.UTC))},
+		pod: &protos.Podcast{Title: "Show", Category: []*protos.Category{
+			{Text: "Technology", Category: []*protos.Category{{Text: "Podcasting"}}},
+		}},
+		userEpi: &protos.UserEpisode{Offset: int64(25 * time.Minute / time.Millisecond)},
+		now:     now,
+	}
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{query: `title ~ NEWS`, want: true},
+		{query: `title = "weekly news"`, want: false},
+		{query: `title != "Weekly News"`, want: false},
+		{query: `podcast.category = Podcasting`, want: true},
+		{query: `podcast.category != Technology`, want: false},
+		{query: `podcast.category != Comedy`, want: true},
+		{query: `podcast.category ~ tech`, want: true},
+		{query: `duration < 30m`, want: false},
+		{query: `remaining < 30m and inprogress = true and played = false`, want: true},
+		{query: `season > 1 and season <= 2`, want: true},
+		{query: `lastseen > 2000-01-01`, want: false},
+		{query: `pubdate = 2020-03-10`, want: true},
+		{query: `pubdate != 2020-03-10`, want: false},
+		{query: `pubdate <= 2020-03-10 and pubdate >= 2020-03-10`, want: true},
+		{query: `pubdate > 2020-03-10`, want: false},
+		{query: `pubdate < 2020-03-11 and pubdate > 2020-03-09`, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := q.match(r); got != tt.want {
+				t.Errorf("Query.match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package podcast
+
+import (
+	"errors"
+	"fmt"
+)
+
+// adtsSampleRates are the sample rates by the index in ADTS headers
+var adtsSampleRates = []int{96000, 88200, 64000, 48000, 44100, 32000, 24000, 22050, 16000, 12000, 11025, 8000, 7350}
+
+// adtsHeader is a parsed ADTS frame header of raw AAC audio
+type adtsHeader struct {
+	frameSize  int
+	samples    int
+	sampleRate int
+}
+
+// parseADTSHeader parses the ADTS frame header at the start of b
+func parseADTSHeader(b []byte) (*adtsHeader, bool) {
+	// the sync word is followed by the layer, which is always 0
+	if len(b) < 7 || b[0] != 0xff || b[1]&0xf6 != 0xf0 {
+		return nil, false
+	}
+	rateIndex := int(b[2]>>2) & 0x0f
+	if rateIndex >= len(adtsSampleRates) {
+		return nil, false
+	}
+	headerLen := 7
+	if b[1]&0x01 == 0 {
+		// crc
+		headerLen = 9
+	}
+	h := &adtsHeader{
+		frameSize:  int(b[3]&0x03)<<11 | int(b[4])<<3 | int(b[5]>>5),
+		samples:    (int(b[6]&0x03) + 1) * 1024,
+		sampleRate: adtsSampleRates[rateIndex],
+	}
+	if h.frameSize < headerLen {
+		return nil, false
+	}
+	return h, true
+}
+
+// findADTSFrame returns the offset of the first ADTS frame in data, -1 if there is none.
+// a header only counts if the frame after it also starts with a header
+func findADTSFrame(data []byte) int {
+	for i := 0; i+7 <= len(data); i++ {
+		h, ok := parseADTSHeader(data[i:])
+		if !ok {
+			continue
+		}
+		next := i + h.frameSize
+		if next+7 > len(data) {
+			return i
+		}
+		if _, ok := parseADTSHeader(data[next:]); ok {
+			return i
+		}
+	}
+	return -1
+}
+
+// probeADTS finds the duration in millis of the raw AAC enclosure by scanning its first frames
+func probeADTS(e *enclosure) (int64, error) {
+	tag := id3Size(e.head)
+	data, err := e.read(tag, probeBytes)
+	if err != nil {
+		return 0, fmt.Errorf("probeADTS() error: %v", err)
+	}
+	start := findADTSFrame(data)
+	if start < 0 {
+		return 0, errors.New("probeADTS() error: no ADTS frame found")
+	}
+
+	var seconds float64
+	pos := start
+	for pos+7 <= len(data) {
+		h, ok := parseADTSHeader(data[pos:])
+		if !ok || pos+h.frameSize > len(data) {
+			break
+		}
+		seconds += float64(h.samples) / float64(h.sampleRate)
+		pos += h.frameSize
+	}
+	audioLen := int64(-1)
+	if e.size >= 0 {
+		audioLen = e.size - tag - int64(start)
+	}
+	complete := e.size >= 0 && tag+int64(len(data)) >= e.size
+	return scannedDuration(seconds, int64(pos-start), audioLen, complete)
+}
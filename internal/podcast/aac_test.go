@@ -0,0 +1,33 @@
+package podcast
+
+import (
+	"bytes"
+	"testing"
+)
+
+// adtsFrames creates n silent AAC LC stereo frames at 44.1kHz of frameSize bytes
+func adtsFrames(n, frameSize int) []byte {
+	frame := make([]byte, frameSize)
+	copy(frame, []byte{0xff, 0xf1, 0x50, 0x80 | byte(frameSize>>11&0x03), byte(frameSize >> 3), byte(frameSize&0x07)<<5 | 0x1f, 0xfc})
+	return bytes.Repeat(frame, n)
+}
+
+func Test_findADTSFrame(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{name: "frames", data: adtsFrames(3, 371), want: 0},
+		{name: "false_sync", data: append([]byte{0xff, 0xf1, 0x50, 0x80, 0x2e, 0x7f, 0xfc, 0}, adtsFrames(3, 371)...), want: 8},
+		{name: "mp3", data: mp3Frames(mpeg1Header, 3), want: -1},
+		{name: "none", data: []byte("not audio at all"), want: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := findADTSFrame(tt.data); got != tt.want {
+				t.Errorf("findADTSFrame() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package podcast
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// link relations of paged & archived feeds (RFC 5005)
+const (
+	relNext        = "next"
+	relPrevArchive = "prev-archive"
+)
+
+// maxFeedPages bounds the pages followed by a single backfill in case a feed links in circles
+const maxFeedPages = 1000
+
+// feedPage is a page linked to by a paged or archived feed
+type feedPage struct {
+	url     string
+	archive bool
+}
+
+// FindFeedPage finds the imported feed page of the podcast via its url
+func FindFeedPage(dbClient db.Database, podID *protos.ObjectID, url string) (*models.FeedPage, error) {
+	page := &models.FeedPage{}
+	err := dbClient.FindOne(database.ColFeedPage, page, &db.Filter{"podcast_id": podID, "url": url}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("FindFeedPage() error: %v", err)
+	}
+	return page, nil
+}
+
+// UpsertFeedPage upserts the imported feed page
+func UpsertFeedPage(dbClient db.Database, page *models.FeedPage) error {
+	err := dbClient.Upsert(database.ColFeedPage, page, &db.Filter{"podcast_id": page.PodcastID, "url": page.URL})
+	if err != nil {
+		return fmt.Errorf("error upserting feed page: %v", err)
+	}
+	return nil
+}
+
+// BackfillPodcasts imports the back catalog of every podcast's paged & archived feed
+// returns the number of episodes added
+func BackfillPodcasts(dbClient db.Database) (int, error) {
+	added := 0
+	for start, end := 0, 10; ; start, end = end, end+10 {
+		podcasts, err := FindPodcastsByRange(dbClient, start, end)
+		if err != nil {
+			return added, fmt.Errorf("BackfillPodcasts() error retrieving from db: %v", err)
+		}
+		if len(podcasts) == 0 {
+			return added, nil
+		}
+		for _, pod := range podcasts {
+			if pod.Private {
+				continue
+			}
+			n, err := BackfillPodcast(dbClient, pod)
+			added += n
+			if err != nil {
+				log.Printf("BackfillPodcasts() error backfilling podcast %v: %v\n", pod.Title, err)
+			}
+		}
+	}
+}
+
+// BackfillPodcast follows the paged & archived links of the podcast's feed, importing the episodes
+// of every older page. returns the number of episodes added
+func BackfillPodcast(dbClient db.Database, pod *protos.Podcast) (int, error) {
+	if pod.Private {
+		// the pages of a private feed carry its credentials, which are only stored encrypted
+		return 0, errors.New("BackfillPodcast() error: private feeds can't be backfilled")
+	}
+	body, _, err := downloadRSS(pod.Rss, &models.FetchState{})
+	if err != nil {
+		return 0, fmt.Errorf("BackfillPodcast() error downloading rss: %v", err)
+	}
+	rssPod, err := parseFeed(body)
+	if err != nil {
+		return 0, fmt.Errorf("BackfillPodcast() error parsing feed: %v", err)
+	}
+	return backfillFeed(dbClient, pod, pod.Rss, rssPod)
+}
+
+// backfillFeed walks the pages linked from the feed at feedURL and imports their episodes.
+// archive documents never change, so those already imported are not fetched again.
+// the pages of a paged feed shift as episodes are published, so they are fetched again but
+// the walk stops at a previously imported page that has no new episodes.
+// paged feed pages are only recorded once the whole walk succeeded, that way an
+// interrupted backfill is resumed from the start instead of stopping early
+func backfillFeed(dbClient db.Database, pod *protos.Podcast, feedURL string, rssPod *models.RSSPodcast) (int, error) {
+	added := 0
+	visited := map[string]bool{feedURL: true}
+	var paged []*models.FeedPage
+	queue := pageLinks(feedURL, rssPod)
+	for pages := 0; len(queue) > 0 && pages < maxFeedPages; pages++ {
+		page := queue[0]
+		queue = queue[1:]
+		if visited[page.url] {
+			continue
+		}
+		visited[page.url] = true
+
+		_, err := FindFeedPage(dbClient, pod.Id, page.url)
+		imported := err == nil
+		if page.archive && imported {
+			continue
+		}
+
+		body, _, err := downloadRSS(page.url, &models.FetchState{})
+		if err != nil {
+			return added, fmt.Errorf("backfillFeed() error downloading page %v: %v", page.url, err)
+		}
+		pagePod, err := parseFeed(body)
+		if err != nil {
+			return added, fmt.Errorf("backfillFeed() error parsing page %v: %v", page.url, err)
+		}
+		n, err := importEpisodes(dbClient, pod, pagePod)
+		added += n
+		if err != nil {
+			return added, fmt.Errorf("backfillFeed() error importing page %v: %v", page.url, err)
+		}
+
+		consumed := &models.FeedPage{URL: page.url, PodcastID: pod.Id, Archive: page.archive, ConsumedAt: time.Now()}
+		if page.archive {
+			err = UpsertFeedPage(dbClient, consumed)
+			if err != nil {
+				return added, fmt.Errorf("backfillFeed() error: %v", err)
+			}
+		} else {
+			paged = append(paged, consumed)
+			if imported && n == 0 {
+				// the rest of the paged feed was imported by a previous backfill
+				continue
+			}
+		}
+		queue = append(queue, pageLinks(page.url, pagePod)...)
+	}
+
+	for _, page := range paged {
+		err := UpsertFeedPage(dbClient, page)
+		if err != nil {
+			return added, fmt.Errorf("backfillFeed() error: %v", err)
+		}
+	}
+	return added, nil
+}
+
+// importEpisodes reconciles every episode of the feed page, returns the number of new episodes
+func importEpisodes(dbClient db.Database, pod *protos.Podcast, rssPod *models.RSSPodcast) (int, error) {
+	added := 0
+	for e := range rssPod.RSSEpisodes {
+		epi := convertEpisode(pod.Id, &rssPod.RSSEpisodes[e])
+		if epi.Author == "" {
+			epi.Author = pod.Author
+		}
+		isNew, err := reconcileEpisode(dbClient, epi, false)
+		if err != nil {
+			return added, err
+		}
+		if isNew {
+			added++
+		}
+	}
+	return added, nil
+}
+
+// pageLinks returns the next & prev-archive pages linked to by the feed, resolved against its url
+func pageLinks(feedURL string, p *models.RSSPodcast) []feedPage {
+	base, err := url.Parse(feedURL)
+	if err != nil {
+		return nil
+	}
+	var pages []feedPage
+	for _, link := range p.AtomLinks {
+		rel := strings.ToLower(strings.TrimSpace(link.Rel))
+		if rel != relNext && rel != relPrevArchive {
+			continue
+		}
+		ref, err := url.Parse(strings.TrimSpace(link.Href))
+		if err != nil || link.Href == "" {
+			continue
+		}
+		pages = append(pages, feedPage{url: base.ResolveReference(ref).String(), archive: rel == relPrevArchive})
+	}
+	return pages
+}
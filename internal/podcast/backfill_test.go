@@ -0,0 +1,110 @@
+package podcast
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/stockpile/mock"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// pagedFeed renders an rss page with the episodes & atom links
+func pagedFeed(links string, episodes ...int) string {
+	items := ""
+	for _, e := range episodes {
+		items += fmt.Sprintf(`<item><title>Episode %d</title><guid>episode-%d</guid><pubDate>Thu, %02d Oct 2020 15:00:00 +0000</pubDate>`+
+			`<enclosure url="https://example.com/%d.mp3" type="audio/mpeg" /><itunes:duration>10:00</itunes:duration></item>`, e, e, e, e)
+	}
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
+<channel><title>Paged Show</title>` + links + items + `</channel></rss>`
+}
+
+// createPagedFeedServer serves a paged feed whose second page links to an archive, counting the requests of each page
+func createPagedFeedServer() (*httptest.Server, map[string]int, *sync.Mutex) {
+	pages := map[string]string{
+		"/feed":     pagedFeed(`<atom:link rel="self" href="/feed" /><atom:link rel="next" href="/page2" />`, 5, 4),
+		"/page2":    pagedFeed(`<atom:link rel="prev-archive" href="archive1" />`, 3, 2),
+		"/archive1": pagedFeed(`<atom:link rel="next" href="/feed" />`, 1),
+	}
+	hits := map[string]int{}
+	var mutex sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		mutex.Lock()
+		hits[req.URL.Path]++
+		mutex.Unlock()
+		page, ok := pages[req.URL.Path]
+		if !ok {
+			res.WriteHeader(http.StatusNotFound)
+			return
+		}
+		res.Write([]byte(page))
+	}))
+	return server, hits, &mutex
+}
+
+func TestBackfillPodcast(t *testing.T) {
+	server, hits, mutex := createPagedFeedServer()
+	defer server.Close()
+	mockDB := mock.CreateDB()
+	insertOrFail(t, mockDB, database.ColEpisode, &protos.Episode{Id: protos.NewObjectID(), PodcastID: protos.NewObjectID()})
+
+	err := AddNewPodcast(mockDB, server.URL+"/feed")
+	if err != nil {
+		t.Fatalf("AddNewPodcast() error = %v", err)
+	}
+	pod, err := FindPodcastByFeed(mockDB, server.URL+"/feed")
+	if err != nil {
+		t.Fatalf("AddNewPodcast() error finding podcast: %v", err)
+	}
+	var episodes []*protos.Episode
+	err = mockDB.FindAll(database.ColEpisode, &episodes, &db.Filter{"podcastid": pod.Id}, nil)
+	if err != nil || len(episodes) != 5 {
+		t.Fatalf("AddNewPodcast() episode count = %v, want 5, error = %v", len(episodes), err)
+	}
+	for _, url := range []string{server.URL + "/page2", server.URL + "/archive1"} {
+		if _, err := FindFeedPage(mockDB, pod.Id, url); err != nil {
+			t.Errorf("AddNewPodcast() page %v not recorded: %v", url, err)
+		}
+	}
+
+	// re-running only fetches the paged feed again, stopping at the page without new episodes
+	added, err := BackfillPodcast(mockDB, pod)
+	if err != nil {
+		t.Fatalf("BackfillPodcast() error = %v", err)
+	}
+	if added != 0 {
+		t.Errorf("BackfillPodcast() added = %v, want 0", added)
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	want := map[string]int{"/feed": 2, "/page2": 2, "/archive1": 1}
+	for path, count := range want {
+		if hits[path] != count {
+			t.Errorf("BackfillPodcast() requests of %v = %v, want %v", path, hits[path], count)
+		}
+	}
+}
+
+func Test_pageLinks(t *testing.T) {
+	p := &models.RSSPodcast{AtomLinks: []models.AtomLink{
+		{Rel: "self", Href: "https://example.com/feed"},
+		{Rel: "next", Href: "?page=2"},
+		{Rel: "Prev-Archive", Href: "https://archive.example.com/2019.xml"},
+		{Rel: "next"},
+	}}
+	want := []feedPage{
+		{url: "https://example.com/feed?page=2"},
+		{url: "https://archive.example.com/2019.xml", archive: true},
+	}
+	got := pageLinks("https://example.com/feed", p)
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("pageLinks() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,170 @@
+package podcast
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+const (
+	// maxChapterTag is the largest ID3 tag or moov box read for chapters
+	maxChapterTag = 16 << 20
+	// how often queued chapter extractions are checked for
+	chapterInterval = time.Minute
+	// extractions per check
+	chapterBatch = 50
+	// failed extractions are retried this many times, backing off by chapterInterval each time
+	maxChapterAttempts = 5
+)
+
+// queueChapters queues the extraction of the chapters embedded in the episode's enclosure
+func queueChapters(dbClient db.Database, epi *protos.Episode) {
+	if epi.MP3URL == "" {
+		return
+	}
+	job := &models.ChapterJob{EpisodeID: epi.Id, URL: epi.MP3URL, Type: epi.EnclosureType, Due: time.Now()}
+	err := dbClient.Upsert(database.ColChapterJob, job, &db.Filter{"episode_id": epi.Id})
+	if err != nil {
+		log.Println("queueChapters() error upserting job:", err)
+	}
+}
+
+// NextChapter returns the first chapter starting after offset millis, nil if there is none
+func NextChapter(chapters []*protos.Chapter, offset int64) *protos.Chapter {
+	var next *protos.Chapter
+	for _, c := range chapters {
+		if c.StartMillis > offset && (next == nil || c.StartMillis < next.StartMillis) {
+			next = c
+		}
+	}
+	return next
+}
+
+// ExtractChapters extracts the chapters embedded in the ID3 tag or MP4 chpl box of the enclosure at url,
+// only the tag or moov box is requested. Enclosures without embedded chapters have none
+func ExtractChapters(url, mimeType string) ([]*protos.Chapter, error) {
+	head, size, err := fetchRange(url, 0, probeBytes)
+	if err != nil {
+		return nil, fmt.Errorf("ExtractChapters() error: %v", err)
+	}
+	e := &enclosure{url: url, head: head, size: size}
+	switch enclosureFormat(mimeType, e) {
+	case formatMP3, formatADTS:
+		return id3Chapters(e)
+	case formatMP4:
+		return mp4Chapters(e)
+	}
+	return nil, nil
+}
+
+// id3Chapters extracts the chapters from the enclosure's ID3 tag
+func id3Chapters(e *enclosure) ([]*protos.Chapter, error) {
+	tag := id3Size(e.head)
+	if tag == 0 {
+		return nil, nil
+	}
+	if tag > maxChapterTag {
+		return nil, fmt.Errorf("id3Chapters() error: ID3 tag of %d bytes is too large", tag)
+	}
+	data, err := e.read(0, tag)
+	if err != nil {
+		return nil, fmt.Errorf("id3Chapters() error reading tag: %v", err)
+	}
+	return parseID3Chapters(data), nil
+}
+
+// ChapterExtractor extracts the chapters embedded in the enclosures of new episodes in the background
+type ChapterExtractor struct {
+	dbClient db.Database
+	stop     chan struct{}
+}
+
+// NewChapterExtractor creates a chapter extractor
+func NewChapterExtractor(dbClient db.Database) *ChapterExtractor {
+	return &ChapterExtractor{dbClient: dbClient, stop: make(chan struct{})}
+}
+
+// Start extracts the queued chapters every chapterInterval, blocks until Stop is called
+func (c *ChapterExtractor) Start() {
+	c.extractQueued()
+	ticker := time.NewTicker(chapterInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.extractQueued()
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// Stop stops the extractor
+func (c *ChapterExtractor) Stop() {
+	close(c.stop)
+}
+
+// extractQueued extracts the chapters of the queued episodes that are due
+func (c *ChapterExtractor) extractQueued() {
+	var jobs []*models.ChapterJob
+	opts := db.CreateOptions().SetSort("due", 1).SetLimit(chapterBatch)
+	err := c.dbClient.FindAll(database.ColChapterJob, &jobs, nil, opts)
+	if err != nil {
+		log.Println("ChapterExtractor.extractQueued() error finding jobs:", err)
+		return
+	}
+	now := time.Now()
+	for _, job := range jobs {
+		if job.Due.After(now) {
+			break
+		}
+		err = extractChapterJob(c.dbClient, job)
+		if err != nil {
+			log.Printf("ChapterExtractor.extractQueued() error extracting chapters of %v: %v\n", job.URL, err)
+		}
+	}
+}
+
+// extractChapterJob extracts the chapters of the job's enclosure and stores them on its episode
+func extractChapterJob(dbClient db.Database, job *models.ChapterJob) error {
+	chapters, err := ExtractChapters(job.URL, job.Type)
+	if err != nil {
+		job.Attempts++
+		if job.Attempts >= maxChapterAttempts {
+			if deleteErr := deleteChapterJob(dbClient, job); deleteErr != nil {
+				log.Println("extractChapterJob() error:", deleteErr)
+			}
+			return fmt.Errorf("extractChapterJob() giving up: %v", err)
+		}
+		job.Due = time.Now().Add(chapterInterval * time.Duration(job.Attempts))
+		if upsertErr := dbClient.Upsert(database.ColChapterJob, job, &db.Filter{"episode_id": job.EpisodeID, "url": job.URL}); upsertErr != nil {
+			log.Println("extractChapterJob() error upserting job:", upsertErr)
+		}
+		return fmt.Errorf("extractChapterJob() error: %v", err)
+	}
+
+	epi, err := FindEpisodeByID(dbClient, job.EpisodeID)
+	// the enclosure may have been replaced while extracting, its own job is queued
+	if err == nil && epi.MP3URL == job.URL {
+		epi.EmbeddedChapters = chapters
+		err = UpsertEpisode(dbClient, epi)
+		if err != nil {
+			return fmt.Errorf("extractChapterJob() error: %v", err)
+		}
+	}
+	return deleteChapterJob(dbClient, job)
+}
+
+// deleteChapterJob deletes the job, unless the episode's enclosure was replaced and queued again
+func deleteChapterJob(dbClient db.Database, job *models.ChapterJob) error {
+	err := dbClient.Delete(database.ColChapterJob, &db.Filter{"episode_id": job.EpisodeID, "url": job.URL})
+	if err != nil {
+		return fmt.Errorf("deleteChapterJob() error: %v", err)
+	}
+	return nil
+}
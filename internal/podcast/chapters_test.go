@@ -0,0 +1,174 @@
+package podcast
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/stockpile/mock"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// chapterServer serves enclosures with embedded chapters, counting the bytes written
+func chapterServer(written *int) *httptest.Server {
+	files := map[string][]byte{
+		"/chapters.mp3": append(id3v2Tag(4, 0,
+			chapFrame(4, "chp0", 0, 60000, titleFrame(4, "Intro")),
+			chapFrame(4, "chp1", 60000, 120000, titleFrame(4, "News")),
+			// artwork larger than the probed head
+			id3v2Frame(4, "APIC", append([]byte{0, 'i', 'm', 'a', 'g', 'e', '/', 'j', 'p', 'e', 'g', 0, 3, 0}, make([]byte, 200<<10)...)),
+		), mp3Frames(mpeg1Header, 5000)...),
+		"/no_chapters.mp3": append(id3Tag(100), mp3Frames(mpeg1Header, 100)...),
+		"/chapters.m4a": m4aFile(append(mvhdBox(0, 1000, 600000), chplBox(0,
+			&protos.Chapter{StartMillis: 0, Title: "Intro"}, &protos.Chapter{StartMillis: 90500, Title: "Interview"})...), 1<<20, true),
+		"/opus.ogg": opusFile(312, 2880312, 100),
+	}
+	return httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		file, ok := files[req.URL.Path]
+		if !ok {
+			http.NotFound(res, req)
+			return
+		}
+		http.ServeContent(countingWriter{res, written}, req, req.URL.Path, time.Time{}, bytes.NewReader(file))
+	}))
+}
+
+func TestExtractChapters(t *testing.T) {
+	written := 0
+	server := chapterServer(&written)
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		path    string
+		want    []*protos.Chapter
+		wantErr bool
+	}{
+		{
+			name: "id3",
+			path: "/chapters.mp3",
+			want: []*protos.Chapter{{StartMillis: 0, EndMillis: 60000, Title: "Intro"}, {StartMillis: 60000, EndMillis: 120000, Title: "News"}},
+		},
+		{
+			name: "mp4",
+			path: "/chapters.m4a",
+			want: []*protos.Chapter{{StartMillis: 0, EndMillis: 90500, Title: "Intro"}, {StartMillis: 90500, EndMillis: 600000, Title: "Interview"}},
+		},
+		{name: "no_chapters", path: "/no_chapters.mp3", want: nil},
+		{name: "ogg", path: "/opus.ogg", want: nil},
+		{name: "not_found", path: "/missing.mp3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			written = 0
+			got, err := ExtractChapters(server.URL+tt.path, "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExtractChapters() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			// only the tag is requested, not the audio
+			if written > 300<<10 {
+				t.Errorf("ExtractChapters() downloaded %v bytes", written)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractChapters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_extractChapterJob(t *testing.T) {
+	written := 0
+	server := chapterServer(&written)
+	defer server.Close()
+	mockDB := mock.CreateDB()
+	epi := &protos.Episode{Id: protos.NewObjectID(), Title: "Chapters", MP3URL: server.URL + "/chapters.mp3"}
+	insertOrFail(t, mockDB, database.ColEpisode, epi)
+	replaced := &protos.Episode{Id: protos.NewObjectID(), Title: "Replaced", MP3URL: server.URL + "/no_chapters.mp3"}
+	insertOrFail(t, mockDB, database.ColEpisode, replaced)
+	missing := &protos.Episode{Id: protos.NewObjectID(), Title: "Missing", MP3URL: server.URL + "/missing.mp3"}
+	insertOrFail(t, mockDB, database.ColEpisode, missing)
+
+	tests := []struct {
+		name         string
+		job          *models.ChapterJob
+		wantErr      bool
+		wantChapters int
+		wantQueued   bool
+	}{
+		{
+			name:         "extracted",
+			job:          &models.ChapterJob{EpisodeID: epi.Id, URL: epi.MP3URL},
+			wantChapters: 2,
+		},
+		{
+			name:         "enclosure_replaced",
+			job:          &models.ChapterJob{EpisodeID: replaced.Id, URL: epi.MP3URL},
+			wantChapters: 0,
+		},
+		{
+			name:       "retried",
+			job:        &models.ChapterJob{EpisodeID: missing.Id, URL: missing.MP3URL},
+			wantErr:    true,
+			wantQueued: true,
+		},
+		{
+			name:    "given_up",
+			job:     &models.ChapterJob{EpisodeID: missing.Id, URL: missing.MP3URL, Attempts: maxChapterAttempts - 1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			insertOrFail(t, mockDB, database.ColChapterJob, tt.job)
+			err := extractChapterJob(mockDB, tt.job)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("extractChapterJob() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			stored, err := FindEpisodeByID(mockDB, tt.job.EpisodeID)
+			if err != nil {
+				t.Fatalf("extractChapterJob() error finding episode: %v", err)
+			}
+			if len(stored.EmbeddedChapters) != tt.wantChapters {
+				t.Errorf("extractChapterJob() stored %v chapters, want %v", len(stored.EmbeddedChapters), tt.wantChapters)
+			}
+			queued := &models.ChapterJob{}
+			err = mockDB.FindOne(database.ColChapterJob, queued, &db.Filter{"episode_id": tt.job.EpisodeID}, nil)
+			if (err == nil) != tt.wantQueued {
+				t.Errorf("extractChapterJob() job queued = %v, want %v", err == nil, tt.wantQueued)
+			}
+			if tt.wantQueued {
+				if queued.Attempts != 1 || !queued.Due.After(time.Now()) {
+					t.Errorf("extractChapterJob() job not backed off: %v", queued)
+				}
+				mockDB.Delete(database.ColChapterJob, &db.Filter{"episode_id": tt.job.EpisodeID})
+			}
+		})
+	}
+}
+
+func TestNextChapter(t *testing.T) {
+	chapters := []*protos.Chapter{{StartMillis: 0, Title: "Intro"}, {StartMillis: 90500, Title: "Interview"}, {StartMillis: 30000, Title: "News"}}
+	tests := []struct {
+		name   string
+		offset int64
+		want   string
+	}{
+		{name: "start", offset: 0, want: "News"},
+		{name: "unordered", offset: 45000, want: "Interview"},
+		{name: "last", offset: 90500, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NextChapter(chapters, tt.offset)
+			if (got == nil && tt.want != "") || (got != nil && got.Title != tt.want) {
+				t.Errorf("NextChapter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,488 @@
+package podcast
+
+import (
+	"fmt"
+	"log"
+	"math/bits"
+	"sort"
+	"time"
+
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+const (
+	// alignBlock is the sub-fingerprints of a variant matched against the reference at once, ~1.5s
+	alignBlock = 64
+	// blocks matching with a larger percentage of their bits differing are not the same audio
+	maxBlockErrorPercent = 25
+	// refineWindow is the sub-fingerprints compared while extending a match to its exact edges
+	refineWindow = 4
+	// sub-fingerprints repeated more often than maxHashPositions aren't voted with
+	maxHashPositions = 16
+	// alignCandidates is the offsets with the most votes compared per block
+	alignCandidates = 4
+	// minAdMillis is the shortest gap between matching content that is an ad
+	minAdMillis = 5000
+
+	// how often queued fingerprints are checked for
+	fingerprintInterval = 10 * time.Minute
+	// fingerprints per check
+	fingerprintBatch = 10
+	// refetchDelay is how long after the first fetch the enclosure is fetched again, for a different variant
+	refetchDelay = 6 * time.Hour
+	// fingerprintFetches is how many times each enclosure is fetched
+	fingerprintFetches = 2
+	// failed fingerprints are retried this many times, backing off by fingerprintInterval each time
+	maxFingerprintAttempts = 3
+)
+
+// queueFingerprint queues fingerprinting the episode's enclosure if it's an mp3
+func queueFingerprint(dbClient db.Database, epi *protos.Episode) {
+	if !isMP3Enclosure(epi) {
+		return
+	}
+	job := &models.FingerprintJob{EpisodeID: epi.Id, URL: epi.MP3URL, Due: time.Now()}
+	err := dbClient.Upsert(database.ColFingerprint, job, &db.Filter{"episode_id": epi.Id})
+	if err != nil {
+		log.Println("queueFingerprint() error upserting job:", err)
+	}
+}
+
+// FindVariant finds the episode's enclosure variant of length bytes
+func FindVariant(dbClient db.Database, epiID *protos.ObjectID, length int64) (*models.EnclosureVariant, error) {
+	variant := &models.EnclosureVariant{}
+	err := dbClient.FindOne(database.ColVariant, variant, &db.Filter{"episode_id": epiID, "length": length}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("FindVariant() error: %v", err)
+	}
+	return variant, nil
+}
+
+// FindReferenceVariant finds the variant of the episode's enclosure that was fetched first
+func FindReferenceVariant(dbClient db.Database, epiID *protos.ObjectID) (*models.EnclosureVariant, error) {
+	variant := &models.EnclosureVariant{}
+	err := dbClient.FindOne(database.ColVariant, variant, &db.Filter{"episode_id": epiID, "reference": true}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("FindReferenceVariant() error: %v", err)
+	}
+	return variant, nil
+}
+
+// FindAdMarkers returns the ads inserted into the episode's variant of length bytes,
+// or those of the reference variant if that variant is unknown
+func FindAdMarkers(dbClient db.Database, epiID *protos.ObjectID, length int64) ([]*protos.AdMarker, error) {
+	variant, err := FindVariant(dbClient, epiID, length)
+	if err != nil {
+		variant, err = FindReferenceVariant(dbClient, epiID)
+		if err != nil {
+			return nil, fmt.Errorf("FindAdMarkers() error: %v", err)
+		}
+	}
+	return variant.Ads, nil
+}
+
+// MapOffset maps an offset in millis within the episode's variant of fromLength bytes to the same content
+// in the variant of toLength bytes, a length of 0 is the reference variant offsets are stored in.
+// Offsets within an ad map to the start of the content following it, offsets of unknown variants are unchanged
+func MapOffset(dbClient db.Database, epiID *protos.ObjectID, fromLength, toLength, offset int64) int64 {
+	if fromLength == toLength {
+		return offset
+	}
+	if fromLength != 0 {
+		from, err := FindVariant(dbClient, epiID, fromLength)
+		if err == nil && !from.Reference {
+			offset = mapSegments(from.Segments, offset, true)
+		}
+	}
+	if toLength != 0 {
+		to, err := FindVariant(dbClient, epiID, toLength)
+		if err == nil && !to.Reference {
+			offset = mapSegments(to.Segments, offset, false)
+		}
+	}
+	return offset
+}
+
+// mapSegments maps offset within a variant to the reference if toReference, otherwise from the reference to the variant
+func mapSegments(segments []models.VariantSegment, offset int64, toReference bool) int64 {
+	if len(segments) == 0 {
+		return offset
+	}
+	var end int64
+	for _, s := range segments {
+		from, to := s.StartMillis, s.RefStartMillis
+		if !toReference {
+			from, to = to, from
+		}
+		if offset < from {
+			return to
+		}
+		if offset < from+s.LengthMillis {
+			return to + offset - from
+		}
+		end = to + s.LengthMillis
+	}
+	return end
+}
+
+// matchRun is a run of a variant's sub-fingerprints b[b:b+n] matching the reference's a[a:a+n]
+type matchRun struct {
+	a, b, n int
+}
+
+// alignFingerprints finds the runs of b matching a, in order of both.
+// Each block of b is compared around the offsets into a with the most sub-fingerprints matching within a bit,
+// and the offset of the previous block, then adjacent matching blocks are merged & extended to their edges
+func alignFingerprints(a, b []uint32) []matchRun {
+	index := map[uint32][]int{}
+	for i, h := range a {
+		index[h] = append(index[h], i)
+	}
+	maxErrors := alignBlock * 32 * maxBlockErrorPercent / 100
+
+	var runs []matchRun
+	prev, matched := 0, false
+	for start := 0; start+alignBlock <= len(b); start += alignBlock {
+		block := b[start : start+alignBlock]
+		votes := map[int]int{}
+		for j, h := range block {
+			for bit := -1; bit < 32; bit++ {
+				flipped := h
+				if bit >= 0 {
+					flipped ^= 1 << uint(bit)
+				}
+				// common sub-fingerprints, such as silence's, say nothing of where the block is
+				if positions := index[flipped]; len(positions) <= maxHashPositions {
+					for _, i := range positions {
+						votes[i-start-j]++
+					}
+				}
+			}
+		}
+		candidates := topOffsets(votes, alignCandidates)
+		if matched {
+			candidates = append(candidates, prev)
+		}
+		best, bestErrors := 0, maxErrors+1
+		for _, c := range candidates {
+			// overlapping frames make the neighbouring offsets nearly as close, the best is compared
+			for d := c - 2; d <= c+2; d++ {
+				if start+d < 0 || start+d+alignBlock > len(a) {
+					continue
+				}
+				if diff := hamming(a[start+d:start+d+alignBlock], block); diff < bestErrors {
+					best, bestErrors = d, diff
+				}
+			}
+		}
+		if bestErrors > maxErrors {
+			matched = false
+			continue
+		}
+
+		// a block continuing the previous one, within a sub-fingerprint's jitter, extends its run
+		last := len(runs) - 1
+		if matched && best-prev >= -1 && best-prev <= 1 {
+			runs[last].n = start + alignBlock - runs[last].b
+		} else {
+			runs = append(runs, matchRun{a: start + best, b: start, n: alignBlock})
+		}
+		prev, matched = best, true
+	}
+	runs = monotonicRuns(runs)
+	refineRuns(a, b, runs)
+	return runs
+}
+
+// topOffsets returns the n offsets with the most votes, of at least 2
+func topOffsets(votes map[int]int, n int) []int {
+	offsets := []int{}
+	for d, v := range votes {
+		if v >= 2 {
+			offsets = append(offsets, d)
+		}
+	}
+	sort.Slice(offsets, func(i, j int) bool {
+		if votes[offsets[i]] != votes[offsets[j]] {
+			return votes[offsets[i]] > votes[offsets[j]]
+		}
+		return offsets[i] < offsets[j]
+	})
+	if len(offsets) > n {
+		offsets = offsets[:n]
+	}
+	return offsets
+}
+
+// monotonicRuns drops runs matching content before the previous run, such as a repeated jingle,
+// and trims those overlapping it
+func monotonicRuns(runs []matchRun) []matchRun {
+	kept := runs[:0]
+	for _, r := range runs {
+		if len(kept) > 0 {
+			last := kept[len(kept)-1]
+			overlap := last.a + last.n - r.a
+			if overlap >= r.n {
+				continue
+			}
+			if overlap > 0 {
+				r.a, r.b, r.n = r.a+overlap, r.b+overlap, r.n-overlap
+			}
+		}
+		kept = append(kept, r)
+	}
+	return kept
+}
+
+// refineRuns trims or extends the runs to the edges of the matching content, up to their neighbours.
+// Blocks only partly matching are within maxBlockErrorPercent, so runs are first trimmed of any mismatched edges
+func refineRuns(a, b []uint32, runs []matchRun) {
+	maxErrors := refineWindow * 32 * maxBlockErrorPercent / 100
+	for i := range runs {
+		r := &runs[i]
+		for r.n > refineWindow && hamming(a[r.a+r.n-refineWindow:r.a+r.n], b[r.b+r.n-refineWindow:r.b+r.n]) > maxErrors {
+			r.n--
+		}
+		for r.n > refineWindow && hamming(a[r.a:r.a+refineWindow], b[r.b:r.b+refineWindow]) > maxErrors {
+			r.a, r.b, r.n = r.a+1, r.b+1, r.n-1
+		}
+
+		minA, minB := 0, 0
+		if i > 0 {
+			minA, minB = runs[i-1].a+runs[i-1].n, runs[i-1].b+runs[i-1].n
+		}
+		for r.a-refineWindow >= minA && r.b-refineWindow >= minB &&
+			hamming(a[r.a-refineWindow:r.a], b[r.b-refineWindow:r.b]) <= maxErrors {
+			r.a, r.b, r.n = r.a-1, r.b-1, r.n+1
+		}
+
+		maxA, maxB := len(a), len(b)
+		if i < len(runs)-1 {
+			maxA, maxB = runs[i+1].a, runs[i+1].b
+		}
+		for r.a+r.n+refineWindow <= maxA && r.b+r.n+refineWindow <= maxB &&
+			hamming(a[r.a+r.n:r.a+r.n+refineWindow], b[r.b+r.n:r.b+r.n+refineWindow]) <= maxErrors {
+			r.n++
+		}
+	}
+}
+
+// hamming returns the number of bits differing between the sub-fingerprints of a and b
+func hamming(a, b []uint32) int {
+	n := 0
+	for i := range b {
+		n += bits.OnesCount32(a[i] ^ b[i])
+	}
+	return n
+}
+
+// frameMillis is the offset in millis of the i'th sub-fingerprint
+func frameMillis(i int) int64 {
+	return int64(i) * fingerprintHop * 1000 / fingerprintRate
+}
+
+// adGaps returns the gaps of at least minAdMillis between the runs in the reference a if inReference,
+// otherwise in the variant b, of length sub-fingerprints. There are none if no content matched
+func adGaps(runs []matchRun, length int, inReference bool) []*protos.AdMarker {
+	if len(runs) == 0 {
+		return nil
+	}
+	var ads []*protos.AdMarker
+	gap := func(start, end int) {
+		if frameMillis(end)-frameMillis(start) >= minAdMillis {
+			ads = append(ads, &protos.AdMarker{StartMillis: frameMillis(start), EndMillis: frameMillis(end)})
+		}
+	}
+	cursor := 0
+	for _, r := range runs {
+		start := r.b
+		if inReference {
+			start = r.a
+		}
+		gap(cursor, start)
+		cursor = start + r.n
+	}
+	gap(cursor, length)
+	return ads
+}
+
+// mergeAds returns the union of the ads, ordered by start
+func mergeAds(ads ...[]*protos.AdMarker) []*protos.AdMarker {
+	var all []*protos.AdMarker
+	for _, a := range ads {
+		all = append(all, a...)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].StartMillis < all[j].StartMillis })
+	var merged []*protos.AdMarker
+	for _, ad := range all {
+		if last := len(merged) - 1; last >= 0 && ad.StartMillis <= merged[last].EndMillis {
+			if ad.EndMillis > merged[last].EndMillis {
+				merged[last].EndMillis = ad.EndMillis
+			}
+			continue
+		}
+		merged = append(merged, &protos.AdMarker{StartMillis: ad.StartMillis, EndMillis: ad.EndMillis})
+	}
+	return merged
+}
+
+// addVariant stores the fetched variant of the episode's enclosure. The first fetched is the reference,
+// later ones are aligned against it for their shared segments & the ads inserted into either
+func addVariant(dbClient db.Database, epiID *protos.ObjectID, variant *models.EnclosureVariant) error {
+	variant.EpisodeID = epiID
+	ref, err := FindReferenceVariant(dbClient, epiID)
+	if err != nil {
+		variant.Reference = true
+		return upsertVariant(dbClient, variant)
+	}
+	if _, err = FindVariant(dbClient, epiID, variant.Length); err == nil {
+		return nil
+	}
+
+	runs := alignFingerprints(ref.Fingerprint, variant.Fingerprint)
+	for _, r := range runs {
+		variant.Segments = append(variant.Segments, models.VariantSegment{
+			RefStartMillis: frameMillis(r.a),
+			StartMillis:    frameMillis(r.b),
+			LengthMillis:   frameMillis(r.n),
+		})
+	}
+	variant.Ads = adGaps(runs, len(variant.Fingerprint), false)
+	// only the reference's fingerprint is aligned against
+	variant.Fingerprint = nil
+	err = upsertVariant(dbClient, variant)
+	if err != nil {
+		return err
+	}
+	// each variant reveals the reference's ads it doesn't share
+	ref.Ads = mergeAds(ref.Ads, adGaps(runs, len(ref.Fingerprint), true))
+	return upsertVariant(dbClient, ref)
+}
+
+func upsertVariant(dbClient db.Database, variant *models.EnclosureVariant) error {
+	err := dbClient.Upsert(database.ColVariant, variant, &db.Filter{"episode_id": variant.EpisodeID, "length": variant.Length})
+	if err != nil {
+		return fmt.Errorf("upsertVariant() error: %v", err)
+	}
+	return nil
+}
+
+// AdDetector fingerprints the enclosures of new episodes of subscribed podcasts in the background,
+// fetching each twice to find the dynamically inserted ads
+type AdDetector struct {
+	dbClient db.Database
+	stop     chan struct{}
+}
+
+// NewAdDetector creates an ad detector
+func NewAdDetector(dbClient db.Database) *AdDetector {
+	return &AdDetector{dbClient: dbClient, stop: make(chan struct{})}
+}
+
+// Start fingerprints the queued enclosures every fingerprintInterval, blocks until Stop is called
+func (d *AdDetector) Start() {
+	d.fingerprintQueued()
+	ticker := time.NewTicker(fingerprintInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			d.fingerprintQueued()
+		case <-d.stop:
+			return
+		}
+	}
+}
+
+// Stop stops the detector, the enclosure being fingerprinted is finished
+func (d *AdDetector) Stop() {
+	close(d.stop)
+}
+
+// fingerprintQueued fingerprints the queued enclosures that are due, one at a time as each is downloaded whole
+func (d *AdDetector) fingerprintQueued() {
+	var jobs []*models.FingerprintJob
+	opts := db.CreateOptions().SetSort("due", 1).SetLimit(fingerprintBatch)
+	err := d.dbClient.FindAll(database.ColFingerprint, &jobs, nil, opts)
+	if err != nil {
+		log.Println("AdDetector.fingerprintQueued() error finding jobs:", err)
+		return
+	}
+	now := time.Now()
+	for _, job := range jobs {
+		select {
+		case <-d.stop:
+			return
+		default:
+		}
+		if job.Due.After(now) {
+			break
+		}
+		err = fingerprintJob(d.dbClient, job)
+		if err != nil {
+			log.Printf("AdDetector.fingerprintQueued() error fingerprinting %v: %v\n", job.URL, err)
+		}
+	}
+}
+
+// fingerprintJob fetches & fingerprints the job's enclosure, queueing it again until it has been fetched fingerprintFetches times
+func fingerprintJob(dbClient db.Database, job *models.FingerprintJob) error {
+	epi, err := FindEpisodeByID(dbClient, job.EpisodeID)
+	// the enclosure may have been replaced, its own job is queued
+	if err != nil || epi.MP3URL != job.URL || !hasSubscribers(dbClient, epi.PodcastID) {
+		return deleteFingerprintJob(dbClient, job)
+	}
+
+	variant, err := fingerprintEnclosure(job.URL)
+	if err == nil {
+		err = addVariant(dbClient, job.EpisodeID, variant)
+	}
+	if err != nil {
+		job.Attempts++
+		if job.Attempts >= maxFingerprintAttempts {
+			if deleteErr := deleteFingerprintJob(dbClient, job); deleteErr != nil {
+				log.Println("fingerprintJob() error:", deleteErr)
+			}
+			return fmt.Errorf("fingerprintJob() giving up: %v", err)
+		}
+		job.Due = time.Now().Add(fingerprintInterval * time.Duration(job.Attempts))
+		if upsertErr := upsertFingerprintJob(dbClient, job); upsertErr != nil {
+			log.Println("fingerprintJob() error:", upsertErr)
+		}
+		return fmt.Errorf("fingerprintJob() error: %v", err)
+	}
+
+	job.Fetches++
+	if job.Fetches >= fingerprintFetches {
+		return deleteFingerprintJob(dbClient, job)
+	}
+	job.Attempts = 0
+	job.Due = time.Now().Add(refetchDelay)
+	return upsertFingerprintJob(dbClient, job)
+}
+
+// hasSubscribers returns whether any user is subscribed to the podcast
+func hasSubscribers(dbClient db.Database, podID *protos.ObjectID) bool {
+	err := dbClient.FindOne(database.ColSubscription, &protos.Subscription{}, &db.Filter{"podcastid": podID}, nil)
+	return err == nil
+}
+
+func upsertFingerprintJob(dbClient db.Database, job *models.FingerprintJob) error {
+	err := dbClient.Upsert(database.ColFingerprint, job, &db.Filter{"episode_id": job.EpisodeID, "url": job.URL})
+	if err != nil {
+		return fmt.Errorf("upsertFingerprintJob() error: %v", err)
+	}
+	return nil
+}
+
+// deleteFingerprintJob deletes the job, unless the episode's enclosure was replaced and queued again
+func deleteFingerprintJob(dbClient db.Database, job *models.FingerprintJob) error {
+	err := dbClient.Delete(database.ColFingerprint, &db.Filter{"episode_id": job.EpisodeID, "url": job.URL})
+	if err != nil {
+		return fmt.Errorf("deleteFingerprintJob() error: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,391 @@
+package podcast
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/stockpile/mock"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// sampleHeader is the frame header of test/sample.mp3
+var sampleHeader = []byte{0xff, 0xfb, 0x30, 0x64}
+
+// splitFrames splits mp3 audio without tags into its frames
+func splitFrames(t *testing.T, data []byte) [][]byte {
+	var frames [][]byte
+	for len(data) > 0 {
+		h, err := decodeFrame(data)
+		if err != nil || h.Size() > len(data) {
+			t.Fatalf("splitFrames() invalid frame at %d bytes from the end", len(data))
+		}
+		frames = append(frames, data[:h.Size()])
+		data = data[h.Size():]
+	}
+	return frames
+}
+
+// spliceSilence inserts n silent frames before the frame at i
+func spliceSilence(frames [][]byte, i, n int) []byte {
+	var out []byte
+	for _, f := range frames[:i] {
+		out = append(out, f...)
+	}
+	out = append(out, mp3Frames(sampleHeader, n)...)
+	for _, f := range frames[i:] {
+		out = append(out, f...)
+	}
+	return out
+}
+
+// sampleServer serves test/sample.mp3 & the sample after 230 silent frames, 6 seconds
+func sampleServer(t *testing.T) (*httptest.Server, map[string][]byte) {
+	sample, err := ioutil.ReadFile("./test/sample.mp3")
+	if err != nil {
+		t.Fatalf("sampleServer() error reading sample: %v", err)
+	}
+	enclosures := map[string][]byte{
+		"/sample.mp3":  sample,
+		"/preroll.mp3": spliceSilence(splitFrames(t, sample), 0, 230),
+		"/text.mp3":    bytes.Repeat([]byte("not audio "), 1000),
+	}
+	return httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		enclosure, ok := enclosures[req.URL.Path]
+		if !ok {
+			http.NotFound(res, req)
+			return
+		}
+		res.Write(enclosure)
+	})), enclosures
+}
+
+func TestFingerprintEnclosure(t *testing.T) {
+	server, enclosures := sampleServer(t)
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		path         string
+		wantDuration int64
+		wantSilent   int // leading sub-fingerprints of silence
+		wantErr      bool
+	}{
+		{name: "sample", path: "/sample.mp3", wantDuration: 10031},
+		{name: "preroll", path: "/preroll.mp3", wantDuration: 16039, wantSilent: 200},
+		{name: "not_audio", path: "/text.mp3", wantErr: true},
+		{name: "not_found", path: "/missing.mp3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fingerprintEnclosure(server.URL + tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fingerprintEnclosure() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			enclosure := enclosures[tt.path]
+			sum := sha256.Sum256(enclosure)
+			if got.Length != int64(len(enclosure)) || got.SHA256 != hex.EncodeToString(sum[:]) {
+				t.Errorf("fingerprintEnclosure() length = %v, sha256 = %v, want %v bytes", got.Length, got.SHA256, len(enclosure))
+			}
+			if got.DurationMillis != tt.wantDuration {
+				t.Errorf("fingerprintEnclosure() duration = %v, want %v", got.DurationMillis, tt.wantDuration)
+			}
+			// a sub-fingerprint every hop, after the first window
+			wantLen := int(tt.wantDuration*fingerprintRate/1000-fingerprintWindow) / fingerprintHop
+			if len(got.Fingerprint) < wantLen-2 || len(got.Fingerprint) > wantLen+2 {
+				t.Errorf("fingerprintEnclosure() %v sub-fingerprints, want %v", len(got.Fingerprint), wantLen)
+			}
+			for i, sub := range got.Fingerprint[:tt.wantSilent] {
+				if sub != 0 {
+					t.Fatalf("fingerprintEnclosure() sub-fingerprint %d of silence = %x", i, sub)
+				}
+			}
+		})
+	}
+}
+
+// syntheticAudio is n random sub-fingerprints
+func syntheticAudio(r *rand.Rand, n int) []uint32 {
+	f := make([]uint32, n)
+	for i := range f {
+		f[i] = r.Uint32()
+	}
+	return f
+}
+
+// reencoded flips each bit of the fingerprint with a 1 in 10 chance, like the same audio encoded again
+func reencoded(r *rand.Rand, f []uint32) []uint32 {
+	out := make([]uint32, len(f))
+	for i, sub := range f {
+		for bit := uint(0); bit < 32; bit++ {
+			if r.Intn(10) == 0 {
+				sub ^= 1 << bit
+			}
+		}
+		out[i] = sub
+	}
+	return out
+}
+
+func concat(parts ...[]uint32) []uint32 {
+	var out []uint32
+	for _, p := range parts {
+		out = append(out, p...)
+	}
+	return out
+}
+
+func Test_alignFingerprints(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	content := syntheticAudio(r, 3000)
+	ad, otherAd := syntheticAudio(r, 600), syntheticAudio(r, 900)
+	// markers of the ads, ~23ms a sub-fingerprint
+	marker := func(start, end int) *protos.AdMarker {
+		return &protos.AdMarker{StartMillis: frameMillis(start), EndMillis: frameMillis(end)}
+	}
+
+	tests := []struct {
+		name        string
+		a, b        []uint32
+		wantAAds    []*protos.AdMarker
+		wantBAds    []*protos.AdMarker
+		wantMatched bool
+	}{
+		{name: "identical", a: content, b: reencoded(r, content), wantMatched: true},
+		{
+			name:        "preroll",
+			a:           content,
+			b:           reencoded(r, concat(ad, content)),
+			wantBAds:    []*protos.AdMarker{marker(0, 600)},
+			wantMatched: true,
+		},
+		{
+			name:        "midroll",
+			a:           content,
+			b:           reencoded(r, concat(content[:1500], ad, content[1500:])),
+			wantBAds:    []*protos.AdMarker{marker(1500, 2100)},
+			wantMatched: true,
+		},
+		{
+			name:        "postroll",
+			a:           reencoded(r, concat(content, otherAd)),
+			b:           content,
+			wantAAds:    []*protos.AdMarker{marker(3000, 3900)},
+			wantMatched: true,
+		},
+		{
+			name:        "replaced_ads",
+			a:           concat(content[:1000], otherAd, content[1000:]),
+			b:           reencoded(r, concat(content[:1000], ad, content[1000:2000], ad, content[2000:])),
+			wantAAds:    []*protos.AdMarker{marker(1000, 1900)},
+			wantBAds:    []*protos.AdMarker{marker(1000, 1600), marker(2600, 3200)},
+			wantMatched: true,
+		},
+		{name: "unrelated", a: content, b: otherAd, wantMatched: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs := alignFingerprints(tt.a, tt.b)
+			if (len(runs) > 0) != tt.wantMatched {
+				t.Fatalf("alignFingerprints() = %v, want matched %v", runs, tt.wantMatched)
+			}
+			for _, run := range runs {
+				if diff := hamming(tt.a[run.a:run.a+run.n], tt.b[run.b:run.b+run.n]); diff > run.n*32*maxBlockErrorPercent/100 {
+					t.Errorf("alignFingerprints() run %v differs by %v bits", run, diff)
+				}
+			}
+			checkAds(t, "reference", adGaps(runs, len(tt.a), true), tt.wantAAds)
+			checkAds(t, "variant", adGaps(runs, len(tt.b), false), tt.wantBAds)
+		})
+	}
+}
+
+// checkAds checks the ads were found within a tenth of a second of their edges
+func checkAds(t *testing.T, variant string, got, want []*protos.AdMarker) {
+	if len(got) != len(want) {
+		t.Errorf("adGaps() %v ads = %v, want %v", variant, got, want)
+		return
+	}
+	for i := range got {
+		if abs(got[i].StartMillis-want[i].StartMillis) > 100 || abs(got[i].EndMillis-want[i].EndMillis) > 100 {
+			t.Errorf("adGaps() %v ad = %v, want %v", variant, got[i], want[i])
+		}
+	}
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func Test_mapSegments(t *testing.T) {
+	// a 30s preroll then content, with a 60s midroll replacing the reference's 20s one at 10 minutes
+	segments := []models.VariantSegment{
+		{RefStartMillis: 0, StartMillis: 30000, LengthMillis: 600000},
+		{RefStartMillis: 620000, StartMillis: 690000, LengthMillis: 1200000},
+	}
+	tests := []struct {
+		name        string
+		offset      int64
+		toReference bool
+		want        int64
+	}{
+		{name: "in_preroll", offset: 15000, toReference: true, want: 0},
+		{name: "content", offset: 60000, toReference: true, want: 30000},
+		{name: "in_midroll", offset: 650000, toReference: true, want: 620000},
+		{name: "after_midroll", offset: 700000, toReference: true, want: 630000},
+		{name: "after_content", offset: 2000000, toReference: true, want: 1820000},
+		{name: "from_reference", offset: 30000, toReference: false, want: 60000},
+		{name: "in_reference_midroll", offset: 610000, toReference: false, want: 690000},
+		{name: "after_reference_midroll", offset: 630000, toReference: false, want: 700000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mapSegments(segments, tt.offset, tt.toReference); got != tt.want {
+				t.Errorf("mapSegments() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapOffset(t *testing.T) {
+	mockDB := mock.CreateDB()
+	epiID := protos.NewObjectID()
+	r := rand.New(rand.NewSource(2))
+	content, midroll, preroll := syntheticAudio(r, 3000), syntheticAudio(r, 600), syntheticAudio(r, 600)
+	// the reference has a midroll, the variant a preroll
+	ref := &models.EnclosureVariant{Length: 1000, Fingerprint: concat(content[:1500], midroll, content[1500:])}
+	variant := &models.EnclosureVariant{Length: 2000, Fingerprint: reencoded(r, concat(preroll, content))}
+	for _, v := range []*models.EnclosureVariant{ref, variant} {
+		err := addVariant(mockDB, epiID, v)
+		if err != nil {
+			t.Fatalf("addVariant() error = %v", err)
+		}
+	}
+	// a refetched variant changes nothing
+	err := addVariant(mockDB, epiID, &models.EnclosureVariant{Length: 2000, Fingerprint: content})
+	if err != nil {
+		t.Fatalf("addVariant() error = %v", err)
+	}
+
+	storedRef, err := FindReferenceVariant(mockDB, epiID)
+	if err != nil || storedRef.Length != 1000 {
+		t.Fatalf("FindReferenceVariant() = %v, error = %v", storedRef, err)
+	}
+	checkAds(t, "reference", storedRef.Ads, []*protos.AdMarker{{StartMillis: frameMillis(1500), EndMillis: frameMillis(2100)}})
+	stored, err := FindVariant(mockDB, epiID, 2000)
+	if err != nil || stored.Reference || stored.Fingerprint != nil || len(stored.Segments) != 2 {
+		t.Fatalf("FindVariant() = %v, error = %v", stored, err)
+	}
+	ads, err := FindAdMarkers(mockDB, epiID, 3000)
+	if err != nil || !reflect.DeepEqual(ads, storedRef.Ads) {
+		t.Errorf("FindAdMarkers() of an unknown variant = %v, error = %v, want the reference's", ads, err)
+	}
+
+	tests := []struct {
+		name       string
+		fromLength int64
+		toLength   int64
+		offset     int64
+		want       int64
+	}{
+		{name: "same_variant", fromLength: 2000, toLength: 2000, offset: 20000, want: 20000},
+		{name: "unknown_variant", fromLength: 3000, toLength: 0, offset: 20000, want: 20000},
+		{name: "to_reference", fromLength: 2000, toLength: 0, offset: frameMillis(600 + 100), want: frameMillis(100)},
+		{name: "after_reference_ad", fromLength: 2000, toLength: 1000, offset: frameMillis(600 + 2000), want: frameMillis(2600)},
+		{name: "from_reference", fromLength: 0, toLength: 2000, offset: frameMillis(2600), want: frameMillis(600 + 2000)},
+		{name: "in_reference_ad", fromLength: 1000, toLength: 2000, offset: frameMillis(1800), want: frameMillis(600 + 1500)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MapOffset(mockDB, epiID, tt.fromLength, tt.toLength, tt.offset); abs(got-tt.want) > 100 {
+				t.Errorf("MapOffset() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_fingerprintJob(t *testing.T) {
+	server, enclosures := sampleServer(t)
+	defer server.Close()
+	mockDB := mock.CreateDB()
+	subscribed, unsubscribed := protos.NewObjectID(), protos.NewObjectID()
+	insertOrFail(t, mockDB, database.ColSubscription, &protos.Subscription{Id: protos.NewObjectID(), PodcastID: subscribed})
+
+	tests := []struct {
+		name         string
+		podID        *protos.ObjectID
+		path         string
+		wantVariants int
+	}{
+		{name: "subscribed", podID: subscribed, path: "/sample.mp3", wantVariants: 1},
+		{name: "unsubscribed", podID: unsubscribed, path: "/sample.mp3", wantVariants: 0},
+		{name: "not_audio", podID: subscribed, path: "/text.mp3", wantVariants: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			epi := &protos.Episode{Id: protos.NewObjectID(), PodcastID: tt.podID, MP3URL: server.URL + tt.path}
+			insertOrFail(t, mockDB, database.ColEpisode, epi)
+			queueFingerprint(mockDB, epi)
+
+			// fetched fingerprintFetches times, or until it gives up
+			for i := 0; i < maxFingerprintAttempts; i++ {
+				job := &models.FingerprintJob{}
+				err := mockDB.FindOne(database.ColFingerprint, job, &db.Filter{"episode_id": epi.Id}, nil)
+				if err != nil {
+					break
+				}
+				fingerprintJob(mockDB, job)
+			}
+			err := mockDB.FindOne(database.ColFingerprint, &models.FingerprintJob{}, &db.Filter{"episode_id": epi.Id}, nil)
+			if err == nil {
+				t.Errorf("fingerprintJob() left the job queued")
+			}
+			var variants []*models.EnclosureVariant
+			mockDB.FindAll(database.ColVariant, &variants, &db.Filter{"episode_id": epi.Id}, nil)
+			if len(variants) != tt.wantVariants {
+				t.Fatalf("fingerprintJob() stored %v variants, want %v", len(variants), tt.wantVariants)
+			}
+			if tt.wantVariants > 0 && (!variants[0].Reference || variants[0].Length != int64(len(enclosures[tt.path]))) {
+				t.Errorf("fingerprintJob() stored variant of %v bytes, reference %v", variants[0].Length, variants[0].Reference)
+			}
+		})
+	}
+}
+
+func Test_queueFingerprint(t *testing.T) {
+	mockDB := mock.CreateDB()
+	tests := []struct {
+		name string
+		epi  *protos.Episode
+		want bool
+	}{
+		{name: "mp3", epi: &protos.Episode{Id: protos.NewObjectID(), MP3URL: "https://example.com/1.mp3", EnclosureType: "audio/mpeg"}, want: true},
+		{name: "untyped", epi: &protos.Episode{Id: protos.NewObjectID(), MP3URL: "https://example.com/1.mp3"}, want: true},
+		{name: "aac", epi: &protos.Episode{Id: protos.NewObjectID(), MP3URL: "https://example.com/1.m4a", EnclosureType: "audio/x-m4a"}, want: false},
+		{name: "no_enclosure", epi: &protos.Episode{Id: protos.NewObjectID()}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queueFingerprint(mockDB, tt.epi)
+			err := mockDB.FindOne(database.ColFingerprint, &models.FingerprintJob{}, &db.Filter{"episode_id": tt.epi.Id}, nil)
+			if (err == nil) != tt.want {
+				t.Errorf("queueFingerprint() queued = %v, want %v", err == nil, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package podcast
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/go-mp3"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// maxDecodeSize is the largest enclosure downloaded & decoded whole
+const maxDecodeSize = 2 << 30
+
+// decodeClient downloads whole enclosures, decoding them as they stream
+var decodeClient = &http.Client{Timeout: time.Hour}
+
+// isMP3Enclosure returns whether the episode's enclosure is an mp3, or untyped
+func isMP3Enclosure(epi *protos.Episode) bool {
+	mimeType, _, _ := mime.ParseMediaType(epi.EnclosureType)
+	return epi.MP3URL != "" && (mimeType == "" || enclosureMimeTypes[strings.ToLower(mimeType)] == formatMP3)
+}
+
+// mp3Enclosure is an mp3 enclosure being downloaded & decoded
+type mp3Enclosure struct {
+	resp    *http.Response
+	body    *countingReader
+	hash    hash.Hash
+	dec     *mp3.Decoder
+	samples int64
+}
+
+// getEnclosure requests the whole enclosure at url, up to maxDecodeSize
+func getEnclosure(url string) (*http.Response, error) {
+	resp, err := decodeClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("getEnclosure() error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("getEnclosure() error: %s", resp.Status)
+	}
+	if resp.ContentLength > maxDecodeSize {
+		resp.Body.Close()
+		return nil, fmt.Errorf("getEnclosure() error: enclosure of %d bytes is too large", resp.ContentLength)
+	}
+	return resp, nil
+}
+
+// openMP3 requests the mp3 enclosure at url and starts decoding it
+func openMP3(url string) (*mp3Enclosure, error) {
+	resp, err := getEnclosure(url)
+	if err != nil {
+		return nil, fmt.Errorf("openMP3() error: %v", err)
+	}
+
+	e := &mp3Enclosure{resp: resp, hash: sha256.New()}
+	e.body = &countingReader{r: io.TeeReader(io.LimitReader(resp.Body, maxDecodeSize+1), e.hash)}
+	// hiding the body's type stops the decoder scanning the whole stream up front if it's a Seeker
+	e.dec, err = mp3.NewDecoder(struct{ io.Reader }{e.body})
+	if err != nil {
+		resp.Body.Close()
+		return nil, fmt.Errorf("openMP3() error creating decoder: %v", err)
+	}
+	return e, nil
+}
+
+// sampleRate returns the sample rate of the decoded audio
+func (e *mp3Enclosure) sampleRate() int {
+	return e.dec.SampleRate()
+}
+
+// decode decodes the rest of the audio, passing each sample downmixed to mono to write
+func (e *mp3Enclosure) decode(write func(sample float64)) error {
+	// the decoder always outputs 16 bit little endian stereo
+	buf := make([]byte, 4096)
+	for {
+		n, err := io.ReadFull(e.dec, buf)
+		for i := 0; i+4 <= n; i += 4 {
+			left := int16(binary.LittleEndian.Uint16(buf[i:]))
+			right := int16(binary.LittleEndian.Uint16(buf[i+2:]))
+			write((float64(left) + float64(right)) / 2)
+			e.samples++
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("decode() error: %v", err)
+		}
+	}
+}
+
+// durationMillis returns the duration of the audio decoded
+func (e *mp3Enclosure) durationMillis() int64 {
+	return e.samples * 1000 / int64(e.sampleRate())
+}
+
+// finish reads the rest of the enclosure after its audio, returning its length & hex encoded sha256 checksum
+func (e *mp3Enclosure) finish() (int64, string, error) {
+	_, err := io.Copy(ioutil.Discard, e.body)
+	if err != nil {
+		return 0, "", fmt.Errorf("finish() error reading enclosure: %v", err)
+	}
+	if e.body.n > maxDecodeSize {
+		return 0, "", fmt.Errorf("finish() error: enclosure is larger than %d bytes", int64(maxDecodeSize))
+	}
+	return e.body.n, hex.EncodeToString(e.hash.Sum(nil)), nil
+}
+
+// Close closes the enclosure's response
+func (e *mp3Enclosure) Close() error {
+	return e.resp.Body.Close()
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,272 @@
+package podcast
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// probeBytes is the size of the ranges requested to probe an enclosure,
+// enough for the first frames and an ID3 tag without artwork
+const probeBytes = 64 << 10
+
+// sniffBytes is enough of the audio to detect the format of its first two frames
+const sniffBytes = 4 << 10
+
+// enclosure formats probed, named by their mime type
+const (
+	formatMP3  = "audio/mpeg"
+	formatMP4  = "audio/mp4"
+	formatOgg  = "audio/ogg"
+	formatADTS = "audio/aac"
+)
+
+// enclosureMimeTypes maps the mime types feeds use for enclosures to the format probed
+var enclosureMimeTypes = map[string]string{
+	"audio/mpeg":   formatMP3,
+	"audio/mp3":    formatMP3,
+	"audio/mpeg3":  formatMP3,
+	"audio/mp4":    formatMP4,
+	"audio/m4a":    formatMP4,
+	"audio/x-m4a":  formatMP4,
+	"video/mp4":    formatMP4,
+	"audio/ogg":    formatOgg,
+	"audio/opus":   formatOgg,
+	"audio/vorbis": formatOgg,
+	"audio/aac":    formatADTS,
+	"audio/aacp":   formatADTS,
+	"audio/x-aac":  formatADTS,
+}
+
+// enclosureProbers find the duration in millis of an enclosure by format
+var enclosureProbers = map[string]func(e *enclosure) (int64, error){
+	formatMP3:  probeMP3,
+	formatMP4:  probeMP4,
+	formatOgg:  probeOgg,
+	formatADTS: probeADTS,
+}
+
+var probeClient = &http.Client{Timeout: time.Second * 30}
+
+// durationProbes tracks the episodes being probed, so concurrent requests don't probe the same enclosure
+var durationProbes = struct {
+	sync.Mutex
+	ids map[string]bool
+}{ids: map[string]bool{}}
+
+// EnclosureInfo is what probing an episode's enclosure found out about it
+type EnclosureInfo struct {
+	Type           string // mime type of the detected format
+	Length         int64  // size in bytes, 0 if unknown
+	DurationMillis int64
+}
+
+// enclosure is an enclosure being probed, its first bytes are requested up front
+// and the probers request any other ranges they need
+type enclosure struct {
+	url  string
+	head []byte
+	size int64 // -1 if unknown
+}
+
+// read returns up to length bytes of the enclosure at offset, requesting them unless they were already probed
+func (e *enclosure) read(offset, length int64) ([]byte, error) {
+	if e.size >= 0 && offset+length > e.size {
+		length = e.size - offset
+	}
+	if length <= 0 {
+		return nil, errors.New("read() error: offset is past the end of the enclosure")
+	}
+	if offset+length <= int64(len(e.head)) {
+		return e.head[offset : offset+length], nil
+	}
+	data, _, err := fetchRange(e.url, offset, length)
+	return data, err
+}
+
+// CacheDuration probes the episode's enclosure and stores its duration on the episode, along with its
+// type & length if the feed didn't provide them. meant to be run in the background whenever an
+// episode's duration is unknown
+func CacheDuration(dbClient db.Database, epi *protos.Episode) error {
+	id := epi.Id.GetHex()
+	durationProbes.Lock()
+	if durationProbes.ids[id] {
+		durationProbes.Unlock()
+		return nil
+	}
+	durationProbes.ids[id] = true
+	durationProbes.Unlock()
+	defer func() {
+		durationProbes.Lock()
+		delete(durationProbes.ids, id)
+		durationProbes.Unlock()
+	}()
+
+	info, err := ProbeEnclosure(epi.MP3URL, epi.EnclosureType)
+	if err != nil {
+		return fmt.Errorf("CacheDuration() error: %v", err)
+	}
+	// the episode is found again so changes made while probing are kept
+	stored, err := FindEpisodeByID(dbClient, epi.Id)
+	if err != nil {
+		return fmt.Errorf("CacheDuration() error: %v", err)
+	}
+	stored.DurationMillis = info.DurationMillis
+	if stored.EnclosureType == "" {
+		stored.EnclosureType = info.Type
+	}
+	if stored.EnclosureLength <= 0 {
+		stored.EnclosureLength = info.Length
+	}
+	return UpsertEpisode(dbClient, stored)
+}
+
+// keepProbed copies what was probed from the stored episode's enclosure onto the episode parsed from
+// its feed, unless the feed gives it or the enclosure was replaced
+func keepProbed(stored, epi *protos.Episode) {
+	if stored.MP3URL != epi.MP3URL {
+		return
+	}
+	if epi.DurationMillis == 0 {
+		epi.DurationMillis = stored.DurationMillis
+	}
+	if epi.EnclosureType == "" {
+		epi.EnclosureType = stored.EnclosureType
+	}
+	if epi.EnclosureLength <= 0 {
+		epi.EnclosureLength = stored.EnclosureLength
+	}
+	epi.EmbeddedChapters = stored.EmbeddedChapters
+}
+
+// ProbeEnclosure finds the format, size & duration of the enclosure at url by only requesting the
+// parts of it needed. The format is detected by its magic bytes, falling back to the mime type
+// given by the feed
+func ProbeEnclosure(url, mimeType string) (*EnclosureInfo, error) {
+	head, size, err := fetchRange(url, 0, probeBytes)
+	if err != nil {
+		return nil, fmt.Errorf("ProbeEnclosure() error: %v", err)
+	}
+	e := &enclosure{url: url, head: head, size: size}
+	format := enclosureFormat(mimeType, e)
+	probe, ok := enclosureProbers[format]
+	if !ok {
+		return nil, fmt.Errorf("ProbeEnclosure() error: unsupported enclosure type %q", mimeType)
+	}
+	dur, err := probe(e)
+	if err != nil {
+		return nil, fmt.Errorf("ProbeEnclosure() error: %v", err)
+	}
+	info := &EnclosureInfo{Type: format, DurationMillis: dur}
+	if size > 0 {
+		info.Length = size
+	}
+	return info, nil
+}
+
+// enclosureFormat detects the format of the enclosure from the magic bytes at its start,
+// falling back to its mime type
+func enclosureFormat(mimeType string, e *enclosure) string {
+	// the audio follows any ID3 tag, an error leaves it empty
+	audio, _ := e.read(id3Size(e.head), sniffBytes)
+	switch {
+	case len(e.head) >= 8 && string(e.head[4:8]) == "ftyp":
+		return formatMP4
+	case bytes.HasPrefix(e.head, []byte("OggS")):
+		return formatOgg
+	case findADTSFrame(audio) == 0:
+		return formatADTS
+	case findFrame(audio) == 0:
+		return formatMP3
+	}
+	mimeType = strings.ToLower(strings.TrimSpace(strings.SplitN(mimeType, ";", 2)[0]))
+	return enclosureMimeTypes[mimeType]
+}
+
+// scannedDuration returns the duration in millis of audio from the frames scanned at its start,
+// all of the audio was scanned if complete, otherwise their bitrate is applied to audioLen
+func scannedDuration(seconds float64, scanned int64, audioLen int64, complete bool) (int64, error) {
+	if complete {
+		return int64(seconds * 1000), nil
+	}
+	if scanned == 0 || audioLen < 0 {
+		return 0, errors.New("scannedDuration() error: no complete frames to estimate duration")
+	}
+	return int64(seconds * 1000 * float64(audioLen) / float64(scanned)), nil
+}
+
+// fetchRange requests length bytes of the file at url starting at offset,
+// returns the bytes and the size of the whole file, -1 if unknown
+func fetchRange(url string, offset, length int64) ([]byte, int64, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("fetchRange() error creating request: %v", err)
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+	resp, err := probeClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("fetchRange() error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	total := int64(-1)
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		total = contentRangeTotal(resp.Header.Get("Content-Range"))
+	case http.StatusOK:
+		// the range was ignored, skip to the offset and read no more than requested
+		total = resp.ContentLength
+		_, err = io.CopyN(ioutil.Discard, resp.Body, offset)
+		if err != nil {
+			return nil, 0, fmt.Errorf("fetchRange() error skipping to offset: %v", err)
+		}
+	default:
+		return nil, 0, fmt.Errorf("fetchRange() server responded: %s", resp.Status)
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, length))
+	if err != nil {
+		return nil, 0, fmt.Errorf("fetchRange() error reading body: %v", err)
+	}
+	if total < 0 && int64(len(data)) < length {
+		// the file ended before the requested length
+		total = offset + int64(len(data))
+	}
+	return data, total, nil
+}
+
+// contentRangeTotal returns the size of the whole file from a Content-Range header, -1 if unknown
+func contentRangeTotal(contentRange string) int64 {
+	i := strings.LastIndex(contentRange, "/")
+	if i < 0 {
+		return -1
+	}
+	total, err := strconv.ParseInt(strings.TrimSpace(contentRange[i+1:]), 10, 64)
+	if err != nil {
+		return -1
+	}
+	return total
+}
+
+// id3Size returns the size of the ID3v2 tag at the start of data, 0 if there is none
+func id3Size(data []byte) int64 {
+	if len(data) < 10 || string(data[:3]) != "ID3" {
+		return 0
+	}
+	size := syncsafe(data[6:]) + 10
+	if data[5]&0x10 != 0 {
+		// footer
+		size += 10
+	}
+	return size
+}
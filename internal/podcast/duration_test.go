@@ -0,0 +1,165 @@
+package podcast
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sschwartz96/stockpile/mock"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// countingWriter counts the bytes of the body written to the response
+type countingWriter struct {
+	http.ResponseWriter
+	written *int
+}
+
+func (c countingWriter) Write(b []byte) (int, error) {
+	*c.written += len(b)
+	return c.ResponseWriter.Write(b)
+}
+
+func Test_enclosureFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		mimeType string
+		head     []byte
+		want     string
+	}{
+		{name: "mp3", head: mp3Frames(mpeg1Header, 3), want: formatMP3},
+		{name: "mp3_id3", mimeType: "audio/x-m4a", head: append(id3Tag(100), mp3Frames(mpeg1Header, 3)...), want: formatMP3},
+		{name: "m4a", mimeType: "audio/mpeg", head: m4aFile(mvhdBox(0, 1000, 1000), 100, false), want: formatMP4},
+		{name: "ogg", head: opusFile(312, 48312, 1), want: formatOgg},
+		{name: "aac", mimeType: "audio/mpeg", head: adtsFrames(3, 371), want: formatADTS},
+		{name: "mime_type", mimeType: " Audio/X-M4A; charset=binary", head: []byte("unknown"), want: formatMP4},
+		{name: "tag_past_head", mimeType: "audio/opus", head: id3Tag(100)[:50], want: formatOgg},
+		{name: "unknown", mimeType: "video/quicktime", head: []byte("unknown"), want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &enclosure{head: tt.head, size: int64(len(tt.head))}
+			if got := enclosureFormat(tt.mimeType, e); got != tt.want {
+				t.Errorf("enclosureFormat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProbeEnclosure(t *testing.T) {
+	sample, err := ioutil.ReadFile("./test/sample.mp3")
+	if err != nil {
+		t.Fatalf("TestProbeEnclosure() unable to read file: %v", err)
+	}
+	files := map[string][]byte{
+		// an hour long episode with artwork in its ID3 tag
+		"/artwork.mp3":    append(id3Tag(200<<10), mp3Frames(mpeg1Header, 137812)...),
+		"/sample.mp3":     sample,
+		"/moov_start.m4a": m4aFile(mvhdBox(0, 44100, 158760000), 1<<20, false),
+		"/moov_end.m4a":   m4aFile(mvhdBox(1, 1000, 5400000), 1<<20, true),
+		"/opus.ogg":       opusFile(312, 2880312, 100),
+		"/vorbis.ogg":     vorbisFile(44100, 44100*90),
+		"/short.aac":      adtsFrames(100, 371),
+		"/long.aac":       append(id3Tag(100), adtsFrames(20000, 371)...),
+		"/unknown.bin":    bytes.Repeat([]byte("unknown"), 100),
+	}
+
+	written := 0
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/no_range.mp3" {
+			res.Write(sample)
+			return
+		}
+		file, ok := files[req.URL.Path]
+		if !ok {
+			http.NotFound(res, req)
+			return
+		}
+		http.ServeContent(countingWriter{res, &written}, req, req.URL.Path, time.Time{}, bytes.NewReader(file))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		path     string
+		mimeType string
+		want     *EnclosureInfo
+		wantErr  bool
+	}{
+		{name: "sample", path: "/sample.mp3", want: &EnclosureInfo{Type: formatMP3, DurationMillis: 10031}},
+		{name: "no_range", path: "/no_range.mp3", want: &EnclosureInfo{Type: formatMP3, DurationMillis: 10031}},
+		{name: "artwork", path: "/artwork.mp3", want: &EnclosureInfo{Type: formatMP3, DurationMillis: 3599986}},
+		{name: "moov_start", path: "/moov_start.m4a", mimeType: "audio/x-m4a", want: &EnclosureInfo{Type: formatMP4, DurationMillis: 3600000}},
+		{name: "moov_end", path: "/moov_end.m4a", want: &EnclosureInfo{Type: formatMP4, DurationMillis: 5400000}},
+		{name: "opus", path: "/opus.ogg", mimeType: "audio/opus", want: &EnclosureInfo{Type: formatOgg, DurationMillis: 60000}},
+		{name: "vorbis", path: "/vorbis.ogg", want: &EnclosureInfo{Type: formatOgg, DurationMillis: 90000}},
+		{name: "short_aac", path: "/short.aac", mimeType: "audio/aac", want: &EnclosureInfo{Type: formatADTS, DurationMillis: 2321}},
+		{name: "long_aac", path: "/long.aac", want: &EnclosureInfo{Type: formatADTS, DurationMillis: 464399}},
+		{name: "unsupported", path: "/unknown.bin", mimeType: "video/quicktime", wantErr: true},
+		{name: "not_found", path: "/missing.mp3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			written = 0
+			got, err := ProbeEnclosure(server.URL+tt.path, tt.mimeType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ProbeEnclosure() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if written > 3*probeBytes {
+				t.Errorf("ProbeEnclosure() downloaded %v bytes, want at most %v", written, 3*probeBytes)
+			}
+			if tt.wantErr {
+				return
+			}
+			// the length is the size of the served file
+			tt.want.Length = int64(len(sample))
+			if file, ok := files[tt.path]; ok {
+				tt.want.Length = int64(len(file))
+			}
+			if *got != *tt.want {
+				t.Errorf("ProbeEnclosure() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCacheDuration(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		http.ServeFile(res, req, "./test/sample.mp3")
+	}))
+	defer server.Close()
+	mockDB := mock.CreateDB()
+	epi := &protos.Episode{Id: protos.NewObjectID(), Title: "Sample", MP3URL: server.URL + "/sample.mp3"}
+	insertOrFail(t, mockDB, database.ColEpisode, epi)
+	typed := &protos.Episode{Id: protos.NewObjectID(), MP3URL: server.URL + "/sample.mp3", EnclosureType: "audio/mp3", EnclosureLength: 60000}
+	insertOrFail(t, mockDB, database.ColEpisode, typed)
+
+	tests := []struct {
+		name string
+		epi  *protos.Episode
+		want *EnclosureInfo
+	}{
+		{name: "unknown_enclosure", epi: epi, want: &EnclosureInfo{Type: formatMP3, Length: 60186, DurationMillis: 10031}},
+		{name: "feed_enclosure", epi: typed, want: &EnclosureInfo{Type: "audio/mp3", Length: 60000, DurationMillis: 10031}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CacheDuration(mockDB, tt.epi)
+			if err != nil {
+				t.Fatalf("CacheDuration() error = %v", err)
+			}
+			stored, err := FindEpisodeByID(mockDB, tt.epi.Id)
+			if err != nil {
+				t.Fatalf("CacheDuration() error finding episode: %v", err)
+			}
+			got := &EnclosureInfo{Type: stored.EnclosureType, Length: stored.EnclosureLength, DurationMillis: stored.DurationMillis}
+			if *got != *tt.want || stored.Title != tt.epi.Title {
+				t.Errorf("CacheDuration() stored episode = %v, want %v", stored, tt.want)
+			}
+		})
+	}
+}
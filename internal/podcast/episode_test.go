@@ -373,3 +373,58 @@ func TestDoesEpisodeExist(t *testing.T) {
 		})
 	}
 }
+
+func TestFindEpisodeByIdentity(t *testing.T) {
+	mockDB := mock.CreateDB()
+	podID := protos.NewObjectID()
+	pubDate := ptypes.TimestampNow()
+	withGUID := &protos.Episode{Id: protos.ObjectIDFromHex("guid"), PodcastID: podID, Guid: "guid-1", Title: "Guid Title", MP3URL: "https://example.com/1.mp3", PubDate: pubDate}
+	noGUID := &protos.Episode{Id: protos.ObjectIDFromHex("no_guid"), PodcastID: podID, Title: "Old Title", MP3URL: "https://example.com/2.mp3", PubDate: pubDate}
+	insertOrFail(t, mockDB, database.ColEpisode, withGUID)
+	insertOrFail(t, mockDB, database.ColEpisode, noGUID)
+
+	tests := []struct {
+		name    string
+		epi     *protos.Episode
+		want    *protos.ObjectID
+		wantErr bool
+	}{
+		{
+			name: "guid_renamed",
+			epi:  &protos.Episode{PodcastID: podID, Guid: "guid-1", Title: "New Title", PubDate: ptypes.TimestampNow()},
+			want: withGUID.Id,
+		},
+		{
+			name: "enclosure_url",
+			epi:  &protos.Episode{PodcastID: podID, Guid: "guid-2", Title: "New Title", MP3URL: "https://example.com/2.mp3"},
+			want: noGUID.Id,
+		},
+		{
+			name: "title_pubdate",
+			epi:  &protos.Episode{PodcastID: podID, Title: "Old Title", PubDate: pubDate},
+			want: noGUID.Id,
+		},
+		{
+			name: "different_guid_same_url",
+			epi:  &protos.Episode{PodcastID: podID, Guid: "guid-3", MP3URL: "https://example.com/1.mp3"},
+			want: nil,
+		},
+		{
+			name: "other_podcast",
+			epi:  &protos.Episode{PodcastID: protos.NewObjectID(), Guid: "guid-1"},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FindEpisodeByIdentity(mockDB, tt.epi)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FindEpisodeByIdentity() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.GetId().GetHex() != tt.want.GetHex() {
+				t.Errorf("FindEpisodeByIdentity() = %v, want %v", got.GetId(), tt.want)
+			}
+		})
+	}
+}
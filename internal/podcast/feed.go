@@ -0,0 +1,264 @@
+package podcast
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sschwartz96/syncapod/internal/models"
+)
+
+// feed formats
+const (
+	formatRSS      = "rss"
+	formatAtom     = "atom"
+	formatJSONFeed = "jsonfeed"
+)
+
+// errUnknownFormat is returned when a feed is neither RSS 2.0, Atom 1.0 or JSON Feed
+var errUnknownFormat = errors.New("unknown feed format")
+
+// feedParsers parse each feed format into the rss podcast model used by convertPodcast & convertEpisode
+var feedParsers = map[string]func(r io.Reader) (*models.RSSPodcast, error){
+	formatRSS:      parseRSS,
+	formatAtom:     parseAtom,
+	formatJSONFeed: parseJSONFeed,
+}
+
+// parseFeed sniffs the format of the feed body and parses it
+func parseFeed(body []byte) (*models.RSSPodcast, error) {
+	format, err := sniffFormat(body)
+	if err != nil {
+		return nil, err
+	}
+	return feedParsers[format](bytes.NewReader(body))
+}
+
+// sniffFormat determines the feed format via its first character or root xml element
+func sniffFormat(body []byte) (string, error) {
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(body, []byte("\xef\xbb\xbf")))
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		return formatJSONFeed, nil
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(trimmed))
+	decoder.Strict = false
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", fmt.Errorf("sniffFormat() %v: %v", errUnknownFormat, err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch strings.ToLower(start.Name.Local) {
+		case "rss":
+			return formatRSS, nil
+		case "feed":
+			return formatAtom, nil
+		}
+		return "", fmt.Errorf("sniffFormat() %v: root element <%s>", errUnknownFormat, start.Name.Local)
+	}
+}
+
+// parseAtom decodes an Atom 1.0 feed and converts it to the rss podcast model
+func parseAtom(r io.Reader) (*models.RSSPodcast, error) {
+	var feed models.AtomFeed
+	err := xml.NewDecoder(r).Decode(&feed)
+	if err != nil {
+		return nil, err
+	}
+	return convertAtomFeed(&feed), nil
+}
+
+// parseJSONFeed decodes a JSON Feed and converts it to the rss podcast model
+func parseJSONFeed(r io.Reader) (*models.RSSPodcast, error) {
+	var feed models.JSONFeed
+	err := json.NewDecoder(r).Decode(&feed)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(feed.Version, "https://jsonfeed.org/version/") {
+		return nil, fmt.Errorf("parseJSONFeed() %v: version %q", errUnknownFormat, feed.Version)
+	}
+	return convertJSONFeed(&feed), nil
+}
+
+func convertAtomFeed(feed *models.AtomFeed) *models.RSSPodcast {
+	image := feed.Logo
+	if image == "" {
+		image = feed.Icon
+	}
+	updated := rfc3339ToRFC2822(feed.Updated)
+	p := &models.RSSPodcast{
+		Title:         strings.TrimSpace(feed.Title),
+		Author:        atomAuthors(feed.Authors),
+		Subtitle:      strings.TrimSpace(feed.Subtitle),
+		Summary:       strings.TrimSpace(feed.Subtitle),
+		Link:          atomLink(feed.Links, "alternate"),
+		Image:         models.Image{Title: feed.Title, URL: image},
+		Category:      atomCategories(feed.Categories),
+		PubDate:       updated,
+		LastBuildDate: updated,
+		AtomLinks:     feed.Links,
+	}
+	for _, entry := range feed.Entries {
+		pubDate := entry.Published
+		if pubDate == "" {
+			pubDate = entry.Updated
+		}
+		description := entry.Content
+		if description == "" {
+			description = entry.Summary
+		}
+		p.RSSEpisodes = append(p.RSSEpisodes, models.RSSEpisode{
+			GUID:        strings.TrimSpace(entry.ID),
+			Title:       strings.TrimSpace(entry.Title),
+			Author:      atomAuthors(entry.Authors),
+			PubDate:     rfc3339ToRFC2822(pubDate),
+			Description: strings.TrimSpace(description),
+			Summary:     strings.TrimSpace(entry.Summary),
+			Category:    atomCategories(entry.Categories),
+			Enclosure:   atomEnclosure(entry.Links),
+			Duration:    strings.TrimSpace(entry.Duration),
+		})
+	}
+	return p
+}
+
+// atomLink returns the href of the first link with the relation, a link without rel is "alternate"
+func atomLink(links []models.AtomLink, rel string) string {
+	for _, link := range links {
+		linkRel := link.Rel
+		if linkRel == "" {
+			linkRel = "alternate"
+		}
+		if linkRel == rel {
+			return link.Href
+		}
+	}
+	return ""
+}
+
+// atomEnclosure returns the enclosure link of the entry
+func atomEnclosure(links []models.AtomLink) models.Enclosure {
+	for _, link := range links {
+		if link.Rel == "enclosure" {
+			return models.Enclosure{MP3: link.Href, Type: link.Type, Length: link.Length}
+		}
+	}
+	return models.Enclosure{}
+}
+
+func atomAuthors(authors []models.AtomPerson) string {
+	names := make([]string, 0, len(authors))
+	for _, a := range authors {
+		if name := strings.TrimSpace(a.Name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+func atomCategories(cats []models.AtomCategory) []models.Category {
+	var categories []models.Category
+	for _, c := range cats {
+		text := c.Label
+		if text == "" {
+			text = c.Term
+		}
+		categories = append(categories, models.Category{Text: text})
+	}
+	return categories
+}
+
+func convertJSONFeed(feed *models.JSONFeed) *models.RSSPodcast {
+	image := feed.Icon
+	if image == "" {
+		image = feed.Favicon
+	}
+	p := &models.RSSPodcast{
+		Title:    strings.TrimSpace(feed.Title),
+		Author:   jsonFeedAuthors(feed.Authors, feed.Author),
+		Summary:  strings.TrimSpace(feed.Description),
+		Link:     feed.HomePageURL,
+		Image:    models.Image{Title: feed.Title, URL: image},
+		Language: feed.Language,
+	}
+	for _, item := range feed.Items {
+		pubDate := item.DatePublished
+		if pubDate == "" {
+			pubDate = item.DateModified
+		}
+		description := item.ContentHTML
+		if description == "" {
+			description = item.ContentText
+		}
+		epi := models.RSSEpisode{
+			GUID:        strings.TrimSpace(item.ID),
+			Title:       strings.TrimSpace(item.Title),
+			Author:      jsonFeedAuthors(item.Authors, item.Author),
+			Image:       models.EpiImage{HREF: item.Image},
+			PubDate:     rfc3339ToRFC2822(pubDate),
+			Description: strings.TrimSpace(description),
+			Summary:     strings.TrimSpace(item.Summary),
+		}
+		for _, tag := range item.Tags {
+			epi.Category = append(epi.Category, models.Category{Text: tag})
+		}
+		if attachment := jsonFeedMedia(item.Attachments); attachment != nil {
+			epi.Enclosure = models.Enclosure{MP3: attachment.URL, Type: attachment.MimeType}
+			if attachment.SizeInBytes > 0 {
+				epi.Enclosure.Length = strconv.FormatInt(attachment.SizeInBytes, 10)
+			}
+			if attachment.DurationInSeconds > 0 {
+				epi.Duration = strconv.Itoa(int(attachment.DurationInSeconds))
+			}
+		}
+		p.RSSEpisodes = append(p.RSSEpisodes, epi)
+	}
+	return p
+}
+
+// jsonFeedMedia returns the first audio attachment, or the first attachment if none are audio
+func jsonFeedMedia(attachments []models.JSONFeedAttachment) *models.JSONFeedAttachment {
+	if len(attachments) == 0 {
+		return nil
+	}
+	for i := range attachments {
+		if strings.HasPrefix(attachments[i].MimeType, "audio/") {
+			return &attachments[i]
+		}
+	}
+	return &attachments[0]
+}
+
+func jsonFeedAuthors(authors []models.JSONFeedAuthor, author *models.JSONFeedAuthor) string {
+	if author != nil {
+		authors = append(authors, *author)
+	}
+	names := make([]string, 0, len(authors))
+	for _, a := range authors {
+		if name := strings.TrimSpace(a.Name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// rfc3339ToRFC2822 converts the RFC3339 dates of Atom & JSON Feed into the RFC2822 dates of RSS,
+// returns the date unchanged if it can't be parsed
+func rfc3339ToRFC2822(s string) string {
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(s))
+	if err != nil {
+		return s
+	}
+	return t.Format("Mon, 02 Jan 2006 15:04:05 -0700")
+}
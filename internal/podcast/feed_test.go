@@ -0,0 +1,116 @@
+package podcast
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+func Test_parseFeed(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string
+		body        string
+		wantErr     error
+		wantTitle   string
+		wantGUID    string
+		wantMP3     string
+		wantPubDate string
+		wantLength  int64
+		wantType    string
+		wantSize    int64
+	}{
+		{
+			name:        "rss",
+			file:        "./test/feed.xml",
+			wantTitle:   "Go Time",
+			wantGUID:    "changelog.com/2/1057",
+			wantMP3:     "https://cdn.changelog.com/uploads/gotime/149/go-time-149.mp3",
+			wantPubDate: "2020-10-01T15:00:00Z",
+			wantLength:  4578000,
+			wantType:    "audio/mpeg",
+			wantSize:    73524584,
+		},
+		{
+			name:        "atom",
+			file:        "./test/atom_feed.xml",
+			wantTitle:   "Self Hosted Show",
+			wantGUID:    "tag:example.com,2020:episode-2",
+			wantMP3:     "https://example.com/episode-2.mp3",
+			wantPubDate: "2020-10-08T15:30:00Z",
+			wantLength:  2730000,
+			wantType:    "audio/mpeg",
+			wantSize:    1337,
+		},
+		{
+			name:        "jsonfeed",
+			file:        "./test/json_feed.json",
+			wantTitle:   "JSON Show",
+			wantGUID:    "https://example.org/episode-1",
+			wantMP3:     "https://example.org/episode-1.mp3",
+			wantPubDate: "2020-10-08T15:30:00Z",
+			wantLength:  2730000,
+			wantType:    "audio/mpeg",
+			wantSize:    1337,
+		},
+		{
+			name:    "unknown_xml",
+			body:    `<?xml version="1.0"?><html><body></body></html>`,
+			wantErr: errUnknownFormat,
+		},
+		{
+			name:    "unknown_json_version",
+			body:    `{"version": "1.0", "title": "Not a feed"}`,
+			wantErr: errUnknownFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := []byte(tt.body)
+			if tt.file != "" {
+				var err error
+				body, err = ioutil.ReadFile(tt.file)
+				if err != nil {
+					t.Fatalf("parseFeed() error reading test file: %v", err)
+				}
+			}
+			got, err := parseFeed(body)
+			if tt.wantErr != nil {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr.Error()) {
+					t.Fatalf("parseFeed() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFeed() error = %v", err)
+			}
+			if got.Title != tt.wantTitle {
+				t.Errorf("parseFeed() title = %v, want %v", got.Title, tt.wantTitle)
+			}
+			if len(got.RSSEpisodes) == 0 {
+				t.Fatalf("parseFeed() no episodes")
+			}
+
+			epi := convertEpisode(protos.NewObjectID(), &got.RSSEpisodes[0])
+			if epi.Guid != tt.wantGUID {
+				t.Errorf("parseFeed() guid = %v, want %v", epi.Guid, tt.wantGUID)
+			}
+			if epi.MP3URL != tt.wantMP3 {
+				t.Errorf("parseFeed() mp3 = %v, want %v", epi.MP3URL, tt.wantMP3)
+			}
+			pubDate, err := ptypes.Timestamp(epi.PubDate)
+			if err != nil || pubDate.UTC().Format("2006-01-02T15:04:05Z") != tt.wantPubDate {
+				t.Errorf("parseFeed() pubdate = %v, want %v, error = %v", pubDate, tt.wantPubDate, err)
+			}
+			if epi.DurationMillis != tt.wantLength {
+				t.Errorf("parseFeed() duration = %v, want %v", epi.DurationMillis, tt.wantLength)
+			}
+			if epi.EnclosureType != tt.wantType || epi.EnclosureLength != tt.wantSize {
+				t.Errorf("parseFeed() enclosure = %v %v, want %v %v", epi.EnclosureType, epi.EnclosureLength, tt.wantType, tt.wantSize)
+			}
+		})
+	}
+}
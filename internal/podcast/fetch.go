@@ -0,0 +1,111 @@
+package podcast
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// errNotModified is returned when a feed has not changed since it was last fetched
+var errNotModified = errors.New("feed not modified")
+
+// FindFetchState finds the fetch state of the podcast's feed via podcast id
+func FindFetchState(dbClient db.Database, podID *protos.ObjectID) (*models.FetchState, error) {
+	state := &models.FetchState{}
+	err := dbClient.FindOne(database.ColFetchState, state, &db.Filter{"podcast_id": podID}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("FindFetchState() error: %v", err)
+	}
+	return state, nil
+}
+
+// UpsertFetchState upserts the fetch state of the podcast's feed
+func UpsertFetchState(dbClient db.Database, state *models.FetchState) error {
+	err := dbClient.Upsert(database.ColFetchState, state, &db.Filter{"podcast_id": state.PodcastID})
+	if err != nil {
+		return fmt.Errorf("error upserting fetch state: %v", err)
+	}
+	return nil
+}
+
+// downloadRSS performs a conditional GET of the feed at url using the caching info within state.
+// returns the body and a copy of state describing the response, or errNotModified if the
+// server responded 304 or the body is identical to the last one fetched.
+// fetched.MovedTo is set if the feed was permanently redirected
+func downloadRSS(url string, state *models.FetchState) ([]byte, *models.FetchState, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("downloadRSS() error creating request: %v", err)
+	}
+	if state.ETag != "" {
+		req.Header.Set("If-None-Match", state.ETag)
+	}
+	if state.LastModified != "" {
+		req.Header.Set("If-Modified-Since", state.LastModified)
+	}
+
+	// follow redirects, remembering where the feed moved to if every hop was permanent
+	permanent, movedTo := true, ""
+	client := &http.Client{CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		code := req.Response.StatusCode
+		permanent = permanent && (code == http.StatusMovedPermanently || code == http.StatusPermanentRedirect)
+		movedTo = ""
+		if permanent {
+			movedTo = req.URL.String()
+		}
+		return nil
+	}}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			log.Println("downloadRSS() error closing body:", err)
+		}
+	}()
+
+	fetched := *state
+	fetched.LastStatus = resp.StatusCode
+	fetched.MovedTo = movedTo
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, &fetched, errNotModified
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &fetched, fmt.Errorf("downloadRSS() unexpected status: %s", resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &fetched, fmt.Errorf("downloadRSS() error reading body: %v", err)
+	}
+	fetched.ETag = resp.Header.Get("ETag")
+	fetched.LastModified = resp.Header.Get("Last-Modified")
+
+	hash := hashFeed(body)
+	if hash == state.ContentHash {
+		return nil, &fetched, errNotModified
+	}
+	fetched.ContentHash = hash
+	return body, &fetched, nil
+}
+
+// hashFeed returns the hex encoded sha256 sum of the feed body
+func hashFeed(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
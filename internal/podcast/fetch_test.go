@@ -0,0 +1,186 @@
+package podcast
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sschwartz96/stockpile/mock"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// createFeedServer serves the test feed with caching headers, responding 304 on matching etag,
+// and redirects to it
+func createFeedServer(t *testing.T) *httptest.Server {
+	feed, err := ioutil.ReadFile("./test/feed.xml")
+	if err != nil {
+		t.Fatalf("createFeedServer() error reading test feed: %v", err)
+	}
+	return httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/feed":
+			if req.Header.Get("If-None-Match") == `"v1"` {
+				res.WriteHeader(http.StatusNotModified)
+				return
+			}
+			res.Header().Set("ETag", `"v1"`)
+			res.Header().Set("Last-Modified", "Thu, 01 Oct 2020 15:00:00 GMT")
+			res.Write(feed)
+		case "/no_cache":
+			res.Write(feed)
+		case "/moved":
+			http.Redirect(res, req, "/feed", http.StatusMovedPermanently)
+		case "/moved_twice":
+			http.Redirect(res, req, "/moved", http.StatusPermanentRedirect)
+		case "/temporary":
+			http.Redirect(res, req, "/moved", http.StatusFound)
+		default:
+			res.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func Test_downloadRSS(t *testing.T) {
+	server := createFeedServer(t)
+	defer server.Close()
+
+	feed, _ := ioutil.ReadFile("./test/feed.xml")
+	feedHash := hashFeed(feed)
+
+	type args struct {
+		url   string
+		state *models.FetchState
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantBody        bool
+		wantStatus      int
+		wantETag        string
+		wantNotModified bool
+		wantMovedTo     string
+		wantErr         bool
+	}{
+		{
+			name:       "first_fetch",
+			args:       args{url: server.URL + "/feed", state: &models.FetchState{}},
+			wantBody:   true,
+			wantStatus: http.StatusOK,
+			wantETag:   `"v1"`,
+		},
+		{
+			name:            "etag_not_modified",
+			args:            args{url: server.URL + "/feed", state: &models.FetchState{ETag: `"v1"`}},
+			wantStatus:      http.StatusNotModified,
+			wantETag:        `"v1"`,
+			wantNotModified: true,
+		},
+		{
+			name:            "hash_not_modified",
+			args:            args{url: server.URL + "/no_cache", state: &models.FetchState{ContentHash: feedHash}},
+			wantStatus:      http.StatusOK,
+			wantNotModified: true,
+		},
+		{
+			name:        "permanent_redirect",
+			args:        args{url: server.URL + "/moved_twice", state: &models.FetchState{}},
+			wantBody:    true,
+			wantStatus:  http.StatusOK,
+			wantETag:    `"v1"`,
+			wantMovedTo: server.URL + "/feed",
+		},
+		{
+			name:       "temporary_redirect",
+			args:       args{url: server.URL + "/temporary", state: &models.FetchState{}},
+			wantBody:   true,
+			wantStatus: http.StatusOK,
+			wantETag:   `"v1"`,
+		},
+		{
+			name:       "not_found",
+			args:       args{url: server.URL + "/missing", state: &models.FetchState{}},
+			wantStatus: http.StatusNotFound,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, fetched, err := downloadRSS(tt.args.url, tt.args.state)
+			if (err == errNotModified) != tt.wantNotModified {
+				t.Errorf("downloadRSS() error = %v, wantNotModified %v", err, tt.wantNotModified)
+			}
+			if (err != nil && err != errNotModified) != tt.wantErr {
+				t.Errorf("downloadRSS() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (len(body) > 0) != tt.wantBody {
+				t.Errorf("downloadRSS() body length = %v, wantBody %v", len(body), tt.wantBody)
+			}
+			if fetched.LastStatus != tt.wantStatus {
+				t.Errorf("downloadRSS() status = %v, want %v", fetched.LastStatus, tt.wantStatus)
+			}
+			if fetched.ETag != tt.wantETag {
+				t.Errorf("downloadRSS() etag = %v, want %v", fetched.ETag, tt.wantETag)
+			}
+			if fetched.MovedTo != tt.wantMovedTo {
+				t.Errorf("downloadRSS() moved to = %v, want %v", fetched.MovedTo, tt.wantMovedTo)
+			}
+			if tt.wantBody && fetched.ContentHash != feedHash {
+				t.Errorf("downloadRSS() hash = %v, want %v", fetched.ContentHash, feedHash)
+			}
+		})
+	}
+}
+
+func Test_updatePodcast_conditional(t *testing.T) {
+	server := createFeedServer(t)
+	defer server.Close()
+
+	mockDB := mock.CreateDB()
+	pod := &protos.Podcast{Id: protos.NewObjectID(), Title: "Go Time", Rss: server.URL + "/feed"}
+	insertOrFail(t, mockDB, database.ColPodcast, pod)
+	insertOrFail(t, mockDB, database.ColEpisode, &protos.Episode{Id: protos.NewObjectID(), PodcastID: protos.NewObjectID()})
+
+	// first update downloads the feed and stores the caching info
+	if err := updatePodcast(mockDB, pod); err != nil {
+		t.Fatalf("updatePodcast() first update error = %v", err)
+	}
+	state, err := FindFetchState(mockDB, pod.Id)
+	if err != nil {
+		t.Fatalf("updatePodcast() error finding fetch state: %v", err)
+	}
+	if state.ETag != `"v1"` || state.LastStatus != http.StatusOK || state.LastSuccess.IsZero() {
+		t.Errorf("updatePodcast() fetch state after first update = %+v", state)
+	}
+	firstSuccess := state.LastSuccess
+
+	// second update should be answered with 304
+	if err := updatePodcast(mockDB, pod); err != nil {
+		t.Fatalf("updatePodcast() second update error = %v", err)
+	}
+	state, err = FindFetchState(mockDB, pod.Id)
+	if err != nil {
+		t.Fatalf("updatePodcast() error finding fetch state: %v", err)
+	}
+	if state.LastStatus != http.StatusNotModified || state.ETag != `"v1"` || !state.LastSuccess.After(firstSuccess) {
+		t.Errorf("updatePodcast() fetch state after second update = %+v", state)
+	}
+
+	// an unchanged body under an etag the server no longer matches keeps the new etag
+	state.ETag = `"v0"`
+	if err = UpsertFetchState(mockDB, state); err != nil {
+		t.Fatalf("UpsertFetchState() error = %v", err)
+	}
+	if err := updatePodcast(mockDB, pod); err != nil {
+		t.Fatalf("updatePodcast() third update error = %v", err)
+	}
+	state, err = FindFetchState(mockDB, pod.Id)
+	if err != nil {
+		t.Fatalf("updatePodcast() error finding fetch state: %v", err)
+	}
+	if state.LastStatus != http.StatusOK || state.ETag != `"v1"` || state.LastModified == "" {
+		t.Errorf("updatePodcast() fetch state after unchanged update = %+v", state)
+	}
+}
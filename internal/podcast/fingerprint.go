@@ -0,0 +1,187 @@
+package podcast
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/sschwartz96/syncapod/internal/models"
+)
+
+const (
+	// fingerprintRate is the sample rate audio is downmixed to before fingerprinting
+	fingerprintRate = 5512
+	// fingerprintWindow is the samples of each frame, ~370ms
+	fingerprintWindow = 2048
+	// fingerprintHop is the samples between frames, ~23ms
+	fingerprintHop = 128
+	// the sub-fingerprint's bits compare the energy of bands log spaced between these frequencies
+	fingerprintMinFreq = 300
+	fingerprintMaxFreq = 2000
+)
+
+// fingerprintEnclosure downloads and fingerprints the mp3 enclosure at url, returning the variant it was served
+func fingerprintEnclosure(url string) (*models.EnclosureVariant, error) {
+	e, err := openMP3(url)
+	if err != nil {
+		return nil, fmt.Errorf("fingerprintEnclosure() error: %v", err)
+	}
+	defer e.Close()
+	f := newFingerprinter(e.sampleRate())
+	err = e.decode(f.write)
+	if err != nil {
+		return nil, fmt.Errorf("fingerprintEnclosure() error: %v", err)
+	}
+	// the checksum & length cover any trailing tag the decoder stopped before
+	length, sum, err := e.finish()
+	if err != nil {
+		return nil, fmt.Errorf("fingerprintEnclosure() error: %v", err)
+	}
+	return &models.EnclosureVariant{
+		Length:         length,
+		SHA256:         sum,
+		DurationMillis: e.durationMillis(),
+		Fingerprint:    f.fingerprint,
+		FetchedAt:      time.Now(),
+	}, nil
+}
+
+// fingerprinter computes the sub-fingerprints of mono audio, one for every fingerprintHop samples
+// after it's resampled to fingerprintRate. Each of a sub-fingerprint's 32 bits is whether the energy
+// difference between adjacent frequency bands increased since the previous frame
+type fingerprinter struct {
+	sampleRate int
+	samples    int64 // at sampleRate
+
+	// samples being averaged into the next resampled one
+	sum   float64
+	count int
+
+	frame      []float64 // resampled samples of the next frame
+	window     []float64
+	bands      []int // fft bin each of the 33 bands starts at, the last ends the last band
+	fft        *fft
+	prevEnergy []float64
+	// fingerprint has a sub-fingerprint for every frame after the first
+	fingerprint []uint32
+}
+
+func newFingerprinter(sampleRate int) *fingerprinter {
+	f := &fingerprinter{
+		sampleRate: sampleRate,
+		frame:      make([]float64, 0, fingerprintWindow),
+		window:     make([]float64, fingerprintWindow),
+		bands:      make([]int, 34),
+		fft:        newFFT(fingerprintWindow),
+	}
+	// hann window
+	for i := range f.window {
+		f.window[i] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(fingerprintWindow-1))
+	}
+	ratio := float64(fingerprintMaxFreq) / fingerprintMinFreq
+	for i := range f.bands {
+		freq := fingerprintMinFreq * math.Pow(ratio, float64(i)/float64(len(f.bands)-1))
+		f.bands[i] = int(math.Round(freq * fingerprintWindow / fingerprintRate))
+	}
+	return f
+}
+
+// write adds the next sample at sampleRate, averaging the samples falling within each resampled one
+func (f *fingerprinter) write(sample float64) {
+	f.sum += sample
+	f.count++
+	f.samples++
+	// the resampled sample is complete once the next sample falls within the following one
+	if f.samples*fingerprintRate/int64(f.sampleRate) == (f.samples-1)*fingerprintRate/int64(f.sampleRate) {
+		return
+	}
+	f.frame = append(f.frame, f.sum/float64(f.count))
+	f.sum, f.count = 0, 0
+	if len(f.frame) == fingerprintWindow {
+		f.processFrame()
+		f.frame = append(f.frame[:0], f.frame[fingerprintHop:]...)
+	}
+}
+
+// processFrame computes the band energies of the frame and its sub-fingerprint
+func (f *fingerprinter) processFrame() {
+	re, im := f.fft.re, f.fft.im
+	for i, s := range f.frame {
+		re[i], im[i] = s*f.window[i], 0
+	}
+	f.fft.transform()
+
+	energy := make([]float64, len(f.bands)-1)
+	for b := range energy {
+		for k := f.bands[b]; k < f.bands[b+1]; k++ {
+			energy[b] += re[k]*re[k] + im[k]*im[k]
+		}
+	}
+	if f.prevEnergy != nil {
+		var sub uint32
+		for b := 0; b < len(energy)-1; b++ {
+			if energy[b]-energy[b+1]-(f.prevEnergy[b]-f.prevEnergy[b+1]) > 0 {
+				sub |= 1 << uint(b)
+			}
+		}
+		f.fingerprint = append(f.fingerprint, sub)
+	}
+	f.prevEnergy = energy
+}
+
+// fft is an in place radix-2 fast fourier transform of a fixed power of 2 size
+type fft struct {
+	re, im   []float64
+	cos, sin []float64
+	reversed []int
+}
+
+func newFFT(size int) *fft {
+	f := &fft{
+		re:       make([]float64, size),
+		im:       make([]float64, size),
+		cos:      make([]float64, size/2),
+		sin:      make([]float64, size/2),
+		reversed: make([]int, size),
+	}
+	for i := range f.cos {
+		f.cos[i] = math.Cos(2 * math.Pi * float64(i) / float64(size))
+		f.sin[i] = -math.Sin(2 * math.Pi * float64(i) / float64(size))
+	}
+	bits := 0
+	for 1<<uint(bits) < size {
+		bits++
+	}
+	for i := range f.reversed {
+		r := 0
+		for b := 0; b < bits; b++ {
+			r |= (i >> uint(b) & 1) << uint(bits-1-b)
+		}
+		f.reversed[i] = r
+	}
+	return f
+}
+
+// transform replaces re & im with their discrete fourier transform
+func (f *fft) transform() {
+	n := len(f.re)
+	for i, r := range f.reversed {
+		if i < r {
+			f.re[i], f.re[r] = f.re[r], f.re[i]
+			f.im[i], f.im[r] = f.im[r], f.im[i]
+		}
+	}
+	for size := 2; size <= n; size <<= 1 {
+		half, step := size/2, n/size
+		for start := 0; start < n; start += size {
+			for k := 0; k < half; k++ {
+				wr, wi := f.cos[k*step], f.sin[k*step]
+				i, j := start+k, start+k+half
+				tr := wr*f.re[j] - wi*f.im[j]
+				ti := wr*f.im[j] + wi*f.re[j]
+				f.re[j], f.im[j] = f.re[i]-tr, f.im[i]-ti
+				f.re[i], f.im[i] = f.re[i]+tr, f.im[i]+ti
+			}
+		}
+	}
+}
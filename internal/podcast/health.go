@@ -0,0 +1,145 @@
+package podcast
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// classes of feed errors
+const (
+	ErrorClassDNS          = "dns"
+	ErrorClassTLS          = "tls"
+	ErrorClassHTTPStatus   = "http_status"
+	ErrorClassNetwork      = "network"
+	ErrorClassXMLParse     = "xml_parse"
+	ErrorClassEmptyChannel = "empty_channel"
+	ErrorClassInternal     = "internal"
+)
+
+const (
+	// consecutive failures after which a feed is quarantined
+	quarantineFailures = 10
+	// interval between checks of a quarantined feed
+	quarantineInterval = time.Hour * 24 * 7
+)
+
+// feedError is an error updating a podcast via its feed along with the class of the error
+type feedError struct {
+	class string
+	err   error
+}
+
+func (e *feedError) Error() string {
+	return e.err.Error()
+}
+
+// classifyFetchError determines the class of an error downloading a feed
+func classifyFetchError(err error, fetched *models.FetchState) string {
+	if fetched != nil && fetched.LastStatus != 0 && fetched.LastStatus != http.StatusOK {
+		return ErrorClassHTTPStatus
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return ErrorClassDNS
+	}
+	var recordErr tls.RecordHeaderError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	if errors.As(err, &recordErr) || errors.As(err, &authorityErr) ||
+		errors.As(err, &hostnameErr) || errors.As(err, &invalidErr) {
+		return ErrorClassTLS
+	}
+	return ErrorClassNetwork
+}
+
+// FindFeedHealth finds the health of the podcast's feed via podcast id
+func FindFeedHealth(dbClient db.Database, podID *protos.ObjectID) (*protos.FeedHealth, error) {
+	health := &protos.FeedHealth{}
+	err := dbClient.FindOne(database.ColFeedHealth, health, &db.Filter{"podcastid": podID}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("FindFeedHealth() error: %v", err)
+	}
+	return health, nil
+}
+
+// FindUnhealthyFeeds finds the health of the feeds the user is subscribed to whose last check failed, most failures first
+func FindUnhealthyFeeds(dbClient db.Database, userID *protos.ObjectID) ([]*protos.FeedHealth, error) {
+	var subs []*protos.Subscription
+	err := dbClient.FindAll(database.ColSubscription, &subs, &db.Filter{"userid": userID}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("FindUnhealthyFeeds() error finding subscriptions: %v", err)
+	}
+	unhealthy := []*protos.FeedHealth{}
+	if len(subs) == 0 {
+		return unhealthy, nil
+	}
+	opts := db.CreateOptions().SetSort("consecutivefailures", -1)
+	err = dbClient.FindAll(database.ColFeedHealth, &unhealthy, unhealthyFilter(subs), opts)
+	if err != nil {
+		return nil, fmt.Errorf("FindUnhealthyFeeds() error: %v", err)
+	}
+	return unhealthy, nil
+}
+
+// unhealthyFilter filters feed health to the subscriptions' feeds whose last check failed
+func unhealthyFilter(subs []*protos.Subscription) *db.Filter {
+	podIDs := make([]*protos.ObjectID, len(subs))
+	for i, sub := range subs {
+		podIDs[i] = sub.PodcastID
+	}
+	return &db.Filter{
+		"podcastid":           db.Filter{"$in": podIDs},
+		"consecutivefailures": db.Filter{"$gt": 0},
+	}
+}
+
+// UpsertFeedHealth upserts the health of the podcast's feed
+func UpsertFeedHealth(dbClient db.Database, health *protos.FeedHealth) error {
+	err := dbClient.Upsert(database.ColFeedHealth, health, &db.Filter{"podcastid": health.PodcastID})
+	if err != nil {
+		return fmt.Errorf("error upserting feed health: %v", err)
+	}
+	return nil
+}
+
+// recordFeedHealth records the outcome of updating the podcast via its feed,
+// the feed is quarantined after quarantineFailures consecutive failures
+func recordFeedHealth(dbClient db.Database, pod *protos.Podcast, updateErr error) (*protos.FeedHealth, error) {
+	health, err := FindFeedHealth(dbClient, pod.Id)
+	if err != nil {
+		// feed has not been checked before
+		health = &protos.FeedHealth{PodcastID: pod.Id}
+	}
+	health.Title = pod.Title
+	health.Rss = pod.Rss
+
+	if updateErr == nil {
+		health.ConsecutiveFailures = 0
+		health.Quarantined = false
+		health.LastSuccess = ptypes.TimestampNow()
+	} else {
+		class := ErrorClassInternal
+		var feedErr *feedError
+		if errors.As(updateErr, &feedErr) {
+			class = feedErr.class
+		}
+		health.ConsecutiveFailures++
+		health.Quarantined = health.ConsecutiveFailures >= quarantineFailures
+		health.LastErrorClass = class
+		health.LastError = updateErr.Error()
+		health.LastFailure = ptypes.TimestampNow()
+	}
+	return health, UpsertFeedHealth(dbClient, health)
+}
@@ -0,0 +1,158 @@
+package podcast
+
+import (
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/stockpile/mock"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+func Test_classifyFetchError(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		fetched *models.FetchState
+		want    string
+	}{
+		{
+			name:    "http_status",
+			err:     errors.New("downloadRSS() unexpected status: 404 Not Found"),
+			fetched: &models.FetchState{LastStatus: http.StatusNotFound},
+			want:    ErrorClassHTTPStatus,
+		},
+		{
+			name: "dns",
+			err:  &url.Error{Op: "Get", URL: "https://nowhere.invalid", Err: &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "nowhere.invalid"}}},
+			want: ErrorClassDNS,
+		},
+		{
+			name: "tls",
+			err:  &url.Error{Op: "Get", URL: "https://self-signed.example.com", Err: x509.UnknownAuthorityError{}},
+			want: ErrorClassTLS,
+		},
+		{
+			name:    "network",
+			err:     &url.Error{Op: "Get", URL: "https://example.com", Err: errors.New("connection reset by peer")},
+			fetched: &models.FetchState{LastStatus: http.StatusOK},
+			want:    ErrorClassNetwork,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyFetchError(tt.err, tt.fetched); got != tt.want {
+				t.Errorf("classifyFetchError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_updatePodcast_errorClass(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/invalid":
+			res.Write([]byte("<rss><channel><title>Broken"))
+		case "/empty":
+			res.Write([]byte(`<?xml version="1.0"?><rss version="2.0"><channel><title>Empty</title></channel></rss>`))
+		default:
+			res.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{name: "xml_parse", path: "/invalid", want: ErrorClassXMLParse},
+		{name: "empty_channel", path: "/empty", want: ErrorClassEmptyChannel},
+		{name: "http_status", path: "/error", want: ErrorClassHTTPStatus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := mock.CreateDB()
+			pod := &protos.Podcast{Id: protos.NewObjectID(), Title: "Broken", Rss: server.URL + tt.path}
+			err := updatePodcast(mockDB, pod)
+			var feedErr *feedError
+			if !errors.As(err, &feedErr) {
+				t.Fatalf("updatePodcast() error = %v, want a feed error", err)
+			}
+			if feedErr.class != tt.want {
+				t.Errorf("updatePodcast() error class = %v, want %v", feedErr.class, tt.want)
+			}
+		})
+	}
+}
+
+func Test_recordFeedHealth(t *testing.T) {
+	mockDB := mock.CreateDB()
+	pod := &protos.Podcast{Id: protos.NewObjectID(), Title: "Go Time", Rss: "https://example.com/feed"}
+	insertOrFail(t, mockDB, database.ColPodcast, pod)
+	updateErr := &feedError{class: ErrorClassDNS, err: errors.New("no such host")}
+
+	for i := 1; i <= quarantineFailures; i++ {
+		health, err := recordFeedHealth(mockDB, pod, updateErr)
+		if err != nil {
+			t.Fatalf("recordFeedHealth() error = %v", err)
+		}
+		if health.ConsecutiveFailures != int32(i) || health.LastErrorClass != ErrorClassDNS || health.LastFailure == nil {
+			t.Fatalf("recordFeedHealth() failure %d health = %v", i, health)
+		}
+		if health.Quarantined != (i == quarantineFailures) {
+			t.Errorf("recordFeedHealth() failure %d quarantined = %v", i, health.Quarantined)
+		}
+	}
+
+	// an unclassified error is internal & a success restores the feed
+	health, err := recordFeedHealth(mockDB, pod, errors.New("error upserting episode"))
+	if err != nil || health.LastErrorClass != ErrorClassInternal {
+		t.Errorf("recordFeedHealth() health = %v, error = %v", health, err)
+	}
+	_, err = recordFeedHealth(mockDB, pod, nil)
+	if err != nil {
+		t.Fatalf("recordFeedHealth() error = %v", err)
+	}
+	stored, err := FindFeedHealth(mockDB, pod.Id)
+	if err != nil || stored.ConsecutiveFailures != 0 || stored.Quarantined || stored.LastSuccess == nil || stored.LastErrorClass != ErrorClassInternal {
+		t.Errorf("recordFeedHealth() stored health = %v, error = %v", stored, err)
+	}
+}
+
+func TestFindUnhealthyFeeds(t *testing.T) {
+	mockDB := mock.CreateDB()
+	pod := &protos.Podcast{Id: protos.NewObjectID(), Title: "Go Time"}
+	// another user subscribes to the failing feed
+	insertOrFail(t, mockDB, database.ColSubscription, &protos.Subscription{Id: protos.NewObjectID(), UserID: protos.NewObjectID(), PodcastID: pod.Id})
+	if _, err := recordFeedHealth(mockDB, pod, errors.New("no such host")); err != nil {
+		t.Fatalf("recordFeedHealth() error = %v", err)
+	}
+	// the feed health is found by operators the mock database doesn't support, so only an unsubscribed user is found here
+	unhealthy, err := FindUnhealthyFeeds(mockDB, protos.NewObjectID())
+	if err != nil || unhealthy == nil || len(unhealthy) != 0 {
+		t.Errorf("FindUnhealthyFeeds() = %v, error = %v", unhealthy, err)
+	}
+}
+
+func Test_unhealthyFilter(t *testing.T) {
+	subs := []*protos.Subscription{
+		{Id: protos.NewObjectID(), PodcastID: protos.ObjectIDFromHex("pod1")},
+		{Id: protos.NewObjectID(), PodcastID: protos.ObjectIDFromHex("pod2")},
+	}
+	want := &db.Filter{
+		"podcastid":           db.Filter{"$in": []*protos.ObjectID{protos.ObjectIDFromHex("pod1"), protos.ObjectIDFromHex("pod2")}},
+		"consecutivefailures": db.Filter{"$gt": 0},
+	}
+	if got := unhealthyFilter(subs); !reflect.DeepEqual(got, want) {
+		t.Errorf("unhealthyFilter() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,296 @@
+package podcast
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// maxChapterImage is the largest image embedded in a chapter that is kept, as a data uri
+const maxChapterImage = 256 << 10
+
+// id3Frame is a frame of an ID3v2 tag
+type id3Frame struct {
+	id   string
+	data []byte
+}
+
+// parseID3Chapters extracts the chapters from the CHAP frames of the ID3v2.3/2.4 tag, ordered by the
+// top level CTOC frame if there is one, otherwise by their start
+func parseID3Chapters(tag []byte) []*protos.Chapter {
+	if len(tag) < 10 || string(tag[:3]) != "ID3" {
+		return nil
+	}
+	version, flags := tag[3], tag[5]
+	if version != 3 && version != 4 {
+		// ID3v2.2 has no chapters
+		return nil
+	}
+	end := 10 + int(syncsafe(tag[6:]))
+	if end > len(tag) {
+		end = len(tag)
+	}
+	body := tag[10:end]
+	if version == 3 && flags&0x80 != 0 {
+		body = removeUnsync(body)
+	}
+	if flags&0x40 != 0 && len(body) >= 4 {
+		// extended header, its size only includes itself in ID3v2.4
+		size := int(binary.BigEndian.Uint32(body))
+		if version == 4 {
+			size = int(syncsafe(body))
+		} else {
+			size += 4
+		}
+		if size > len(body) {
+			return nil
+		}
+		body = body[size:]
+	}
+
+	chapters := map[string]*protos.Chapter{}
+	var order []string
+	var toc []string
+	for _, frame := range parseID3Frames(body, version) {
+		switch frame.id {
+		case "CHAP":
+			elementID, chapter := parseCHAP(frame.data, version)
+			if chapter == nil {
+				continue
+			}
+			if _, ok := chapters[elementID]; !ok {
+				order = append(order, elementID)
+			}
+			chapters[elementID] = chapter
+		case "CTOC":
+			if entries, topLevel := parseCTOC(frame.data); topLevel || toc == nil {
+				toc = entries
+			}
+		}
+	}
+
+	if len(chapters) == 0 {
+		return nil
+	}
+	var ordered []*protos.Chapter
+	for _, elementID := range toc {
+		if c, ok := chapters[elementID]; ok {
+			ordered = append(ordered, c)
+		}
+	}
+	if len(ordered) > 0 && len(ordered) == len(chapters) {
+		return ordered
+	}
+	// no table of contents, or chapters it doesn't list
+	ordered = make([]*protos.Chapter, 0, len(order))
+	for _, elementID := range order {
+		ordered = append(ordered, chapters[elementID])
+	}
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].StartMillis < ordered[j].StartMillis })
+	return ordered
+}
+
+// parseID3Frames parses the frames of the tag's body, or the sub frames of a CHAP or CTOC frame
+func parseID3Frames(data []byte, version byte) []id3Frame {
+	var frames []id3Frame
+	for pos := 0; pos+10 <= len(data); {
+		if data[pos] == 0 {
+			// padding
+			break
+		}
+		id := string(data[pos : pos+4])
+		size := int(binary.BigEndian.Uint32(data[pos+4:]))
+		if version == 4 {
+			size = int(syncsafe(data[pos+4:]))
+		}
+		formatFlags := data[pos+9]
+		pos += 10
+		if size < 0 || pos+size > len(data) {
+			break
+		}
+		frame := data[pos : pos+size]
+		pos += size
+
+		if version == 4 {
+			// compressed or encrypted frames are skipped
+			if formatFlags&0x0c != 0 {
+				continue
+			}
+			if formatFlags&0x40 != 0 && len(frame) >= 1 {
+				// grouping identity
+				frame = frame[1:]
+			}
+			if formatFlags&0x01 != 0 && len(frame) >= 4 {
+				// data length indicator
+				frame = frame[4:]
+			}
+			if formatFlags&0x02 != 0 {
+				frame = removeUnsync(frame)
+			}
+		} else {
+			if formatFlags&0xc0 != 0 {
+				continue
+			}
+			if formatFlags&0x20 != 0 && len(frame) >= 1 {
+				// grouping identity
+				frame = frame[1:]
+			}
+		}
+		frames = append(frames, id3Frame{id: id, data: frame})
+	}
+	return frames
+}
+
+// parseCHAP parses a CHAP frame, returns its element id & the chapter
+func parseCHAP(data []byte, version byte) (string, *protos.Chapter) {
+	i := bytes.IndexByte(data, 0)
+	if i < 0 || len(data) < i+17 {
+		return "", nil
+	}
+	elementID := string(data[:i])
+	times := data[i+1:]
+	chapter := &protos.Chapter{
+		StartMillis: int64(binary.BigEndian.Uint32(times)),
+		EndMillis:   int64(binary.BigEndian.Uint32(times[4:])),
+	}
+	for _, frame := range parseID3Frames(times[16:], version) {
+		switch {
+		case frame.id == "TIT2":
+			chapter.Title = id3Text(frame.data)
+		case frame.id == "WXXX":
+			if len(frame.data) > 1 {
+				_, url := splitID3Text(frame.data[0], frame.data[1:])
+				chapter.Url = strings.TrimSpace(latin1(url))
+			}
+		case frame.id == "APIC":
+			chapter.Img = id3Picture(frame.data)
+		case strings.HasPrefix(frame.id, "W") && chapter.Url == "":
+			chapter.Url = strings.TrimSpace(latin1(frame.data))
+		}
+	}
+	return elementID, chapter
+}
+
+// parseCTOC parses a CTOC frame, returns the element ids of its entries & whether it is the top level table of contents
+func parseCTOC(data []byte) ([]string, bool) {
+	i := bytes.IndexByte(data, 0)
+	if i < 0 || len(data) < i+3 {
+		return nil, false
+	}
+	flags, count := data[i+1], int(data[i+2])
+	entries := make([]string, 0, count)
+	rest := data[i+3:]
+	for n := 0; n < count; n++ {
+		end := bytes.IndexByte(rest, 0)
+		if end < 0 {
+			break
+		}
+		entries = append(entries, string(rest[:end]))
+		rest = rest[end+1:]
+	}
+	return entries, flags&0x02 != 0
+}
+
+// id3Picture returns the image of an APIC frame, a url if the frame links to it or a data uri if it is small enough
+func id3Picture(data []byte) string {
+	if len(data) < 2 {
+		return ""
+	}
+	encoding := data[0]
+	end := bytes.IndexByte(data[1:], 0)
+	if end < 0 {
+		return ""
+	}
+	mimeType := latin1(data[1 : 1+end])
+	rest := data[2+end:]
+	if len(rest) < 1 {
+		return ""
+	}
+	// skip the picture type & description
+	_, picture := splitID3Text(encoding, rest[1:])
+	if mimeType == "-->" {
+		return strings.TrimSpace(latin1(picture))
+	}
+	if len(picture) == 0 || len(picture) > maxChapterImage {
+		return ""
+	}
+	if !strings.Contains(mimeType, "/") {
+		// ID3v2.2 style image format
+		mimeType = "image/" + strings.ToLower(mimeType)
+	}
+	return "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(picture)
+}
+
+// id3Text decodes a text frame, the first byte is its encoding
+func id3Text(data []byte) string {
+	if len(data) < 1 {
+		return ""
+	}
+	text, _ := splitID3Text(data[0], data[1:])
+	return strings.TrimSpace(decodeID3Text(data[0], text))
+}
+
+// splitID3Text splits data at the end of the null terminated string in the encoding
+func splitID3Text(encoding byte, data []byte) ([]byte, []byte) {
+	if encoding == 1 || encoding == 2 {
+		// utf-16 strings end with two null bytes at an even offset
+		for i := 0; i+1 < len(data); i += 2 {
+			if data[i] == 0 && data[i+1] == 0 {
+				return data[:i], data[i+2:]
+			}
+		}
+		return data, nil
+	}
+	if i := bytes.IndexByte(data, 0); i >= 0 {
+		return data[:i], data[i+1:]
+	}
+	return data, nil
+}
+
+// decodeID3Text decodes text of the encoding, ISO-8859-1, UTF-16 with a BOM, UTF-16BE or UTF-8
+func decodeID3Text(encoding byte, data []byte) string {
+	switch encoding {
+	case 0:
+		return latin1(data)
+	case 1, 2:
+		order := binary.ByteOrder(binary.BigEndian)
+		if encoding == 1 && len(data) >= 2 {
+			if data[0] == 0xff && data[1] == 0xfe {
+				order = binary.LittleEndian
+			}
+			if (data[0] == 0xff && data[1] == 0xfe) || (data[0] == 0xfe && data[1] == 0xff) {
+				data = data[2:]
+			}
+		}
+		units := make([]uint16, len(data)/2)
+		for i := range units {
+			units[i] = order.Uint16(data[i*2:])
+		}
+		return string(utf16.Decode(units))
+	}
+	return string(data)
+}
+
+// latin1 decodes ISO-8859-1 text
+func latin1(data []byte) string {
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		runes[i] = rune(b)
+	}
+	return string(runes)
+}
+
+// syncsafe decodes a 32 bit syncsafe integer, 7 bits per byte
+func syncsafe(b []byte) int64 {
+	return int64(b[0]&0x7f)<<21 | int64(b[1]&0x7f)<<14 | int64(b[2]&0x7f)<<7 | int64(b[3]&0x7f)
+}
+
+// removeUnsync reverses the unsynchronisation scheme, which inserts a 0x00 after every 0xff
+func removeUnsync(data []byte) []byte {
+	return bytes.ReplaceAll(data, []byte{0xff, 0x00}, []byte{0xff})
+}
@@ -0,0 +1,148 @@
+package podcast
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// id3v2Tag creates an ID3v2 tag of the version holding the frames, applying unsynchronisation if the flag is set
+func id3v2Tag(version, flags byte, frames ...[]byte) []byte {
+	body := bytes.Join(frames, nil)
+	if flags&0x80 != 0 {
+		body = bytes.ReplaceAll(body, []byte{0xff}, []byte{0xff, 0x00})
+	}
+	size := len(body)
+	tag := []byte{'I', 'D', '3', version, 0, flags, byte(size >> 21 & 0x7f), byte(size >> 14 & 0x7f), byte(size >> 7 & 0x7f), byte(size & 0x7f)}
+	return append(tag, body...)
+}
+
+// id3v2Frame creates a frame of the tag version, ID3v2.4 frame sizes are syncsafe
+func id3v2Frame(version byte, id string, data []byte) []byte {
+	frame := make([]byte, 10, 10+len(data))
+	copy(frame, id)
+	size := len(data)
+	if version == 4 {
+		size = size>>21&0x7f<<24 | size>>14&0x7f<<16 | size>>7&0x7f<<8 | size&0x7f
+	}
+	binary.BigEndian.PutUint32(frame[4:], uint32(size))
+	return append(frame, data...)
+}
+
+// chapFrame creates a CHAP frame with the sub frames
+func chapFrame(version byte, elementID string, start, end uint32, subFrames ...[]byte) []byte {
+	data := append([]byte(elementID), 0)
+	times := make([]byte, 16)
+	binary.BigEndian.PutUint32(times, start)
+	binary.BigEndian.PutUint32(times[4:], end)
+	for i := 8; i < 16; i++ {
+		// unused byte offsets
+		times[i] = 0xff
+	}
+	data = append(data, times...)
+	return id3v2Frame(version, "CHAP", append(data, bytes.Join(subFrames, nil)...))
+}
+
+// ctocFrame creates a CTOC frame listing the element ids
+func ctocFrame(version byte, elementID string, topLevel bool, entries ...string) []byte {
+	data := append([]byte(elementID), 0)
+	var flags byte = 0x01
+	if topLevel {
+		flags |= 0x02
+	}
+	data = append(data, flags, byte(len(entries)))
+	for _, e := range entries {
+		data = append(append(data, e...), 0)
+	}
+	return id3v2Frame(version, "CTOC", data)
+}
+
+// titleFrame creates a latin1 TIT2 frame
+func titleFrame(version byte, title string) []byte {
+	return id3v2Frame(version, "TIT2", append([]byte{0}, title...))
+}
+
+func Test_parseID3Chapters(t *testing.T) {
+	// "Café" in UTF-16 with a little endian BOM
+	utf16Title := id3v2Frame(4, "TIT2", []byte{1, 0xff, 0xfe, 'C', 0, 'a', 0, 'f', 0, 0xe9, 0, 0, 0})
+	wxxx := id3v2Frame(3, "WXXX", append([]byte{0, 'l', 'i', 'n', 'k', 0}, "https://example.com/news"...))
+	linkedImage := id3v2Frame(3, "APIC", append([]byte{0, '-', '-', '>', 0, 3, 0}, "https://example.com/news.jpg"...))
+	embeddedImage := id3v2Frame(3, "APIC", append([]byte{0, 'i', 'm', 'a', 'g', 'e', '/', 'p', 'n', 'g', 0, 3, 'c', 'o', 'v', 'e', 'r', 0}, "png"...))
+
+	tests := []struct {
+		name string
+		tag  []byte
+		want []*protos.Chapter
+	}{
+		{
+			name: "ordered_by_toc",
+			tag: id3v2Tag(3, 0,
+				ctocFrame(3, "toc", true, "chp0", "chp1"),
+				chapFrame(3, "chp1", 60000, 120000, titleFrame(3, "News"), wxxx, linkedImage),
+				chapFrame(3, "chp0", 0, 60000, titleFrame(3, "Intro"), embeddedImage),
+			),
+			want: []*protos.Chapter{
+				{StartMillis: 0, EndMillis: 60000, Title: "Intro", Img: "data:image/png;base64,cG5n"},
+				{StartMillis: 60000, EndMillis: 120000, Title: "News", Url: "https://example.com/news", Img: "https://example.com/news.jpg"},
+			},
+		},
+		{
+			name: "sorted_by_start",
+			tag: id3v2Tag(4, 0,
+				chapFrame(4, "b", 30000, 45000, titleFrame(4, "Second")),
+				chapFrame(4, "a", 0, 30000, utf16Title),
+			),
+			want: []*protos.Chapter{
+				{StartMillis: 0, EndMillis: 30000, Title: "Café"},
+				{StartMillis: 30000, EndMillis: 45000, Title: "Second"},
+			},
+		},
+		{
+			name: "toc_missing_chapter",
+			tag: id3v2Tag(3, 0,
+				ctocFrame(3, "toc", true, "c"),
+				chapFrame(3, "c", 20000, 40000, titleFrame(3, "Listed")),
+				chapFrame(3, "d", 0, 20000, titleFrame(3, "Unlisted")),
+			),
+			want: []*protos.Chapter{
+				{StartMillis: 0, EndMillis: 20000, Title: "Unlisted"},
+				{StartMillis: 20000, EndMillis: 40000, Title: "Listed"},
+			},
+		},
+		{
+			name: "unsynchronised",
+			tag:  id3v2Tag(3, 0x80, chapFrame(3, "chp0", 0xff00, 0xffff, titleFrame(3, "Synced"))),
+			want: []*protos.Chapter{{StartMillis: 0xff00, EndMillis: 0xffff, Title: "Synced"}},
+		},
+		{
+			name: "padding",
+			tag:  id3v2Tag(4, 0, chapFrame(4, "chp0", 0, 1000, titleFrame(4, "Padded")), make([]byte, 100)),
+			want: []*protos.Chapter{{StartMillis: 0, EndMillis: 1000, Title: "Padded"}},
+		},
+		{
+			name: "no_chapters",
+			tag:  id3v2Tag(4, 0, titleFrame(4, "Episode")),
+			want: nil,
+		},
+		{
+			name: "id3v2.2",
+			tag:  id3v2Tag(2, 0, make([]byte, 20)),
+			want: nil,
+		},
+		{
+			name: "not_id3",
+			tag:  mp3Frames(mpeg1Header, 1),
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseID3Chapters(tt.tag); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseID3Chapters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
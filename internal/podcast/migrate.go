@@ -0,0 +1,258 @@
+package podcast
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// userEpisodePage is how many user episodes are found at once migrating them
+const userEpisodePage = 1000
+
+// MergeDuplicateEpisodes is a one-off migration merging the duplicate episodes created
+// when episodes were matched on title & pubdate. Duplicates share a guid, enclosure url
+// or title & pubdate. The oldest episode of each group is kept with the metadata of the
+// newest, user episodes & subscriptions are re-pointed to it and the rest are deleted.
+// returns the number of episodes deleted
+func MergeDuplicateEpisodes(dbClient db.Database) (int, error) {
+	merged := 0
+	for start, end := 0, 10; ; start, end = end, end+10 {
+		podcasts, err := FindPodcastsByRange(dbClient, start, end)
+		if err != nil {
+			return merged, fmt.Errorf("MergeDuplicateEpisodes() error finding podcasts: %v", err)
+		}
+		if len(podcasts) == 0 {
+			return merged, nil
+		}
+		for _, pod := range podcasts {
+			var episodes []*protos.Episode
+			err = dbClient.FindAll(database.ColEpisode, &episodes, &db.Filter{"podcastid": pod.Id}, nil)
+			if err != nil {
+				return merged, fmt.Errorf("MergeDuplicateEpisodes() error finding episodes of %v: %v", pod.Title, err)
+			}
+			for _, group := range groupDuplicateEpisodes(episodes) {
+				if len(group) < 2 {
+					continue
+				}
+				err = mergeEpisodes(dbClient, group)
+				if err != nil {
+					return merged, fmt.Errorf("MergeDuplicateEpisodes() error merging episodes of %v: %v", pod.Title, err)
+				}
+				merged += len(group) - 1
+			}
+		}
+	}
+}
+
+// groupDuplicateEpisodes groups episodes that share a guid, enclosure url or title & pubdate,
+// episodes with different guids are never grouped. groups are ordered oldest first
+func groupDuplicateEpisodes(episodes []*protos.Episode) [][]*protos.Episode {
+	var groups [][]*protos.Episode
+	groupGUIDs := []string{}
+	keyGroup := make(map[string]int)
+	for _, epi := range episodes {
+		group := -1
+		for _, key := range episodeKeys(epi) {
+			g, ok := keyGroup[key]
+			if ok && (epi.Guid == "" || groupGUIDs[g] == "" || groupGUIDs[g] == epi.Guid) {
+				group = g
+				break
+			}
+		}
+		if group == -1 {
+			group = len(groups)
+			groups = append(groups, nil)
+			groupGUIDs = append(groupGUIDs, "")
+		}
+		groups[group] = append(groups[group], epi)
+		if groupGUIDs[group] == "" {
+			groupGUIDs[group] = epi.Guid
+		}
+		for _, key := range episodeKeys(epi) {
+			if _, ok := keyGroup[key]; !ok {
+				keyGroup[key] = group
+			}
+		}
+	}
+	for _, group := range groups {
+		// object ids begin with their creation time
+		sort.Slice(group, func(i, j int) bool { return group[i].Id.GetHex() < group[j].Id.GetHex() })
+	}
+	return groups
+}
+
+// episodeKeys returns the keys an episode can be identified by
+func episodeKeys(epi *protos.Episode) []string {
+	var keys []string
+	if epi.Guid != "" {
+		keys = append(keys, "guid:"+epi.Guid)
+	}
+	if epi.MP3URL != "" {
+		keys = append(keys, "url:"+epi.MP3URL)
+	}
+	return append(keys, "title:"+epi.Title+"@"+strconv.FormatInt(epi.PubDate.GetSeconds(), 10))
+}
+
+// mergeEpisodes keeps the first episode of the group with the metadata of the last,
+// moves the user episodes & subscriptions of the others onto it and deletes them
+func mergeEpisodes(dbClient db.Database, group []*protos.Episode) error {
+	keep := group[0]
+	merged := proto.Clone(group[len(group)-1]).(*protos.Episode)
+	merged.Id = keep.Id
+
+	for _, dup := range group[1:] {
+		err := repointEpisode(dbClient, dup, keep.Id)
+		if err != nil {
+			return err
+		}
+		err = dbClient.Delete(database.ColEpisode, &db.Filter{"_id": dup.Id})
+		if err != nil {
+			return fmt.Errorf("error deleting duplicate episode: %v", err)
+		}
+		log.Printf("mergeEpisodes() merged episode %v into %v: %v\n", dup.Id.GetHex(), keep.Id.GetHex(), dup.Title)
+	}
+	return UpsertEpisode(dbClient, merged)
+}
+
+// repointEpisode moves the user episodes & subscription progress of the duplicate onto episode id,
+// if a user has played both the most recently seen user episode is kept
+func repointEpisode(dbClient db.Database, dup *protos.Episode, id *protos.ObjectID) error {
+	var userEpis []*protos.UserEpisode
+	err := dbClient.FindAll(database.ColUserEpisode, &userEpis, &db.Filter{"episodeid": dup.Id}, nil)
+	if err != nil {
+		return fmt.Errorf("error finding user episodes: %v", err)
+	}
+	for _, userEpi := range userEpis {
+		var existing []*protos.UserEpisode
+		err = dbClient.FindAll(database.ColUserEpisode, &existing, &db.Filter{"userid": userEpi.UserID, "episodeid": id}, nil)
+		if err != nil {
+			return fmt.Errorf("error finding user episodes: %v", err)
+		}
+		// merged before moving so the user never has two of the episode
+		kept, err := mergeUserEpisodes(dbClient, append(existing, userEpi))
+		if err != nil {
+			return err
+		}
+		if kept != userEpi {
+			continue
+		}
+		userEpi.EpisodeID = id
+		err = dbClient.Upsert(database.ColUserEpisode, userEpi, &db.Filter{"_id": userEpi.Id})
+		if err != nil {
+			return fmt.Errorf("error upserting user episode: %v", err)
+		}
+	}
+
+	var subs []*protos.Subscription
+	err = dbClient.FindAll(database.ColSubscription, &subs, &db.Filter{"podcastid": dup.PodcastID}, nil)
+	if err != nil {
+		return fmt.Errorf("error finding subscriptions: %v", err)
+	}
+	for _, sub := range subs {
+		found, changed := false, false
+		ids := make([]*protos.ObjectID, 0, len(sub.InProgressIDs))
+		for _, inProgress := range sub.InProgressIDs {
+			switch inProgress.GetHex() {
+			case dup.Id.GetHex():
+				changed = true
+				continue
+			case id.GetHex():
+				found = true
+			}
+			ids = append(ids, inProgress)
+		}
+		if !changed {
+			continue
+		}
+		if !found {
+			ids = append(ids, id)
+		}
+		sub.InProgressIDs = ids
+		err = dbClient.Upsert(database.ColSubscription, sub, &db.Filter{"_id": sub.Id})
+		if err != nil {
+			return fmt.Errorf("error upserting subscription: %v", err)
+		}
+	}
+	return nil
+}
+
+// MergeDuplicateUserEpisodes is a one-off migration merging the user episodes of the same user & episode,
+// which the unique index on them can't be created with. The most recently seen of each is kept and the
+// rest are deleted. returns the number of user episodes deleted
+func MergeDuplicateUserEpisodes(dbClient db.Database) (int, error) {
+	// the users are found before merging as deleting shifts the pages
+	userIDs := map[string]*protos.ObjectID{}
+	for start := int64(0); ; start += userEpisodePage {
+		var page []*protos.UserEpisode
+		opts := db.CreateOptions().SetSort("_id", 1).SetSkip(start).SetLimit(userEpisodePage)
+		err := dbClient.FindAll(database.ColUserEpisode, &page, &db.Filter{}, opts)
+		if err != nil {
+			return 0, fmt.Errorf("MergeDuplicateUserEpisodes() error finding user episodes: %v", err)
+		}
+		for _, userEpi := range page {
+			userIDs[userEpi.UserID.GetHex()] = userEpi.UserID
+		}
+		if len(page) < userEpisodePage {
+			break
+		}
+	}
+
+	merged := 0
+	for _, userID := range userIDs {
+		var userEpis []*protos.UserEpisode
+		err := dbClient.FindAll(database.ColUserEpisode, &userEpis, &db.Filter{"userid": userID}, nil)
+		if err != nil {
+			return merged, fmt.Errorf("MergeDuplicateUserEpisodes() error finding user episodes of %v: %v", userID.GetHex(), err)
+		}
+		groups := map[string][]*protos.UserEpisode{}
+		for _, userEpi := range userEpis {
+			groups[userEpi.EpisodeID.GetHex()] = append(groups[userEpi.EpisodeID.GetHex()], userEpi)
+		}
+		for _, group := range groups {
+			if len(group) < 2 {
+				continue
+			}
+			_, err = mergeUserEpisodes(dbClient, group)
+			if err != nil {
+				return merged, fmt.Errorf("MergeDuplicateUserEpisodes() error merging user episodes of %v: %v", userID.GetHex(), err)
+			}
+			merged += len(group) - 1
+		}
+	}
+	return merged, nil
+}
+
+// mergeUserEpisodes keeps the most recently seen of the user episodes, the first if they were seen at the same time,
+// and deletes the rest. returns the user episode kept
+func mergeUserEpisodes(dbClient db.Database, userEpis []*protos.UserEpisode) (*protos.UserEpisode, error) {
+	kept := userEpis[0]
+	for _, userEpi := range userEpis[1:] {
+		if seenAfter(userEpi, kept) {
+			kept = userEpi
+		}
+	}
+	for _, userEpi := range userEpis {
+		if userEpi == kept {
+			continue
+		}
+		err := dbClient.Delete(database.ColUserEpisode, &db.Filter{"_id": userEpi.Id})
+		if err != nil {
+			return nil, fmt.Errorf("error deleting user episode: %v", err)
+		}
+	}
+	return kept, nil
+}
+
+// seenAfter returns whether user episode a was last seen after b
+func seenAfter(a, b *protos.UserEpisode) bool {
+	if a.LastSeen.GetSeconds() != b.LastSeen.GetSeconds() {
+		return a.LastSeen.GetSeconds() > b.LastSeen.GetSeconds()
+	}
+	return a.LastSeen.GetNanos() > b.LastSeen.GetNanos()
+}
@@ -0,0 +1,138 @@
+package podcast
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/stockpile/mock"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/protos"
+	"github.com/sschwartz96/syncapod/internal/util"
+)
+
+func TestMergeDuplicateEpisodes(t *testing.T) {
+	mockDB := mock.CreateDB()
+	pod := &protos.Podcast{Id: protos.NewObjectID(), Title: "Go Time"}
+	insertOrFail(t, mockDB, database.ColPodcast, pod)
+
+	pubDate := ptypes.TimestampNow()
+	// original, re-titled duplicate with the same enclosure & re-dated duplicate with the same title
+	original := &protos.Episode{Id: protos.ObjectIDFromHex("a_original"), PodcastID: pod.Id, Title: "Episode 1", MP3URL: "https://example.com/1.mp3", PubDate: pubDate}
+	retitled := &protos.Episode{Id: protos.ObjectIDFromHex("b_retitled"), PodcastID: pod.Id, Title: "Episode 1 (edited)", MP3URL: "https://example.com/1.mp3", PubDate: pubDate}
+	other := &protos.Episode{Id: protos.ObjectIDFromHex("c_other"), PodcastID: pod.Id, Title: "Episode 2", MP3URL: "https://example.com/2.mp3", PubDate: pubDate}
+	insertOrFail(t, mockDB, database.ColEpisode, original)
+	insertOrFail(t, mockDB, database.ColEpisode, retitled)
+	insertOrFail(t, mockDB, database.ColEpisode, other)
+
+	now := ptypes.TimestampNow()
+	later := util.AddToTimestamp(ptypes.TimestampNow(), time.Minute)
+	user1, user2, user3 := protos.ObjectIDFromHex("user1"), protos.ObjectIDFromHex("user2"), protos.ObjectIDFromHex("user3")
+	// user1 played both, the duplicate most recently. user2 only played the duplicate.
+	// user3 already had two of the original, the second most recently seen, and played the duplicate
+	insertOrFail(t, mockDB, database.ColUserEpisode, &protos.UserEpisode{Id: protos.ObjectIDFromHex("ue1"), UserID: user1, EpisodeID: original.Id, Offset: 10, LastSeen: now})
+	insertOrFail(t, mockDB, database.ColUserEpisode, &protos.UserEpisode{Id: protos.ObjectIDFromHex("ue2"), UserID: user1, EpisodeID: retitled.Id, Offset: 20, LastSeen: later})
+	insertOrFail(t, mockDB, database.ColUserEpisode, &protos.UserEpisode{Id: protos.ObjectIDFromHex("ue3"), UserID: user2, EpisodeID: retitled.Id, Offset: 30, LastSeen: now})
+	insertOrFail(t, mockDB, database.ColUserEpisode, &protos.UserEpisode{Id: protos.ObjectIDFromHex("ue4"), UserID: user3, EpisodeID: original.Id, Offset: 40, LastSeen: now})
+	insertOrFail(t, mockDB, database.ColUserEpisode, &protos.UserEpisode{Id: protos.ObjectIDFromHex("ue5"), UserID: user3, EpisodeID: original.Id, Offset: 50, LastSeen: later})
+	insertOrFail(t, mockDB, database.ColUserEpisode, &protos.UserEpisode{Id: protos.ObjectIDFromHex("ue6"), UserID: user3, EpisodeID: retitled.Id, Offset: 60, LastSeen: now})
+	insertOrFail(t, mockDB, database.ColSubscription, &protos.Subscription{Id: protos.ObjectIDFromHex("sub"), UserID: user2, PodcastID: pod.Id, InProgressIDs: []*protos.ObjectID{retitled.Id}})
+
+	merged, err := MergeDuplicateEpisodes(mockDB)
+	if err != nil {
+		t.Fatalf("MergeDuplicateEpisodes() error = %v", err)
+	}
+	if merged != 1 {
+		t.Errorf("MergeDuplicateEpisodes() merged = %v, want 1", merged)
+	}
+
+	var episodes []*protos.Episode
+	err = mockDB.FindAll(database.ColEpisode, &episodes, &db.Filter{"podcastid": pod.Id}, nil)
+	if err != nil {
+		t.Fatalf("MergeDuplicateEpisodes() error finding episodes: %v", err)
+	}
+	if len(episodes) != 2 {
+		t.Errorf("MergeDuplicateEpisodes() episode count = %v, want 2", len(episodes))
+	}
+	kept, err := FindEpisodeByID(mockDB, original.Id)
+	if err != nil || kept.Title != retitled.Title {
+		t.Errorf("MergeDuplicateEpisodes() kept episode = %v, error = %v", kept, err)
+	}
+
+	tests := []struct {
+		name       string
+		userID     *protos.ObjectID
+		wantOffset int64
+	}{
+		{name: "played_both", userID: user1, wantOffset: 20},
+		{name: "played_duplicate", userID: user2, wantOffset: 30},
+		{name: "already_duplicated", userID: user3, wantOffset: 50},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var userEpis []*protos.UserEpisode
+			err := mockDB.FindAll(database.ColUserEpisode, &userEpis, &db.Filter{"userid": tt.userID}, nil)
+			if err != nil {
+				t.Fatalf("MergeDuplicateEpisodes() error finding user episodes: %v", err)
+			}
+			if len(userEpis) != 1 || userEpis[0].EpisodeID.GetHex() != original.Id.GetHex() || userEpis[0].Offset != tt.wantOffset {
+				t.Errorf("MergeDuplicateEpisodes() user episodes = %v", userEpis)
+			}
+		})
+	}
+
+	var sub protos.Subscription
+	err = mockDB.FindOne(database.ColSubscription, &sub, &db.Filter{"userid": user2}, nil)
+	if err != nil {
+		t.Fatalf("MergeDuplicateEpisodes() error finding subscription: %v", err)
+	}
+	if len(sub.InProgressIDs) != 1 || sub.InProgressIDs[0].GetHex() != original.Id.GetHex() {
+		t.Errorf("MergeDuplicateEpisodes() subscription in progress = %v", sub.InProgressIDs)
+	}
+}
+
+func TestMergeDuplicateUserEpisodes(t *testing.T) {
+	mockDB := mock.CreateDB()
+	now := ptypes.TimestampNow()
+	later := util.AddToTimestamp(ptypes.TimestampNow(), time.Minute)
+	user1, user2 := protos.ObjectIDFromHex("user1"), protos.ObjectIDFromHex("user2")
+	epi1, epi2 := protos.ObjectIDFromHex("epi1"), protos.ObjectIDFromHex("epi2")
+	// user1 has three of epi1, the second most recently seen, and one of epi2. user2 has one of epi1
+	for _, userEpi := range []*protos.UserEpisode{
+		{Id: protos.ObjectIDFromHex("ue1"), UserID: user1, EpisodeID: epi1, Offset: 10, LastSeen: now},
+		{Id: protos.ObjectIDFromHex("ue2"), UserID: user1, EpisodeID: epi1, Offset: 20, LastSeen: later},
+		{Id: protos.ObjectIDFromHex("ue3"), UserID: user1, EpisodeID: epi1, Offset: 30, LastSeen: now},
+		{Id: protos.ObjectIDFromHex("ue4"), UserID: user1, EpisodeID: epi2, Offset: 40, LastSeen: now},
+		{Id: protos.ObjectIDFromHex("ue5"), UserID: user2, EpisodeID: epi1, Offset: 50, LastSeen: now},
+	} {
+		insertOrFail(t, mockDB, database.ColUserEpisode, userEpi)
+	}
+
+	merged, err := MergeDuplicateUserEpisodes(mockDB)
+	if err != nil || merged != 2 {
+		t.Fatalf("MergeDuplicateUserEpisodes() = %v, error = %v, want 2", merged, err)
+	}
+	tests := []struct {
+		name       string
+		userID     *protos.ObjectID
+		epiID      *protos.ObjectID
+		wantOffset int64
+	}{
+		{name: "most_recently_seen_kept", userID: user1, epiID: epi1, wantOffset: 20},
+		{name: "other_episode", userID: user1, epiID: epi2, wantOffset: 40},
+		{name: "other_user", userID: user2, epiID: epi1, wantOffset: 50},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var userEpis []*protos.UserEpisode
+			err := mockDB.FindAll(database.ColUserEpisode, &userEpis, &db.Filter{"userid": tt.userID, "episodeid": tt.epiID}, nil)
+			if err != nil || len(userEpis) != 1 || userEpis[0].Offset != tt.wantOffset {
+				t.Errorf("MergeDuplicateUserEpisodes() user episodes = %v, error = %v", userEpis, err)
+			}
+		})
+	}
+	if merged, err = MergeDuplicateUserEpisodes(mockDB); err != nil || merged != 0 {
+		t.Errorf("MergeDuplicateUserEpisodes() again = %v, error = %v, want 0", merged, err)
+	}
+}
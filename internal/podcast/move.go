@@ -0,0 +1,255 @@
+package podcast
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// FindPodcastByFeed finds the podcast via its current or a previous feed url
+func FindPodcastByFeed(dbClient db.Database, url string) (*protos.Podcast, error) {
+	podcast := &protos.Podcast{}
+	err := dbClient.FindOne(database.ColPodcast, podcast, &db.Filter{"rss": url}, nil)
+	if err == nil {
+		return podcast, nil
+	}
+	prev := &models.PreviousFeed{}
+	err = dbClient.FindOne(database.ColFeedHistory, prev, &db.Filter{"url": url}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("FindPodcastByFeed() error: %v", err)
+	}
+	return FindPodcastByID(dbClient, prev.PodcastID)
+}
+
+// FindPreviousFeeds finds the feed urls the podcast has moved away from
+func FindPreviousFeeds(dbClient db.Database, podID *protos.ObjectID) ([]*models.PreviousFeed, error) {
+	var feeds []*models.PreviousFeed
+	err := dbClient.FindAll(database.ColFeedHistory, &feeds, &db.Filter{"podcast_id": podID}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("FindPreviousFeeds() error: %v", err)
+	}
+	return feeds, nil
+}
+
+// UpsertPreviousFeed upserts the previous feed url of a podcast
+func UpsertPreviousFeed(dbClient db.Database, feed *models.PreviousFeed) error {
+	err := dbClient.Upsert(database.ColFeedHistory, feed, &db.Filter{"url": feed.URL})
+	if err != nil {
+		return fmt.Errorf("error upserting previous feed: %v", err)
+	}
+	return nil
+}
+
+// followFeed moves the podcast to its new feed url when the feed was permanently redirected
+// to movedTo or announced an <itunes:new-feed-url>, once the new feed is verified to be the same show.
+// rssPod is the feed's content, nil if it is unchanged since the last update.
+// returns the podcast the feed belongs to, which differs if it was merged into another podcast
+func followFeed(dbClient db.Database, pod *protos.Podcast, movedTo string, rssPod *models.RSSPodcast) *protos.Podcast {
+	newURL := ""
+	// the content of a redirect was already fetched from the new url
+	if movedTo != "" && movedTo != pod.Rss && (rssPod == nil || sameShow(pod, rssPod)) {
+		newURL = movedTo
+	}
+	if rssPod != nil {
+		announced := strings.TrimSpace(rssPod.NewFeedURL)
+		if announced != "" && announced != pod.Rss && announced != movedTo {
+			newPod, err := fetchFeed(announced)
+			if err != nil {
+				log.Printf("followFeed() error fetching new feed url %v: %v\n", announced, err)
+			} else if sameShow(pod, newPod) {
+				newURL = announced
+			}
+		}
+	}
+	if newURL == "" {
+		return pod
+	}
+
+	moved, err := moveFeed(dbClient, pod, newURL)
+	if err != nil {
+		log.Printf("followFeed() error moving podcast %v to %v: %v\n", pod.Title, newURL, err)
+		return pod
+	}
+	return moved
+}
+
+// fetchFeed downloads & parses the feed at url
+func fetchFeed(url string) (*models.RSSPodcast, error) {
+	body, _, err := downloadRSS(url, &models.FetchState{})
+	if err != nil {
+		return nil, err
+	}
+	return parseFeed(body)
+}
+
+// sameShow verifies the feed is the podcast via its podcast:guid, or title if either has no guid
+func sameShow(pod *protos.Podcast, p *models.RSSPodcast) bool {
+	guid := strings.TrimSpace(p.GUID)
+	if pod.Guid != "" && guid != "" {
+		return pod.Guid == guid
+	}
+	return strings.EqualFold(strings.TrimSpace(pod.Title), strings.TrimSpace(p.Title))
+}
+
+// moveFeed points the podcast to its new feed url, keeping the old one as a previous feed.
+// if another podcast already uses the new url the podcast is merged into it, which is returned
+func moveFeed(dbClient db.Database, pod *protos.Podcast, newURL string) (*protos.Podcast, error) {
+	other, err := FindPodcastByFeed(dbClient, newURL)
+	if err == nil && other.Id.GetHex() != pod.Id.GetHex() {
+		return other, mergePodcasts(dbClient, other, pod)
+	}
+
+	log.Printf("moveFeed() podcast %v moved from %v to %v\n", pod.Title, pod.Rss, newURL)
+	err = UpsertPreviousFeed(dbClient, &models.PreviousFeed{URL: pod.Rss, PodcastID: pod.Id, MovedAt: time.Now()})
+	if err != nil {
+		return nil, err
+	}
+	pod.Rss = newURL
+	return pod, UpsertPodcast(dbClient, pod)
+}
+
+// mergePodcasts moves the episodes, user episodes, subscriptions & previous feeds of dup
+// onto keep then deletes dup. episodes keep already has are merged like duplicate episodes
+func mergePodcasts(dbClient db.Database, keep, dup *protos.Podcast) error {
+	log.Printf("mergePodcasts() merging podcast %v into %v: %v\n", dup.Id.GetHex(), keep.Id.GetHex(), dup.Title)
+
+	var episodes []*protos.Episode
+	err := dbClient.FindAll(database.ColEpisode, &episodes, &db.Filter{"podcastid": dup.Id}, nil)
+	if err != nil {
+		return fmt.Errorf("mergePodcasts() error finding episodes: %v", err)
+	}
+	for _, epi := range episodes {
+		moved := proto.Clone(epi).(*protos.Episode)
+		moved.PodcastID = keep.Id
+		existing, err := FindEpisodeByIdentity(dbClient, moved)
+		if err != nil {
+			return fmt.Errorf("mergePodcasts() error: %v", err)
+		}
+		if existing == nil {
+			err = UpsertEpisode(dbClient, moved)
+			if err != nil {
+				return fmt.Errorf("mergePodcasts() error: %v", err)
+			}
+			continue
+		}
+		err = repointEpisode(dbClient, epi, existing.Id)
+		if err != nil {
+			return fmt.Errorf("mergePodcasts() error: %v", err)
+		}
+		err = dbClient.Delete(database.ColEpisode, &db.Filter{"_id": epi.Id})
+		if err != nil {
+			return fmt.Errorf("mergePodcasts() error deleting episode: %v", err)
+		}
+	}
+
+	var userEpis []*protos.UserEpisode
+	err = dbClient.FindAll(database.ColUserEpisode, &userEpis, &db.Filter{"podcastid": dup.Id}, nil)
+	if err != nil {
+		return fmt.Errorf("mergePodcasts() error finding user episodes: %v", err)
+	}
+	for _, userEpi := range userEpis {
+		userEpi.PodcastID = keep.Id
+		err = dbClient.Upsert(database.ColUserEpisode, userEpi, &db.Filter{"_id": userEpi.Id})
+		if err != nil {
+			return fmt.Errorf("mergePodcasts() error upserting user episode: %v", err)
+		}
+	}
+
+	err = mergeSubscriptions(dbClient, keep, dup)
+	if err != nil {
+		return err
+	}
+
+	prevFeeds, err := FindPreviousFeeds(dbClient, dup.Id)
+	if err != nil {
+		return fmt.Errorf("mergePodcasts() error: %v", err)
+	}
+	prevFeeds = append(prevFeeds, &models.PreviousFeed{URL: dup.Rss, MovedAt: time.Now()})
+	for _, feed := range prevFeeds {
+		if feed.URL == keep.Rss {
+			continue
+		}
+		feed.PodcastID = keep.Id
+		err = UpsertPreviousFeed(dbClient, feed)
+		if err != nil {
+			return fmt.Errorf("mergePodcasts() error: %v", err)
+		}
+	}
+
+	// the fetch state & schedule are only kept for podcasts that have been fetched
+	if _, err := FindFetchState(dbClient, dup.Id); err == nil {
+		err = dbClient.Delete(database.ColFetchState, &db.Filter{"podcast_id": dup.Id})
+		if err != nil {
+			log.Println("mergePodcasts() error deleting fetch state:", err)
+		}
+	}
+	if _, err := FindFeedSchedule(dbClient, dup.Id); err == nil {
+		err = dbClient.Delete(database.ColFeedSchedule, &db.Filter{"podcastid": dup.Id})
+		if err != nil {
+			log.Println("mergePodcasts() error deleting feed schedule:", err)
+		}
+	}
+
+	err = dbClient.Delete(database.ColPodcast, &db.Filter{"_id": dup.Id})
+	if err != nil {
+		return fmt.Errorf("mergePodcasts() error deleting podcast: %v", err)
+	}
+	return nil
+}
+
+// mergeSubscriptions moves the subscriptions of dup onto keep,
+// combining the progress of users already subscribed to both
+func mergeSubscriptions(dbClient db.Database, keep, dup *protos.Podcast) error {
+	var subs []*protos.Subscription
+	err := dbClient.FindAll(database.ColSubscription, &subs, &db.Filter{"podcastid": dup.Id}, nil)
+	if err != nil {
+		return fmt.Errorf("mergeSubscriptions() error finding subscriptions: %v", err)
+	}
+	for _, sub := range subs {
+		var existing []*protos.Subscription
+		err = dbClient.FindAll(database.ColSubscription, &existing, &db.Filter{"userid": sub.UserID, "podcastid": keep.Id}, nil)
+		if err != nil {
+			return fmt.Errorf("mergeSubscriptions() error finding subscriptions: %v", err)
+		}
+		if len(existing) == 0 {
+			sub.PodcastID = keep.Id
+			err = dbClient.Upsert(database.ColSubscription, sub, &db.Filter{"_id": sub.Id})
+			if err != nil {
+				return fmt.Errorf("mergeSubscriptions() error upserting subscription: %v", err)
+			}
+			continue
+		}
+
+		merged := existing[0]
+		for _, id := range sub.InProgressIDs {
+			if !containsObjectID(merged.InProgressIDs, id) {
+				merged.InProgressIDs = append(merged.InProgressIDs, id)
+			}
+		}
+		err = dbClient.Upsert(database.ColSubscription, merged, &db.Filter{"_id": merged.Id})
+		if err != nil {
+			return fmt.Errorf("mergeSubscriptions() error upserting subscription: %v", err)
+		}
+		err = dbClient.Delete(database.ColSubscription, &db.Filter{"_id": sub.Id})
+		if err != nil {
+			return fmt.Errorf("mergeSubscriptions() error deleting subscription: %v", err)
+		}
+	}
+	return nil
+}
+
+func containsObjectID(ids []*protos.ObjectID, id *protos.ObjectID) bool {
+	for i := range ids {
+		if ids[i].GetHex() == id.GetHex() {
+			return true
+		}
+	}
+	return false
+}
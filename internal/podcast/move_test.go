@@ -0,0 +1,184 @@
+package podcast
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/stockpile/mock"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// createMovedFeedServer serves the test feed at "/new" and at "/old" announcing it moved to "/new"
+func createMovedFeedServer(t *testing.T) *httptest.Server {
+	feed, err := ioutil.ReadFile("./test/feed.xml")
+	if err != nil {
+		t.Fatalf("createMovedFeedServer() error reading test feed: %v", err)
+	}
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/new":
+			res.Write(feed)
+		case "/old":
+			announced := strings.Replace(string(feed), "<title>Go Time</title>",
+				"<title>Go Time</title>\n<itunes:new-feed-url>"+server.URL+"/new</itunes:new-feed-url>", 1)
+			res.Write([]byte(announced))
+		default:
+			res.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server
+}
+
+// createMoveMockDB creates the collections used when moving & merging podcasts
+func createMoveMockDB(t *testing.T) db.Database {
+	mockDB := mock.CreateDB()
+	insertOrFail(t, mockDB, database.ColEpisode, &protos.Episode{Id: protos.NewObjectID(), PodcastID: protos.NewObjectID()})
+	insertOrFail(t, mockDB, database.ColUserEpisode, &protos.UserEpisode{Id: protos.NewObjectID(), PodcastID: protos.NewObjectID()})
+	insertOrFail(t, mockDB, database.ColSubscription, &protos.Subscription{Id: protos.NewObjectID(), PodcastID: protos.NewObjectID()})
+	insertOrFail(t, mockDB, database.ColFeedHistory, &models.PreviousFeed{URL: "https://example.com/unrelated", PodcastID: protos.NewObjectID()})
+	return mockDB
+}
+
+func TestFindPodcastByFeed(t *testing.T) {
+	mockDB := createMoveMockDB(t)
+	pod := &protos.Podcast{Id: protos.NewObjectID(), Title: "Go Time", Rss: "https://example.com/current"}
+	insertOrFail(t, mockDB, database.ColPodcast, pod)
+	insertOrFail(t, mockDB, database.ColFeedHistory, &models.PreviousFeed{URL: "https://example.com/previous", PodcastID: pod.Id})
+
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{name: "current", url: "https://example.com/current"},
+		{name: "previous", url: "https://example.com/previous"},
+		{name: "unknown", url: "https://example.com/unknown", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FindPodcastByFeed(mockDB, tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FindPodcastByFeed() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.Id.GetHex() != pod.Id.GetHex() {
+				t.Errorf("FindPodcastByFeed() = %v, want %v", got, pod)
+			}
+			if DoesPodcastExist(mockDB, tt.url) == tt.wantErr {
+				t.Errorf("DoesPodcastExist() = %v, want %v", !tt.wantErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_updatePodcast_moved(t *testing.T) {
+	server := createFeedServer(t)
+	defer server.Close()
+	movedServer := createMovedFeedServer(t)
+	defer movedServer.Close()
+
+	tests := []struct {
+		name    string
+		pod     *protos.Podcast
+		wantRss string
+	}{
+		{
+			name:    "permanent_redirect",
+			pod:     &protos.Podcast{Id: protos.NewObjectID(), Title: "Go Time", Rss: server.URL + "/moved"},
+			wantRss: server.URL + "/feed",
+		},
+		{
+			name:    "temporary_redirect",
+			pod:     &protos.Podcast{Id: protos.NewObjectID(), Title: "Go Time", Rss: server.URL + "/temporary"},
+			wantRss: server.URL + "/temporary",
+		},
+		{
+			name:    "new_feed_url",
+			pod:     &protos.Podcast{Id: protos.NewObjectID(), Title: "Go Time", Rss: movedServer.URL + "/old"},
+			wantRss: movedServer.URL + "/new",
+		},
+		{
+			name:    "different_show",
+			pod:     &protos.Podcast{Id: protos.NewObjectID(), Title: "Not Go Time", Rss: server.URL + "/moved"},
+			wantRss: server.URL + "/moved",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := createMoveMockDB(t)
+			insertOrFail(t, mockDB, database.ColPodcast, tt.pod)
+			oldRss := tt.pod.Rss
+
+			if err := updatePodcast(mockDB, tt.pod); err != nil {
+				t.Fatalf("updatePodcast() error = %v", err)
+			}
+			got, err := FindPodcastByID(mockDB, tt.pod.Id)
+			if err != nil {
+				t.Fatalf("updatePodcast() error finding podcast: %v", err)
+			}
+			if got.Rss != tt.wantRss {
+				t.Errorf("updatePodcast() rss = %v, want %v", got.Rss, tt.wantRss)
+			}
+			if !DoesPodcastExist(mockDB, oldRss) {
+				t.Errorf("updatePodcast() old feed %v no longer belongs to the podcast", oldRss)
+			}
+		})
+	}
+}
+
+func Test_updatePodcast_merge(t *testing.T) {
+	server := createFeedServer(t)
+	defer server.Close()
+	mockDB := createMoveMockDB(t)
+
+	// keep already uses the feed that dup is redirected to, both have the same episode
+	keep := &protos.Podcast{Id: protos.ObjectIDFromHex("keep"), Title: "Go Time", Rss: server.URL + "/feed"}
+	dup := &protos.Podcast{Id: protos.ObjectIDFromHex("dup"), Title: "Go Time", Rss: server.URL + "/moved"}
+	keepEpi := &protos.Episode{Id: protos.ObjectIDFromHex("keep_epi"), PodcastID: keep.Id, Guid: "changelog.com/2/1057"}
+	dupEpi := &protos.Episode{Id: protos.ObjectIDFromHex("dup_epi"), PodcastID: dup.Id, Guid: "changelog.com/2/1057"}
+	dupOnlyEpi := &protos.Episode{Id: protos.ObjectIDFromHex("dup_only_epi"), PodcastID: dup.Id, Guid: "only-in-dup"}
+	insertOrFail(t, mockDB, database.ColPodcast, keep)
+	insertOrFail(t, mockDB, database.ColPodcast, dup)
+	insertOrFail(t, mockDB, database.ColEpisode, keepEpi)
+	insertOrFail(t, mockDB, database.ColEpisode, dupEpi)
+	insertOrFail(t, mockDB, database.ColEpisode, dupOnlyEpi)
+	userID := protos.ObjectIDFromHex("user")
+	insertOrFail(t, mockDB, database.ColUserEpisode, &protos.UserEpisode{Id: protos.ObjectIDFromHex("ue"), UserID: userID, PodcastID: dup.Id, EpisodeID: dupEpi.Id, Offset: 42})
+	insertOrFail(t, mockDB, database.ColSubscription, &protos.Subscription{Id: protos.ObjectIDFromHex("sub"), UserID: userID, PodcastID: dup.Id, InProgressIDs: []*protos.ObjectID{dupEpi.Id}})
+
+	if err := updatePodcast(mockDB, dup); err != nil {
+		t.Fatalf("updatePodcast() error = %v", err)
+	}
+
+	if _, err := FindPodcastByID(mockDB, dup.Id); err == nil {
+		t.Errorf("updatePodcast() duplicate podcast was not deleted")
+	}
+	got, err := FindPodcastByFeed(mockDB, server.URL+"/moved")
+	if err != nil || got.Id.GetHex() != keep.Id.GetHex() {
+		t.Errorf("updatePodcast() previous feed belongs to %v, error = %v", got, err)
+	}
+	moved, err := FindEpisodeByID(mockDB, dupOnlyEpi.Id)
+	if err != nil || moved.PodcastID.GetHex() != keep.Id.GetHex() {
+		t.Errorf("updatePodcast() episode only in duplicate = %v, error = %v", moved, err)
+	}
+	if _, err := FindEpisodeByID(mockDB, dupEpi.Id); err == nil {
+		t.Errorf("updatePodcast() duplicate episode was not deleted")
+	}
+
+	var userEpi protos.UserEpisode
+	err = mockDB.FindOne(database.ColUserEpisode, &userEpi, &db.Filter{"userid": userID}, nil)
+	if err != nil || userEpi.EpisodeID.GetHex() != keepEpi.Id.GetHex() || userEpi.PodcastID.GetHex() != keep.Id.GetHex() || userEpi.Offset != 42 {
+		t.Errorf("updatePodcast() user episode = %v, error = %v", userEpi.String(), err)
+	}
+	var sub protos.Subscription
+	err = mockDB.FindOne(database.ColSubscription, &sub, &db.Filter{"userid": userID}, nil)
+	if err != nil || sub.PodcastID.GetHex() != keep.Id.GetHex() || len(sub.InProgressIDs) != 1 || sub.InProgressIDs[0].GetHex() != keepEpi.Id.GetHex() {
+		t.Errorf("updatePodcast() subscription = %v, error = %v", sub.String(), err)
+	}
+}
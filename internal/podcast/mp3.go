@@ -0,0 +1,151 @@
+package podcast
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/tcolgate/mp3"
+)
+
+// findFrame returns the offset of the first frame in data, a frame only counts if the frame
+// after it starts right at its end, so sync bytes within other data are skipped
+func findFrame(data []byte) int {
+	for i := 0; i+4 <= len(data); {
+		var frame, next mp3.Frame
+		skipped := 0
+		dec := mp3.NewDecoder(bytes.NewReader(data[i:]))
+		if dec.Decode(&frame, &skipped) != nil && skipped+4 > len(data[i:]) {
+			return -1
+		}
+		start := i + skipped
+		end := start + frame.Size()
+		dec.Decode(&next, &skipped)
+		if skipped == 0 || end+4 > len(data) {
+			return start
+		}
+		i = start + 1
+	}
+	return -1
+}
+
+// decodeFrame decodes the header & side info of the frame at the start of data, the rest of the frame may be cut off
+func decodeFrame(data []byte) (*mp3.Frame, error) {
+	frame := &mp3.Frame{}
+	skipped := 0
+	// an error after the header was found only means the frame is cut off
+	mp3.NewDecoder(bytes.NewReader(data)).Decode(frame, &skipped)
+	if skipped > 0 || len(data) < 4 {
+		return nil, errors.New("decodeFrame() error: no MPEG audio frame at the start of data")
+	}
+	return frame, nil
+}
+
+// probeMP3 finds the duration in millis of the MP3 enclosure, skipping its ID3 tag
+func probeMP3(e *enclosure) (int64, error) {
+	tag := id3Size(e.head)
+	data, err := e.read(tag, probeBytes)
+	if err != nil {
+		return 0, fmt.Errorf("probeMP3() error: %v", err)
+	}
+	audioLen := int64(-1)
+	if e.size >= 0 {
+		audioLen = e.size - tag
+	}
+	return mp3Duration(data, audioLen)
+}
+
+// mp3Duration returns the duration in millis of the MP3 audio starting with data, audioLen is the
+// size of all the audio or -1 if unknown. The frame count of a Xing/Info or VBRI header is used if
+// there is one, otherwise the frames in data are scanned and their bitrate is applied to audioLen
+func mp3Duration(data []byte, audioLen int64) (int64, error) {
+	start := findFrame(data)
+	if start < 0 {
+		return 0, errors.New("mp3Duration() error: no MPEG audio frame found")
+	}
+	first, err := decodeFrame(data[start:])
+	if err != nil {
+		return 0, fmt.Errorf("mp3Duration() error: %v", err)
+	}
+	if samples, ok := xingSamples(data[start:], first); ok {
+		return samplesToMillis(samples, first), nil
+	}
+	if samples, ok := vbriSamples(data[start:], first); ok {
+		return samplesToMillis(samples, first), nil
+	}
+
+	var seconds float64
+	var scanned int64
+	var frame mp3.Frame
+	skipped := 0
+	dec := mp3.NewDecoder(bytes.NewReader(data[start:]))
+	for dec.Decode(&frame, &skipped) == nil {
+		seconds += frame.Duration().Seconds()
+		scanned += int64(skipped + frame.Size())
+	}
+	complete := audioLen >= 0 && int64(len(data)) >= audioLen
+	return scannedDuration(seconds, scanned, audioLen-int64(start), complete)
+}
+
+// samplesToMillis converts a number of samples at the sample rate of the frame to millis
+func samplesToMillis(samples int64, frame *mp3.Frame) int64 {
+	return samples * 1000 / int64(frame.Header().SampleRate())
+}
+
+// flags of the Xing/Info header
+const (
+	xingFrames  = 0x1
+	xingBytes   = 0x2
+	xingTOC     = 0x4
+	xingQuality = 0x8
+)
+
+// xingSamples returns the number of samples from the Xing/Info header in the first frame, written by
+// LAME & most encoders. The encoder delay & padding of a LAME header are not counted
+func xingSamples(frame []byte, h *mp3.Frame) (int64, bool) {
+	sideInfo, err := h.SideInfoLength()
+	if h.Header().Layer() != mp3.Layer3 || err != nil {
+		return 0, false
+	}
+	pos := 4 + sideInfo
+	if len(frame) < pos+12 {
+		return 0, false
+	}
+	tag := string(frame[pos : pos+4])
+	flags := binary.BigEndian.Uint32(frame[pos+4:])
+	if (tag != "Xing" && tag != "Info") || flags&xingFrames == 0 {
+		return 0, false
+	}
+	samples := int64(binary.BigEndian.Uint32(frame[pos+8:])) * int64(h.Samples())
+
+	pos += 12
+	if flags&xingBytes != 0 {
+		pos += 4
+	}
+	if flags&xingTOC != 0 {
+		pos += 100
+	}
+	if flags&xingQuality != 0 {
+		pos += 4
+	}
+	// LAME header, ffmpeg writes the same layout
+	if len(frame) >= pos+24 && (bytes.HasPrefix(frame[pos:], []byte("LAME")) || bytes.HasPrefix(frame[pos:], []byte("Lavf")) || bytes.HasPrefix(frame[pos:], []byte("Lavc"))) {
+		delay := int64(frame[pos+21])<<4 | int64(frame[pos+22]>>4)
+		padding := int64(frame[pos+22]&0x0f)<<8 | int64(frame[pos+23])
+		if delay+padding < samples {
+			samples -= delay + padding
+		}
+	}
+	return samples, true
+}
+
+// vbriSamples returns the number of samples from the VBRI header in the first frame, written by the Fraunhofer encoder
+func vbriSamples(frame []byte, h *mp3.Frame) (int64, bool) {
+	// VBRI always follows 32 bytes after the header
+	pos := 4 + 32
+	if h.Header().Layer() != mp3.Layer3 || len(frame) < pos+18 || string(frame[pos:pos+4]) != "VBRI" {
+		return 0, false
+	}
+	return int64(binary.BigEndian.Uint32(frame[pos+14:])) * int64(h.Samples()), true
+}
@@ -0,0 +1,80 @@
+package podcast
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+// headers of MPEG-1 layer III 128kbps 44.1kHz & MPEG-2 layer III 80kbps 22.05kHz frames
+var (
+	mpeg1Header = []byte{0xff, 0xfb, 0x90, 0x00}
+	mpeg2Header = []byte{0xff, 0xf3, 0x90, 0x00}
+)
+
+// mp3Frames creates n silent frames with the header
+func mp3Frames(header []byte, n int) []byte {
+	h, _ := decodeFrame(header)
+	frame := make([]byte, h.Size())
+	copy(frame, header)
+	return bytes.Repeat(frame, n)
+}
+
+// xingFrame creates an MPEG-1 Info frame with a LAME header
+func xingFrame(frames uint32, delay, padding int) []byte {
+	frame := mp3Frames(mpeg1Header, 1)
+	copy(frame[36:], "Xing")
+	binary.BigEndian.PutUint32(frame[40:], xingFrames)
+	binary.BigEndian.PutUint32(frame[44:], frames)
+	copy(frame[48:], "LAME3.100")
+	frame[48+21] = byte(delay >> 4)
+	frame[48+22] = byte(delay<<4) | byte(padding>>8)
+	frame[48+23] = byte(padding)
+	return frame
+}
+
+// vbriFrame creates an MPEG-1 frame with a VBRI header
+func vbriFrame(frames uint32) []byte {
+	frame := mp3Frames(mpeg1Header, 1)
+	copy(frame[36:], "VBRI")
+	binary.BigEndian.PutUint32(frame[50:], frames)
+	return frame
+}
+
+// id3Tag creates an ID3v2 tag holding size bytes of frames
+func id3Tag(size int) []byte {
+	tag := []byte{'I', 'D', '3', 4, 0, 0, byte(size >> 21 & 0x7f), byte(size >> 14 & 0x7f), byte(size >> 7 & 0x7f), byte(size & 0x7f)}
+	return append(tag, make([]byte, size)...)
+}
+
+func Test_mp3Duration(t *testing.T) {
+	cbr := mp3Frames(mpeg1Header, 100)
+	tests := []struct {
+		name     string
+		data     []byte
+		audioLen int64
+		want     int64
+		wantErr  bool
+	}{
+		{name: "scanned", data: cbr, audioLen: int64(len(cbr)), want: 2612},
+		{name: "unknown_length", data: cbr, audioLen: -1, wantErr: true},
+		{name: "estimated", data: cbr[:417*10], audioLen: 417 * 1000, want: 26122},
+		{name: "mpeg2", data: mp3Frames(mpeg2Header, 100), audioLen: 261 * 100, want: 2612},
+		{name: "xing", data: append(xingFrame(10000, 576, 600), cbr...), audioLen: 417 * 10001, want: 261197},
+		{name: "vbri", data: append(vbriFrame(5000), cbr...), audioLen: 417 * 5001, want: 130612},
+		{name: "false_sync", data: append(append([]byte{0, 0xff, 0xfb, 0x90, 0, 0}, cbr...), 0), audioLen: int64(len(cbr) + 7), want: 2612},
+		{name: "no_frames", data: []byte(strings.Repeat("not audio", 100)), audioLen: 900, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mp3Duration(tt.data, tt.audioLen)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("mp3Duration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("mp3Duration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
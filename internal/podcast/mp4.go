@@ -0,0 +1,170 @@
+package podcast
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// maxMP4Boxes bounds the top level boxes walked looking for the moov box
+const maxMP4Boxes = 64
+
+// probeMP4 finds the duration in millis of the MP4/M4A enclosure from the mvhd box within its moov box
+func probeMP4(e *enclosure) (int64, error) {
+	offset, length, err := findMoov(e)
+	if err != nil {
+		return 0, fmt.Errorf("probeMP4() error: %v", err)
+	}
+	if length <= 0 || length > probeBytes {
+		// mvhd is the first box of moov
+		length = probeBytes
+	}
+	moov, err := e.read(offset, length)
+	if err != nil {
+		return 0, fmt.Errorf("probeMP4() error reading moov box: %v", err)
+	}
+	return mvhdDuration(moov)
+}
+
+// findMoov returns the offset & size of the moov box's content, the size is 0 or less if unknown.
+// The moov box is either before or after the media data, so the top level boxes are walked
+// requesting only their headers until it's found
+func findMoov(e *enclosure) (int64, int64, error) {
+	var offset int64
+	for i := 0; i < maxMP4Boxes; i++ {
+		header, err := e.read(offset, 16)
+		if err != nil || len(header) < 8 {
+			break
+		}
+		size, boxType, headerLen := mp4Box(header)
+		if boxType == "moov" {
+			if size == 0 {
+				// the last box extends to the end of the file
+				size = e.size - offset
+			}
+			return offset + headerLen, size - headerLen, nil
+		}
+		if size < headerLen {
+			break
+		}
+		offset += size
+	}
+	return 0, 0, errors.New("findMoov() error: no moov box found")
+}
+
+// mp4Box parses the box header at the start of b, returns the size of the box including its header,
+// its type and the size of its header
+func mp4Box(b []byte) (int64, string, int64) {
+	size := int64(binary.BigEndian.Uint32(b))
+	boxType := string(b[4:8])
+	if size == 1 && len(b) >= 16 {
+		// the size follows the type as 64 bits
+		return int64(binary.BigEndian.Uint64(b[8:])), boxType, 16
+	}
+	return size, boxType, 8
+}
+
+// findMP4Box returns the content of the box at the path of nested box types within data, nil if there is none.
+// boxes cut off at the end of data are returned truncated
+func findMP4Box(data []byte, path ...string) []byte {
+	for pos := 0; pos+8 <= len(data); {
+		size, boxType, headerLen := mp4Box(data[pos:])
+		if size != 0 && size < headerLen {
+			return nil
+		}
+		end := len(data)
+		if size != 0 && int64(pos)+size < int64(len(data)) {
+			end = pos + int(size)
+		}
+		if boxType == path[0] {
+			content := data[pos+int(headerLen) : end]
+			if len(path) == 1 {
+				return content
+			}
+			return findMP4Box(content, path[1:]...)
+		}
+		pos = end
+	}
+	return nil
+}
+
+// mvhdDuration returns the duration in millis from the mvhd box within the moov box's content
+func mvhdDuration(moov []byte) (int64, error) {
+	mvhd := findMP4Box(moov, "mvhd")
+	var timescale, duration uint64
+	switch {
+	case mvhd == nil:
+		return 0, errors.New("mvhdDuration() error: no mvhd box found")
+	case len(mvhd) >= 20 && mvhd[0] == 0:
+		timescale = uint64(binary.BigEndian.Uint32(mvhd[12:]))
+		duration = uint64(binary.BigEndian.Uint32(mvhd[16:]))
+	case len(mvhd) >= 32 && mvhd[0] == 1:
+		// 64 bit creation & modification times and duration
+		timescale = uint64(binary.BigEndian.Uint32(mvhd[20:]))
+		duration = binary.BigEndian.Uint64(mvhd[24:])
+	default:
+		return 0, errors.New("mvhdDuration() error: invalid mvhd box")
+	}
+	if timescale == 0 {
+		return 0, errors.New("mvhdDuration() error: mvhd timescale is 0")
+	}
+	return int64(duration * 1000 / timescale), nil
+}
+
+// mp4Chapters extracts the Nero chapters from the chpl box within the moov box of the MP4/M4A enclosure
+func mp4Chapters(e *enclosure) ([]*protos.Chapter, error) {
+	offset, length, err := findMoov(e)
+	if err != nil {
+		return nil, fmt.Errorf("mp4Chapters() error: %v", err)
+	}
+	if length <= 0 || length > maxChapterTag {
+		length = maxChapterTag
+	}
+	moov, err := e.read(offset, length)
+	if err != nil {
+		return nil, fmt.Errorf("mp4Chapters() error reading moov box: %v", err)
+	}
+	chpl := findMP4Box(moov, "udta", "chpl")
+	if chpl == nil {
+		return nil, nil
+	}
+	// the last chapter ends with the episode
+	dur, _ := mvhdDuration(moov)
+	return parseChpl(chpl, dur), nil
+}
+
+// parseChpl parses the content of a chpl box, each chapter ends where the next starts
+func parseChpl(chpl []byte, durationMillis int64) []*protos.Chapter {
+	pos := 4
+	if len(chpl) > 0 && chpl[0] == 1 {
+		pos += 4
+	}
+	if pos >= len(chpl) {
+		return nil
+	}
+	count := int(chpl[pos])
+	pos++
+
+	var chapters []*protos.Chapter
+	for i := 0; i < count && pos+9 <= len(chpl); i++ {
+		// start in 100 nanosecond units, followed by the length of the utf-8 title
+		start := int64(binary.BigEndian.Uint64(chpl[pos:]) / 10000)
+		titleLen := int(chpl[pos+8])
+		pos += 9
+		if pos+titleLen > len(chpl) {
+			break
+		}
+		chapters = append(chapters, &protos.Chapter{StartMillis: start, Title: string(chpl[pos : pos+titleLen])})
+		pos += titleLen
+	}
+	for i, c := range chapters {
+		if i+1 < len(chapters) {
+			c.EndMillis = chapters[i+1].StartMillis
+		} else {
+			c.EndMillis = durationMillis
+		}
+	}
+	return chapters
+}
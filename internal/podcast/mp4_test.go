@@ -0,0 +1,121 @@
+package podcast
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// createMP4Box creates a box of the type holding the content
+func createMP4Box(boxType string, content []byte) []byte {
+	box := make([]byte, 8, 8+len(content))
+	binary.BigEndian.PutUint32(box, uint32(8+len(content)))
+	copy(box[4:], boxType)
+	return append(box, content...)
+}
+
+// mvhdBox creates an mvhd box of the version with the timescale & duration
+func mvhdBox(version byte, timescale uint32, duration uint64) []byte {
+	if version == 1 {
+		content := make([]byte, 112)
+		content[0] = 1
+		binary.BigEndian.PutUint32(content[20:], timescale)
+		binary.BigEndian.PutUint64(content[24:], duration)
+		return createMP4Box("mvhd", content)
+	}
+	content := make([]byte, 100)
+	binary.BigEndian.PutUint32(content[12:], timescale)
+	binary.BigEndian.PutUint32(content[16:], uint32(duration))
+	return createMP4Box("mvhd", content)
+}
+
+// m4aFile creates an M4A file with mdatSize bytes of media data, its moov box is either before or after the media data
+func m4aFile(mvhd []byte, mdatSize int, moovAtEnd bool) []byte {
+	ftyp := createMP4Box("ftyp", []byte("M4A \x00\x00\x00\x00isomM4A "))
+	moov := createMP4Box("moov", append(createMP4Box("iods", make([]byte, 16)), mvhd...))
+	mdat := createMP4Box("mdat", make([]byte, mdatSize))
+	if moovAtEnd {
+		return append(append(ftyp, mdat...), moov...)
+	}
+	return append(append(ftyp, moov...), mdat...)
+}
+
+// chplBox creates a udta box holding a chpl box of the version with the chapters' starts & titles
+func chplBox(version byte, chapters ...*protos.Chapter) []byte {
+	content := []byte{version, 0, 0, 0}
+	if version == 1 {
+		content = append(content, 0, 0, 0, 0)
+	}
+	content = append(content, byte(len(chapters)))
+	for _, c := range chapters {
+		start := make([]byte, 8)
+		binary.BigEndian.PutUint64(start, uint64(c.StartMillis)*10000)
+		content = append(append(append(content, start...), byte(len(c.Title))), c.Title...)
+	}
+	return createMP4Box("udta", createMP4Box("chpl", content))
+}
+
+func Test_mvhdDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		moov    []byte
+		want    int64
+		wantErr bool
+	}{
+		{name: "version_0", moov: mvhdBox(0, 44100, 158760000), want: 3600000},
+		{name: "version_1", moov: mvhdBox(1, 1000, 5400000), want: 5400000},
+		{name: "after_other_box", moov: append(createMP4Box("iods", make([]byte, 16)), mvhdBox(0, 600, 1500)...), want: 2500},
+		{name: "zero_timescale", moov: mvhdBox(0, 0, 1500), wantErr: true},
+		{name: "no_mvhd", moov: createMP4Box("trak", make([]byte, 32)), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mvhdDuration(tt.moov)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("mvhdDuration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("mvhdDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseChpl(t *testing.T) {
+	chapters := []*protos.Chapter{{StartMillis: 0, Title: "Intro"}, {StartMillis: 90500, Title: "Interview"}}
+	tests := []struct {
+		name string
+		chpl []byte
+		want []*protos.Chapter
+	}{
+		{
+			name: "version_0",
+			chpl: findMP4Box(chplBox(0, chapters...), "udta", "chpl"),
+			want: []*protos.Chapter{{StartMillis: 0, EndMillis: 90500, Title: "Intro"}, {StartMillis: 90500, EndMillis: 600000, Title: "Interview"}},
+		},
+		{
+			name: "version_1",
+			chpl: findMP4Box(chplBox(1, chapters...), "udta", "chpl"),
+			want: []*protos.Chapter{{StartMillis: 0, EndMillis: 90500, Title: "Intro"}, {StartMillis: 90500, EndMillis: 600000, Title: "Interview"}},
+		},
+		{
+			name: "truncated",
+			chpl: findMP4Box(chplBox(0, chapters...), "udta", "chpl")[:20],
+			want: []*protos.Chapter{{StartMillis: 0, EndMillis: 600000, Title: "Intro"}},
+		},
+		{
+			name: "empty",
+			chpl: []byte{0, 0},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseChpl(tt.chpl, 600000); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseChpl() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
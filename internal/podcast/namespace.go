@@ -0,0 +1,169 @@
+package podcast
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// convertPodcastNamespace copies the podcasting 2.0 tags of the rss podcast onto pod
+func convertPodcastNamespace(pod *protos.Podcast, p *models.RSSPodcast) {
+	pod.Guid = strings.TrimSpace(p.GUID)
+	pod.Locked = strings.EqualFold(strings.TrimSpace(p.Locked.Locked), "yes")
+	pod.LockedOwner = p.Locked.Owner
+	pod.Persons = convertPersons(p.Persons)
+	pod.Funding = convertFunding(p.Funding)
+	pod.Location = convertLocation(p.Location)
+	pod.Value = convertValue(p.Value)
+}
+
+// convertEpisodeNamespace copies the podcasting 2.0 tags of the rss episode onto epi
+func convertEpisodeNamespace(epi *protos.Episode, e *models.RSSEpisode) {
+	if e.PodSeason != nil {
+		epi.SeasonName = strings.TrimSpace(e.PodSeason.Name)
+		if epi.Season == 0 {
+			epi.Season = int32(e.PodSeason.Number)
+		}
+	}
+	epi.Transcripts = convertTranscripts(e.Transcripts)
+	if e.Chapters != nil {
+		epi.Chapters = &protos.Chapters{Url: e.Chapters.URL, Type: e.Chapters.Type}
+	}
+	epi.Persons = convertPersons(e.Persons)
+	epi.Soundbites = convertSoundbites(e.Soundbites)
+	epi.Location = convertLocation(e.Location)
+	epi.Value = convertValue(e.Value)
+	epi.AlternateEnclosures = convertAlternateEnclosures(e.AlternateEnclosures)
+}
+
+func convertPersons(persons []models.Person) []*protos.Person {
+	if len(persons) == 0 {
+		return nil
+	}
+	protoPersons := make([]*protos.Person, len(persons))
+	for i, p := range persons {
+		protoPersons[i] = &protos.Person{
+			Name:  strings.TrimSpace(p.Name),
+			Role:  p.Role,
+			Group: p.Group,
+			Img:   p.Img,
+			Href:  p.HREF,
+		}
+	}
+	return protoPersons
+}
+
+func convertFunding(funding []models.Funding) []*protos.Funding {
+	if len(funding) == 0 {
+		return nil
+	}
+	protoFunding := make([]*protos.Funding, len(funding))
+	for i, f := range funding {
+		protoFunding[i] = &protos.Funding{Url: f.URL, Text: strings.TrimSpace(f.Text)}
+	}
+	return protoFunding
+}
+
+func convertLocation(l *models.Location) *protos.Location {
+	if l == nil {
+		return nil
+	}
+	return &protos.Location{Name: strings.TrimSpace(l.Name), Geo: l.Geo, Osm: l.OSM}
+}
+
+func convertValue(v *models.Value) *protos.Value {
+	if v == nil {
+		return nil
+	}
+	recipients := make([]*protos.ValueRecipient, len(v.Recipients))
+	for i, r := range v.Recipients {
+		split, _ := strconv.Atoi(strings.TrimSpace(r.Split))
+		recipients[i] = &protos.ValueRecipient{
+			Name:        r.Name,
+			CustomKey:   r.CustomKey,
+			CustomValue: r.CustomValue,
+			Type:        r.Type,
+			Address:     r.Address,
+			Split:       int32(split),
+			Fee:         parseBool(r.Fee),
+		}
+	}
+	return &protos.Value{
+		Type:       v.Type,
+		Method:     v.Method,
+		Suggested:  v.Suggested,
+		Recipients: recipients,
+	}
+}
+
+func convertTranscripts(transcripts []models.Transcript) []*protos.Transcript {
+	if len(transcripts) == 0 {
+		return nil
+	}
+	protoTranscripts := make([]*protos.Transcript, len(transcripts))
+	for i, t := range transcripts {
+		protoTranscripts[i] = &protos.Transcript{Url: t.URL, Type: t.Type, Language: t.Language, Rel: t.Rel}
+	}
+	return protoTranscripts
+}
+
+func convertSoundbites(soundbites []models.Soundbite) []*protos.Soundbite {
+	if len(soundbites) == 0 {
+		return nil
+	}
+	protoSoundbites := make([]*protos.Soundbite, len(soundbites))
+	for i, s := range soundbites {
+		protoSoundbites[i] = &protos.Soundbite{
+			StartMillis:    parseSecondsToMillis(s.StartTime),
+			DurationMillis: parseSecondsToMillis(s.Duration),
+			Title:          strings.TrimSpace(s.Title),
+		}
+	}
+	return protoSoundbites
+}
+
+func convertAlternateEnclosures(enclosures []models.AlternateEnclosure) []*protos.AlternateEnclosure {
+	if len(enclosures) == 0 {
+		return nil
+	}
+	protoEnclosures := make([]*protos.AlternateEnclosure, len(enclosures))
+	for i, e := range enclosures {
+		length, _ := strconv.ParseInt(strings.TrimSpace(e.Length), 10, 64)
+		bitrate, _ := strconv.ParseFloat(strings.TrimSpace(e.Bitrate), 64)
+		height, _ := strconv.Atoi(strings.TrimSpace(e.Height))
+		sources := make([]string, len(e.Sources))
+		for s := range e.Sources {
+			sources[s] = e.Sources[s].URI
+		}
+		protoEnclosures[i] = &protos.AlternateEnclosure{
+			Type:    e.Type,
+			Length:  length,
+			Bitrate: int64(bitrate),
+			Height:  int32(height),
+			Lang:    e.Lang,
+			Title:   e.Title,
+			Rel:     e.Rel,
+			Codecs:  e.Codecs,
+			Default: parseBool(e.Default),
+			Sources: sources,
+		}
+	}
+	return protoEnclosures
+}
+
+// parseSecondsToMillis parses decimal seconds into millis, returns 0 if invalid
+func parseSecondsToMillis(s string) int64 {
+	sec, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0
+	}
+	return int64(sec * 1000)
+}
+
+// parseBool parses the namespace's boolean attributes, which may be true/false or yes/no
+func parseBool(s string) bool {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return s == "true" || s == "yes"
+}
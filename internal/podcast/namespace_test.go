@@ -0,0 +1,107 @@
+package podcast
+
+import (
+	"os"
+	"testing"
+
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// parseNamespaceFeed parses the podcasting 2.0 test feed
+func parseNamespaceFeed(t *testing.T) *models.RSSPodcast {
+	rssFile, err := os.Open("./test/podcasting2_feed.xml")
+	if err != nil {
+		t.Fatalf("parseNamespaceFeed() error opening test file: %v", err)
+	}
+	defer rssFile.Close()
+	rssPod, err := parseRSS(rssFile)
+	if err != nil {
+		t.Fatalf("parseNamespaceFeed() error parsing test file: %v", err)
+	}
+	return rssPod
+}
+
+func Test_convertPodcastNamespace(t *testing.T) {
+	rssPod := parseNamespaceFeed(t)
+	tests := []struct {
+		name string
+		p    *models.RSSPodcast
+		want *protos.Podcast
+	}{
+		{
+			name: "podcasting2",
+			p:    rssPod,
+			want: &protos.Podcast{
+				Guid:        "ead4c236-bf58-58c6-a2c6-a6b28d128cb6",
+				Locked:      true,
+				LockedOwner: "podcastowner@example.com",
+				Persons:     []*protos.Person{{Name: "Alice Brown", Role: "host", Img: "https://example.com/images/alice.jpg", Href: "https://example.com/alice"}},
+				Funding:     []*protos.Funding{{Url: "https://example.com/donate", Text: "Support the show!"}},
+				Location:    &protos.Location{Name: "Austin, TX", Geo: "geo:30.2672,97.7431", Osm: "R113314"},
+				Value: &protos.Value{Type: "lightning", Method: "keysend", Suggested: "0.00000005000", Recipients: []*protos.ValueRecipient{
+					{Name: "Alice (Podcaster)", Type: "node", Address: "02d5c1bf8b940dc9cadca86d1b0a3c37fbe39cee4c7e839e33bef9174531d27f52", Split: 90},
+					{Name: "Hosting Provider", Type: "node", Address: "03ae9f91a0cb8ff43840e3c322c4c61f019d8c1c3cea15a25cfc425ac605e61a4a", Split: 10, Fee: true},
+				}},
+			},
+		},
+		{
+			name: "no_namespace",
+			p:    &models.RSSPodcast{},
+			want: &protos.Podcast{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := &protos.Podcast{}
+			convertPodcastNamespace(got, tt.p)
+			if got.String() != tt.want.String() {
+				t.Errorf("convertPodcastNamespace() = \n\t%v, want \n\t%v", got.String(), tt.want.String())
+			}
+		})
+	}
+}
+
+func Test_convertEpisodeNamespace(t *testing.T) {
+	rssPod := parseNamespaceFeed(t)
+	tests := []struct {
+		name string
+		e    *models.RSSEpisode
+		want *protos.Episode
+	}{
+		{
+			name: "podcasting2",
+			e:    &rssPod.RSSEpisodes[0],
+			want: &protos.Episode{
+				SeasonName: "Podcasting 2.0",
+				Transcripts: []*protos.Transcript{
+					{Url: "https://example.com/episode3/transcript.vtt", Type: "text/vtt", Language: "en", Rel: "captions"},
+					{Url: "https://example.com/episode3/transcript.json", Type: "application/json"},
+				},
+				Chapters:   &protos.Chapters{Url: "https://example.com/episode3/chapters.json", Type: "application/json+chapters"},
+				Persons:    []*protos.Person{{Name: "Bob Smith", Role: "guest", Href: "https://example.com/bob"}},
+				Soundbites: []*protos.Soundbite{{StartMillis: 73000, DurationMillis: 60500, Title: "Why the Podcast Namespace Matters"}},
+				Location:   &protos.Location{Name: "Kansas", Geo: "geo:39.7837304,-100.445882"},
+				AlternateEnclosures: []*protos.AlternateEnclosure{{
+					Type: "audio/opus", Length: 32400000, Bitrate: 96000, Title: "High quality", Default: true,
+					Sources: []string{"https://example.com/file-03.opus", "ipfs://QmdwGqd3d2gFPGeJNLLCshdiPert45fMu84552Y4XHTy4y"},
+				}},
+				Season: 3,
+			},
+		},
+		{
+			name: "no_namespace",
+			e:    &models.RSSEpisode{},
+			want: &protos.Episode{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := &protos.Episode{}
+			convertEpisodeNamespace(got, tt.e)
+			if got.String() != tt.want.String() {
+				t.Errorf("convertEpisodeNamespace() = \n\t%v, want \n\t%v", got.String(), tt.want.String())
+			}
+		})
+	}
+}
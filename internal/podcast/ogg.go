@@ -0,0 +1,76 @@
+package podcast
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// opusGranuleRate is the rate of Opus granule positions, whatever the input's sample rate
+const opusGranuleRate = 48000
+
+// probeOgg finds the duration in millis of the Ogg Opus or Vorbis enclosure from the granule position
+// of its last page, which is requested from the end of the enclosure
+func probeOgg(e *enclosure) (int64, error) {
+	_, serial, headerLen, ok := parseOggPage(e.head)
+	if !ok {
+		return 0, errors.New("probeOgg() error: invalid first page")
+	}
+	var rate, preSkip int64
+	packet := e.head[headerLen:]
+	switch {
+	case bytes.HasPrefix(packet, []byte("OpusHead")) && len(packet) >= 12:
+		rate = opusGranuleRate
+		preSkip = int64(binary.LittleEndian.Uint16(packet[10:]))
+	case bytes.HasPrefix(packet, []byte("\x01vorbis")) && len(packet) >= 16:
+		rate = int64(binary.LittleEndian.Uint32(packet[12:]))
+	default:
+		return 0, errors.New("probeOgg() error: unsupported codec, only opus & vorbis are supported")
+	}
+	if rate == 0 {
+		return 0, errors.New("probeOgg() error: sample rate is 0")
+	}
+	if e.size < 0 {
+		return 0, errors.New("probeOgg() error: size of the enclosure is unknown")
+	}
+
+	start := e.size - probeBytes
+	if start < 0 {
+		start = 0
+	}
+	tail, err := e.read(start, e.size-start)
+	if err != nil {
+		return 0, fmt.Errorf("probeOgg() error reading last page: %v", err)
+	}
+	granule := lastGranule(tail, serial)
+	if granule < preSkip {
+		return 0, errors.New("probeOgg() error: no granule position found")
+	}
+	return (granule - preSkip) * 1000 / rate, nil
+}
+
+// parseOggPage parses the page header at the start of b,
+// returns the page's granule position, serial number and the size of the header
+func parseOggPage(b []byte) (int64, uint32, int, bool) {
+	if len(b) < 27 || !bytes.HasPrefix(b, []byte("OggS")) {
+		return 0, 0, 0, false
+	}
+	headerLen := 27 + int(b[26])
+	if len(b) < headerLen {
+		return 0, 0, 0, false
+	}
+	return int64(binary.LittleEndian.Uint64(b[6:])), binary.LittleEndian.Uint32(b[14:]), headerLen, true
+}
+
+// lastGranule returns the granule position of the last page of the logical stream within data, -1 if there is none
+func lastGranule(data []byte, serial uint32) int64 {
+	for i := bytes.LastIndex(data, []byte("OggS")); i >= 0; i = bytes.LastIndex(data[:i], []byte("OggS")) {
+		granule, pageSerial, _, ok := parseOggPage(data[i:])
+		// a granule position of -1 means no packet ends on the page
+		if ok && pageSerial == serial && granule >= 0 {
+			return granule
+		}
+	}
+	return -1
+}
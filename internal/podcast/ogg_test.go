@@ -0,0 +1,70 @@
+package podcast
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// oggPage creates an Ogg page of the logical stream holding the packet, the crc is not set as it isn't checked
+func oggPage(granule int64, serial uint32, packet []byte) []byte {
+	page := make([]byte, 27)
+	copy(page, "OggS")
+	binary.LittleEndian.PutUint64(page[6:], uint64(granule))
+	binary.LittleEndian.PutUint32(page[14:], serial)
+	var lacing []byte
+	for n := len(packet); ; n -= 255 {
+		if n < 255 {
+			lacing = append(lacing, byte(n))
+			break
+		}
+		lacing = append(lacing, 255)
+	}
+	page[26] = byte(len(lacing))
+	return append(append(page, lacing...), packet...)
+}
+
+// opusFile creates an Ogg Opus file whose last page ends at the granule position, followed by pages of other streams
+func opusFile(preSkip uint16, lastGranule int64, audioPages int) []byte {
+	head := []byte("OpusHead\x01\x02\x00\x00\x80\xbb\x00\x00\x00\x00\x00")
+	binary.LittleEndian.PutUint16(head[10:], preSkip)
+	file := oggPage(0, 1, head)
+	file = append(file, oggPage(0, 1, []byte("OpusTags"))...)
+	for i := 1; i <= audioPages; i++ {
+		file = append(file, oggPage(lastGranule*int64(i)/int64(audioPages), 1, bytes.Repeat([]byte{0x7f}, 4000))...)
+	}
+	// a page of another stream & one no packet ends on
+	file = append(file, oggPage(-1, 1, bytes.Repeat([]byte{0x7f}, 300))...)
+	return append(file, oggPage(lastGranule*2, 2, []byte("other stream"))...)
+}
+
+// vorbisFile creates an Ogg Vorbis file at the sample rate whose last page ends at the granule position
+func vorbisFile(sampleRate uint32, lastGranule int64) []byte {
+	head := make([]byte, 30)
+	copy(head, "\x01vorbis")
+	head[11] = 2
+	binary.LittleEndian.PutUint32(head[12:], sampleRate)
+	file := oggPage(0, 7, head)
+	return append(file, oggPage(lastGranule, 7, bytes.Repeat([]byte{0x7f}, 4000))...)
+}
+
+func Test_lastGranule(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []byte
+		serial uint32
+		want   int64
+	}{
+		{name: "opus", data: opusFile(312, 2880312, 3), serial: 1, want: 2880312},
+		{name: "other_stream", data: opusFile(312, 2880312, 3), serial: 2, want: 5760624},
+		{name: "missing_stream", data: opusFile(312, 2880312, 3), serial: 3, want: -1},
+		{name: "truncated_page", data: append(vorbisFile(44100, 100), []byte("OggS\x00")...), serial: 7, want: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lastGranule(tt.data, tt.serial); got != tt.want {
+				t.Errorf("lastGranule() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
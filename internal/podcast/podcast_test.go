@@ -1,8 +1,6 @@
 package podcast
 
 import (
-	"io"
-	"os"
 	"reflect"
 	"testing"
 
@@ -217,43 +215,6 @@ func TestSearchPodcasts(t *testing.T) {
 	}
 }
 
-func TestFindLength(t *testing.T) {
-	mp3File, err := os.Open("./test/sample.mp3")
-	if err != nil {
-		t.Fatalf("TestFindLength() unable to open file: %v", err)
-	}
-	mp3FileInfo, err := mp3File.Stat()
-	if err != nil {
-		t.Fatalf("TestFindLength() unable to get file stat: %v", err)
-	}
-
-	type args struct {
-		r          io.Reader
-		fileLength int64
-	}
-	tests := []struct {
-		name string
-		args args
-		want int64
-	}{
-		{
-			name: "sample",
-			args: args{
-				r:          mp3File,
-				fileLength: mp3FileInfo.Size(),
-			},
-			want: 10000,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := FindLength(tt.args.r, tt.args.fileLength); got != tt.want {
-				t.Errorf("FindLength() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
 func TestFindPodcastsByIDs(t *testing.T) {
 	mockDB := mock.CreateDB()
 	insertOrFail(t, mockDB, database.ColPodcast, &protos.Podcast{Id: protos.ObjectIDFromHex("pod_id1")})
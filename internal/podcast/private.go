@@ -0,0 +1,224 @@
+package podcast
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// privateFeedScheme prefixes the rss of private podcasts, their real url is only kept encrypted
+const privateFeedScheme = "private://"
+
+// Credentials stores the urls & credentials of users' private feeds encrypted with AES-GCM
+type Credentials struct {
+	dbClient db.Database
+	aead     cipher.AEAD
+}
+
+// NewCredentials creates a credential store encrypting with the 32 byte key
+func NewCredentials(dbClient db.Database, key []byte) (*Credentials, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("NewCredentials() error: key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("NewCredentials() error creating cipher: %v", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("NewCredentials() error creating gcm: %v", err)
+	}
+	return &Credentials{dbClient: dbClient, aead: aead}, nil
+}
+
+// Save encrypts & upserts the user's credentials of the private podcast
+func (c *Credentials) Save(userID, podID *protos.ObjectID, auth *models.FeedAuth) error {
+	plain, err := json.Marshal(auth)
+	if err != nil {
+		return fmt.Errorf("Save() error encoding credentials: %v", err)
+	}
+	nonce := make([]byte, c.aead.NonceSize())
+	_, err = io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return fmt.Errorf("Save() error creating nonce: %v", err)
+	}
+	cred := &models.FeedCredential{
+		UserID:    userID,
+		PodcastID: podID,
+		Sealed:    c.aead.Seal(nonce, nonce, plain, credentialData(userID, podID)),
+		CreatedAt: time.Now(),
+	}
+	err = c.dbClient.Upsert(database.ColCredential, cred, &db.Filter{"user_id": userID, "podcast_id": podID})
+	if err != nil {
+		return fmt.Errorf("error upserting credentials: %v", err)
+	}
+	return nil
+}
+
+// Find finds & decrypts the user's credentials of the private podcast
+func (c *Credentials) Find(userID, podID *protos.ObjectID) (*models.FeedAuth, error) {
+	cred := &models.FeedCredential{}
+	err := c.dbClient.FindOne(database.ColCredential, cred, &db.Filter{"user_id": userID, "podcast_id": podID}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Find() error finding credentials: %v", err)
+	}
+	size := c.aead.NonceSize()
+	if len(cred.Sealed) < size {
+		return nil, errors.New("Find() error credentials are corrupt")
+	}
+	plain, err := c.aead.Open(nil, cred.Sealed[:size], cred.Sealed[size:], credentialData(userID, podID))
+	if err != nil {
+		return nil, fmt.Errorf("Find() error decrypting credentials: %v", err)
+	}
+	auth := &models.FeedAuth{}
+	err = json.Unmarshal(plain, auth)
+	if err != nil {
+		return nil, fmt.Errorf("Find() error decoding credentials: %v", err)
+	}
+	return auth, nil
+}
+
+// credentialData binds the encrypted credentials to their user & podcast
+func credentialData(userID, podID *protos.ObjectID) []byte {
+	return []byte(userID.GetHex() + ":" + podID.GetHex())
+}
+
+// authURL returns the url of the private feed with its basic auth credentials, if any
+func authURL(auth *models.FeedAuth) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(auth.URL))
+	if err != nil {
+		return "", fmt.Errorf("authURL() error parsing url: %v", err)
+	}
+	if auth.Username != "" || auth.Password != "" {
+		u.User = url.UserPassword(auth.Username, auth.Password)
+	}
+	return u.String(), nil
+}
+
+// privateFeedRss returns the rss stored on the user's private podcast in place of its url,
+// so the same user can't add the same feed twice without storing the url itself
+func privateFeedRss(userID *protos.ObjectID, feedURL string) string {
+	return privateFeedScheme + hashFeed([]byte(userID.GetHex()+" "+strings.TrimSpace(feedURL)))
+}
+
+// redactFeedError removes the private feed's url from the error, tokenised urls are as secret as passwords
+func redactFeedError(err error, feedURL string) error {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	if u, parseErr := url.Parse(feedURL); parseErr == nil {
+		msg = strings.ReplaceAll(msg, u.Redacted(), "<private feed>")
+		u.User = nil
+		msg = strings.ReplaceAll(msg, u.String(), "<private feed>")
+	}
+	return errors.New(strings.ReplaceAll(msg, feedURL, "<private feed>"))
+}
+
+// CanAccessPodcast checks whether the user may see the podcast & its episodes,
+// private podcasts are only visible to their owner
+func CanAccessPodcast(pod *protos.Podcast, userID *protos.ObjectID) bool {
+	return !pod.Private || (userID != nil && pod.OwnerID.GetHex() == userID.GetHex())
+}
+
+// FindPodcastForUser finds the podcast via id if the user may access it
+func FindPodcastForUser(dbClient db.Database, id, userID *protos.ObjectID) (*protos.Podcast, error) {
+	pod, err := FindPodcastByID(dbClient, id)
+	if err != nil {
+		return nil, err
+	}
+	if !CanAccessPodcast(pod, userID) {
+		// indistinguishable from a podcast that does not exist
+		return nil, errors.New("FindPodcastForUser() error: podcast not found")
+	}
+	return pod, nil
+}
+
+// AddPrivatePodcast adds the user's private feed, fetched with its tokenised url and/or basic auth
+// credentials which are stored encrypted. The podcast & its episodes are only visible to the user,
+// who is subscribed to it
+func AddPrivatePodcast(dbClient db.Database, creds *Credentials, userID *protos.ObjectID, auth *models.FeedAuth) (*protos.Podcast, error) {
+	if creds == nil {
+		return nil, errors.New("AddPrivatePodcast() error: private feeds are not enabled")
+	}
+	rss := privateFeedRss(userID, auth.URL)
+	if DoesPodcastExist(dbClient, rss) {
+		return nil, errors.New("podcast already exists")
+	}
+	feedURL, err := authURL(auth)
+	if err != nil {
+		return nil, err
+	}
+
+	body, fetched, err := downloadRSS(feedURL, &models.FetchState{})
+	if err != nil {
+		return nil, fmt.Errorf("AddPrivatePodcast() error downloading rss: %v", redactFeedError(err, feedURL))
+	}
+	rssPod, err := parseFeed(body)
+	if err != nil {
+		return nil, fmt.Errorf("AddPrivatePodcast() error parsing feed: %v", err)
+	}
+
+	pod := convertPodcast(rss, rssPod)
+	pod.Private = true
+	pod.OwnerID = userID
+	err = dbClient.Insert(database.ColPodcast, pod)
+	if err != nil {
+		return nil, fmt.Errorf("error adding private podcast: %v", err)
+	}
+	err = creds.Save(userID, pod.Id, auth)
+	if err != nil {
+		return nil, fmt.Errorf("AddPrivatePodcast() error: %v", err)
+	}
+
+	_, err = importEpisodes(dbClient, pod, rssPod)
+	if err != nil {
+		log.Println("AddPrivatePodcast() error importing episodes:", err)
+	}
+	err = dbClient.Insert(database.ColSubscription, &protos.Subscription{Id: protos.NewObjectID(), UserID: userID, PodcastID: pod.Id})
+	if err != nil {
+		return nil, fmt.Errorf("AddPrivatePodcast() error subscribing: %v", err)
+	}
+
+	// the url moved to is not stored, it is as secret as the url itself
+	fetched.PodcastID = pod.Id
+	fetched.MovedTo = ""
+	setFeedHints(fetched, rssPod)
+	fetched.LastSuccess = time.Now()
+	saveFetchState(dbClient, fetched)
+	return pod, nil
+}
+
+// updatePrivatePodcast updates the private podcast via the feed url & credentials of its owner
+func updatePrivatePodcast(dbClient db.Database, creds *Credentials, pod *protos.Podcast) error {
+	if creds == nil {
+		return &feedError{class: ErrorClassInternal, err: errors.New("updatePrivatePodcast() error: private feeds are not enabled")}
+	}
+	auth, err := creds.Find(pod.OwnerID, pod.Id)
+	if err != nil {
+		return fmt.Errorf("updatePrivatePodcast() error: %v", err)
+	}
+	feedURL, err := authURL(auth)
+	if err != nil {
+		return fmt.Errorf("updatePrivatePodcast() error: %v", err)
+	}
+	err = updateFeed(dbClient, pod, feedURL)
+	var feedErr *feedError
+	if errors.As(err, &feedErr) {
+		return &feedError{class: feedErr.class, err: redactFeedError(feedErr.err, feedURL)}
+	}
+	return redactFeedError(err, feedURL)
+}
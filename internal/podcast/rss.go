@@ -1,336 +1,466 @@
-package podcast
-
-import (
-	"encoding/xml"
-	"errors"
-	"fmt"
-	"io"
-	"log"
-	"net/http"
-	"strconv"
-	"strings"
-	"sync"
-	"time"
-
-	"github.com/golang/protobuf/ptypes"
-	"github.com/sschwartz96/stockpile/db"
-	"github.com/sschwartz96/syncapod/internal/database"
-	"github.com/sschwartz96/syncapod/internal/models"
-	"github.com/sschwartz96/syncapod/internal/protos"
-	"go.mongodb.org/mongo-driver/bson/primitive"
-)
-
-var tzMap = map[string]string{
-	"PST": "-0800", "PDT": "-0700",
-	"MST": "-0700", "MDT": "-0600",
-	"CST": "-0600", "CDT": "-0500",
-	"EST": "-0500", "EDT": "-0400",
-}
-
-// UpdatePodcasts attempts to go through the list of podcasts update them via RSS feed
-func UpdatePodcasts(dbClient db.Database) error {
-	var podcasts []*protos.Podcast
-	var err error
-	// just increments start and end indices
-	for start, end := 0, 10; ; start, end = end, end+10 {
-		podcasts, err = FindPodcastsByRange(dbClient, start, end)
-		if err != nil || len(podcasts) == 0 {
-			break // will eventually break
-		}
-		var wg sync.WaitGroup
-		for i := range podcasts {
-			pod := podcasts[i]
-			wg.Add(1)
-			go func() {
-				log.Println("starting updatePodcast():", pod.Title)
-				err = updatePodcast(dbClient, pod)
-				if err != nil {
-					fmt.Printf("UpdatePodcasts() error updating podcast %v, error = %v\n", pod, err)
-				}
-				log.Println("finished updatePodcast():", pod.Title)
-				wg.Done()
-			}()
-		}
-		wg.Wait()
-	}
-	if err != nil {
-		return fmt.Errorf("UpdatePodcasts() error retrieving from db: %v", err)
-	}
-	return nil
-}
-
-// updatePodcast updates the given podcast via RSS feed
-func updatePodcast(dbClient db.Database, pod *protos.Podcast) error {
-	// get rss from url
-	rssResp, err := downloadRSS(pod.Rss)
-	if err != nil {
-		return fmt.Errorf("AddNewPodcast() error downloading rss: %v", err)
-	}
-	// defer closing
-	defer func() {
-		err := rssResp.Close()
-		if err != nil {
-			log.Println("parseRSS() error closing r:", err)
-		}
-	}()
-	// parse rss from respone.Body
-	newPod, err := parseRSS(rssResp)
-	if err != nil {
-		fmt.Println("updatePodcast() failed to load podcast rss: ", err)
-		return fmt.Errorf("updatePodcast() error parsing RSS: %v", err)
-	}
-
-	for e := range newPod.RSSEpisodes {
-		epi := convertEpisode(pod.Id, &newPod.RSSEpisodes[e])
-		// check if the latest episode is in collection
-		exists, err := DoesEpisodeExist(dbClient, epi.Title, epi.PubDate)
-		if err != nil {
-			fmt.Println("couldn't tell if object exists: ", err)
-			continue
-		}
-		// episode does not exist
-		if !exists {
-			err = UpsertEpisode(dbClient, epi)
-			if err != nil {
-				fmt.Println("couldn't insert episode: ", err)
-				return fmt.Errorf("updatePodcast() error upserting episode: %v", err)
-			}
-		} else {
-			// assume that if the first podcast exists so do the rest, no need to loop through all
-			break
-		}
-	}
-	return nil
-}
-
-// AddNewPodcast takes RSS url and downloads contents inserts the podcast and its episodes into the db
-// returns error if podcast already exists or connection error
-func AddNewPodcast(dbClient db.Database, url string) error {
-	// check if podcast already contains that rss url
-	exists := DoesPodcastExist(dbClient, url)
-	if exists {
-		return errors.New("podcast already exists")
-	}
-
-	// attempt to download & parse the podcast rss
-	rssResp, err := downloadRSS(url)
-	if err != nil {
-		return fmt.Errorf("AddNewPodcast() error downloading rss: %v", err)
-	}
-	// defer closing
-	defer func() {
-		err := rssResp.Close()
-		if err != nil {
-			log.Println("parseRSS() error closing r:", err)
-		}
-	}()
-
-	rssPod, err := parseRSS(rssResp)
-	if err != nil {
-		return err
-	}
-	pod := convertPodcast(url, rssPod)
-
-	// insert podcast first that way we don't add episodes without podcast
-	err = dbClient.Insert(database.ColPodcast, pod)
-	if err != nil {
-		return fmt.Errorf("error adding new podcast: %v", err)
-	}
-
-	rssEpisodes := rssPod.RSSEpisodes
-
-	// loop through episodes and save them
-	for i := range rssEpisodes {
-		rssEpi := rssEpisodes[i]
-		rssEpi.ID = primitive.NewObjectID()
-		rssEpi.PodcastID = rssPod.ID
-		if rssEpi.Author == "" {
-			rssEpi.Author = pod.Author
-		}
-
-		epi := convertEpisode(pod.Id, &rssEpi)
-
-		err = UpsertEpisode(dbClient, epi)
-		if err != nil {
-			fmt.Println("couldn't insert episode: ", err)
-		}
-	}
-
-	return nil
-}
-
-func downloadRSS(url string) (io.ReadCloser, error) {
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	return resp.Body, nil
-}
-
-// parseRSS takes in reader path and unmarshals the data
-func parseRSS(r io.Reader) (*models.RSSPodcast, error) {
-	// set up rss feed object and decoder
-	var rss models.RSSFeed
-	decoder := xml.NewDecoder(r)
-	decoder.DefaultSpace = "Default"
-
-	// decode
-	err := decoder.Decode(&rss)
-	if err != nil {
-		return nil, err
-	}
-
-	return &rss.RSSPodcast, nil
-}
-
-// convertEpisode takes in id of parent podcast and RSSEpisode
-// and returns a pointer to Episode
-func convertEpisode(pID *protos.ObjectID, e *models.RSSEpisode) *protos.Episode {
-	pubDate, err := parseRFC2822ToUTC(e.PubDate)
-	if err != nil {
-		fmt.Println("convertEpisode() error converting episode:", err)
-	}
-	// no error since we are checking for one above
-	pubTimestamp, _ := ptypes.TimestampProto(*pubDate)
-
-	image := &protos.Image{Title: "", Url: e.Image.HREF}
-
-	dur, err := parseDuration(e.Duration)
-	if err != nil {
-		fmt.Println("convertEpisode() error parsing duration:", err)
-	}
-
-	return &protos.Episode{
-		Id:             protos.NewObjectID(),
-		PodcastID:      pID,
-		Title:          e.Title,
-		Description:    e.Description,
-		Subtitle:       e.Subtitle,
-		Author:         e.Author,
-		Type:           e.Type,
-		Image:          image,
-		PubDate:        pubTimestamp,
-		Summary:        e.Summary,
-		Season:         int32(e.Season),
-		Episode:        int32(e.Episode),
-		Category:       convertCategories(e.Category),
-		Explicit:       e.Explicit,
-		MP3URL:         e.Enclosure.MP3,
-		DurationMillis: dur,
-	}
-}
-
-// convertPodcast
-func convertPodcast(url string, p *models.RSSPodcast) *protos.Podcast {
-
-	keywords := strings.Split(p.Keywords, ",")
-	for w := range keywords {
-		keywords[w] = strings.TrimSpace(keywords[w])
-	}
-
-	lBuildDate, err := parseRFC2822ToUTC(p.LastBuildDate)
-	if err != nil {
-		fmt.Println("convertPodcast() couldn't parse podcast build date:", err)
-	}
-	buildTimestamp, _ := ptypes.TimestampProto(*lBuildDate)
-
-	pubDate, err := parseRFC2822ToUTC(p.PubDate)
-	if err != nil {
-		fmt.Println("convertPodcast() couldn't parse podcast pubdate:", err)
-	}
-	pubTimestamp, _ := ptypes.TimestampProto(*pubDate)
-
-	return &protos.Podcast{
-		Id:            protos.NewObjectID(),
-		Author:        p.Author,
-		Category:      convertCategories(p.Category),
-		Explicit:      p.Explicit,
-		Image:         &protos.Image{Title: p.Image.Title, Url: p.Image.URL},
-		Keywords:      keywords,
-		Language:      p.Language,
-		LastBuildDate: buildTimestamp,
-		PubDate:       pubTimestamp,
-		Link:          p.Link,
-		Rss:           url,
-		Subtitle:      p.Subtitle,
-		Title:         p.Title,
-		Type:          p.Type,
-	}
-}
-
-func findTimezoneOffset(tz string) (string, error) {
-	offset, ok := tzMap[tz]
-	if !ok {
-		return "", errors.New("timezone not found")
-	}
-	return offset, nil
-}
-
-// parseRFC2822ToUTC parses the string in RFC2822 date format
-// returns pointer to time object and error
-// returns time.Now() even if error occurs
-func parseRFC2822ToUTC(s string) (*time.Time, error) {
-	if s == "" {
-		t := time.Now()
-		return &t, fmt.Errorf("parseRFC2822ToUTC() no time provided")
-	}
-	if !strings.Contains(s, "+") && !strings.Contains(s, "-") {
-		fields := strings.Fields(s)
-		tz := fields[len(fields)-1]
-		offset, err := findTimezoneOffset(tz)
-		if err != nil {
-			t := time.Now()
-			return &t, err
-		}
-		s = strings.ReplaceAll(s, tz, offset)
-	}
-	t, err := time.Parse("Mon, 02 Jan 2006 15:04:05 -0700", s)
-	if err != nil {
-		return &t, err
-	}
-	return &t, nil
-}
-
-//parseDuration takes in the string duration and returns the duration in millis
-func parseDuration(d string) (int64, error) {
-	if d == "" {
-		return 0, fmt.Errorf("parseDuration() error empty duration string")
-	}
-	// check if they just applied the seconds
-	if !strings.Contains(d, ":") {
-		sec, err := strconv.Atoi(d)
-		if err != nil {
-			return 0, fmt.Errorf("parseDuration() error converting duration of episode: %v", err)
-		}
-		return int64(sec) * int64(1000), nil
-	}
-	var millis int64
-	multiplier := int64(1000)
-
-	// format hh:mm:ss || mm:ss
-	split := strings.Split(d, ":")
-
-	for i := len(split) - 1; i >= 0; i-- {
-		v, _ := strconv.Atoi(split[i])
-		millis += int64(v) * multiplier
-		multiplier *= int64(60)
-	}
-
-	return millis, nil
-}
-
-func convertCategories(cats []models.Category) []*protos.Category {
-	protoCats := make([]*protos.Category, len(cats))
-	for i := range cats {
-		protoCats[i] = convertCategory(cats[i])
-	}
-	return protoCats
-}
-
-func convertCategory(cat models.Category) *protos.Category {
-	newCat := &protos.Category{
-		Text:     cat.Text,
-		Category: convertCategories(cat.Category),
-	}
-	return newCat
-}
+package podcast
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/protos"
+	"github.com/sschwartz96/syncapod/internal/queue"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// analyzeMaxAge is how recently an episode must have been published for its new enclosure to be
+// downloaded & analyzed, so a feed moving its whole catalog to a new host doesn't queue all of it
+const analyzeMaxAge = 30 * 24 * time.Hour
+
+var tzMap = map[string]string{
+	"PST": "-0800", "PDT": "-0700",
+	"MST": "-0700", "MDT": "-0600",
+	"CST": "-0600", "CDT": "-0500",
+	"EST": "-0500", "EDT": "-0400",
+}
+
+// updatePodcast updates the given podcast via RSS feed
+func updatePodcast(dbClient db.Database, pod *protos.Podcast) error {
+	return updateFeed(dbClient, pod, pod.Rss)
+}
+
+// updateFeed updates the given podcast via the RSS feed at feedURL
+func updateFeed(dbClient db.Database, pod *protos.Podcast, feedURL string) error {
+	state, err := FindFetchState(dbClient, pod.Id)
+	if err != nil {
+		// feed has not been fetched before
+		state = &models.FetchState{PodcastID: pod.Id}
+	}
+
+	// get rss from url, skipping the update if nothing changed
+	body, fetched, err := downloadRSS(feedURL, state)
+	if fetched != nil {
+		state.LastStatus = fetched.LastStatus
+		if pod.Private {
+			// the url of a private feed is never stored as is
+			fetched.MovedTo = ""
+		}
+	}
+	if err == errNotModified {
+		// an identical body may still come with new caching headers, the next request sends them
+		state.ETag, state.LastModified = fetched.ETag, fetched.LastModified
+		if fetched.MovedTo != "" {
+			// unchanged content, so the new url is the same show
+			pod = followFeed(dbClient, pod, fetched.MovedTo, nil)
+			state.PodcastID = pod.Id
+		}
+		state.LastSuccess = time.Now()
+		return UpsertFetchState(dbClient, state)
+	}
+	if err != nil {
+		saveFetchState(dbClient, state)
+		return &feedError{
+			class: classifyFetchError(err, fetched),
+			err:   fmt.Errorf("updateFeed() error downloading rss: %v", err),
+		}
+	}
+	return ingestFeed(dbClient, pod, state, fetched, body)
+}
+
+// ingestFeed parses the feed body and reconciles its episodes onto the podcast.
+// state is the stored fetch state of the podcast, fetched describes where the body came from
+// and is stored once the feed has been fully processed
+func ingestFeed(dbClient db.Database, pod *protos.Podcast, state, fetched *models.FetchState, body []byte) error {
+	// parse rss from respone body
+	newPod, err := parseFeed(body)
+	if err != nil {
+		fmt.Println("ingestFeed() failed to load podcast rss: ", err)
+		saveFetchState(dbClient, state)
+		return &feedError{class: ErrorClassXMLParse, err: fmt.Errorf("ingestFeed() error parsing RSS: %v", err)}
+	}
+	if len(newPod.RSSEpisodes) == 0 {
+		saveFetchState(dbClient, state)
+		return &feedError{class: ErrorClassEmptyChannel, err: errors.New("ingestFeed() error feed has no episodes")}
+	}
+
+	// follow the feed if it moved, the podcast may have been merged into another.
+	// private feeds are not followed, their new url would have to be given by their owner
+	if !pod.Private {
+		pod = followFeed(dbClient, pod, fetched.MovedTo, newPod)
+	}
+	fetched.PodcastID = pod.Id
+
+	// reconcile every episode within the feed, publishers may edit or re-date any of them
+	for e := range newPod.RSSEpisodes {
+		epi := convertEpisode(pod.Id, &newPod.RSSEpisodes[e])
+		if epi.Author == "" {
+			epi.Author = pod.Author
+		}
+		isNew, err := reconcileEpisode(dbClient, epi, true)
+		if err != nil {
+			fmt.Println("couldn't reconcile episode: ", err)
+			saveFetchState(dbClient, state)
+			return fmt.Errorf("ingestFeed() error reconciling episode: %v", err)
+		}
+		// only episodes found by updating the feed are queued, not those of a newly added podcast
+		if isNew {
+			err = queue.AutoQueue(dbClient, epi)
+			if err != nil {
+				fmt.Println("couldn't auto queue episode: ", err)
+			}
+		}
+	}
+
+	// the feed may have started or stopped advertising a WebSub hub,
+	// hubs are never given the url of a private feed
+	if !pod.Private {
+		requestWebSub(dbClient, pod, newPod)
+	}
+
+	// only store the new caching info once the feed has been fully processed
+	setFeedHints(fetched, newPod)
+	fetched.LastSuccess = time.Now()
+	return UpsertFetchState(dbClient, fetched)
+}
+
+// AddNewPodcast takes RSS url and downloads contents inserts the podcast and its episodes into the db
+// returns error if podcast already exists or connection error
+func AddNewPodcast(dbClient db.Database, url string) error {
+	// check if podcast already contains that rss url
+	exists := DoesPodcastExist(dbClient, url)
+	if exists {
+		return errors.New("podcast already exists")
+	}
+
+	// attempt to download & parse the podcast rss
+	body, fetched, err := downloadRSS(url, &models.FetchState{})
+	if err != nil {
+		return fmt.Errorf("AddNewPodcast() error downloading rss: %v", err)
+	}
+	// use the feed's new location if it moved permanently
+	feedURL := url
+	if fetched.MovedTo != "" {
+		if DoesPodcastExist(dbClient, fetched.MovedTo) {
+			return errors.New("podcast already exists")
+		}
+		feedURL = fetched.MovedTo
+	}
+
+	rssPod, err := parseFeed(body)
+	if err != nil {
+		return err
+	}
+	if rssPod.NewFeedURL != "" && DoesPodcastExist(dbClient, strings.TrimSpace(rssPod.NewFeedURL)) {
+		return errors.New("podcast already exists")
+	}
+	pod := convertPodcast(feedURL, rssPod)
+
+	// insert podcast first that way we don't add episodes without podcast
+	err = dbClient.Insert(database.ColPodcast, pod)
+	if err != nil {
+		return fmt.Errorf("error adding new podcast: %v", err)
+	}
+
+	rssEpisodes := rssPod.RSSEpisodes
+
+	// loop through episodes and save them
+	for i := range rssEpisodes {
+		rssEpi := rssEpisodes[i]
+		rssEpi.ID = primitive.NewObjectID()
+		rssEpi.PodcastID = rssPod.ID
+		if rssEpi.Author == "" {
+			rssEpi.Author = pod.Author
+		}
+
+		epi := convertEpisode(pod.Id, &rssEpi)
+
+		_, err = reconcileEpisode(dbClient, epi, false)
+		if err != nil {
+			fmt.Println("couldn't insert episode: ", err)
+		}
+	}
+
+	// import the back catalog linked by paged & archived feeds
+	added, err := backfillFeed(dbClient, pod, feedURL, rssPod)
+	if err != nil {
+		fmt.Println("AddNewPodcast() error backfilling feed: ", err)
+	}
+	if added > 0 {
+		log.Printf("AddNewPodcast() imported %d episodes from older feed pages of %v\n", added, pod.Title)
+	}
+
+	if feedURL != url {
+		err = UpsertPreviousFeed(dbClient, &models.PreviousFeed{URL: url, PodcastID: pod.Id, MovedAt: time.Now()})
+		if err != nil {
+			fmt.Println("AddNewPodcast() error saving previous feed: ", err)
+		}
+	}
+
+	// push updates if the feed advertises a WebSub hub
+	requestWebSub(dbClient, pod, rssPod)
+
+	// save the caching info so the next update can be conditional
+	fetched.PodcastID = pod.Id
+	setFeedHints(fetched, rssPod)
+	fetched.LastSuccess = time.Now()
+	saveFetchState(dbClient, fetched)
+
+	return nil
+}
+
+// FindOrAddPodcast finds the podcast with the rss url, adding it if it's new
+func FindOrAddPodcast(dbClient db.Database, url string) (*protos.Podcast, error) {
+	url = strings.TrimSpace(url)
+	pod, err := FindPodcastByFeed(dbClient, url)
+	if err == nil {
+		return pod, nil
+	}
+	// it may have been added concurrently, or found at the url it moved to
+	addErr := AddNewPodcast(dbClient, url)
+	pod, err = FindPodcastByFeed(dbClient, url)
+	if err != nil {
+		if addErr != nil {
+			return nil, fmt.Errorf("FindOrAddPodcast() error adding podcast: %v", addErr)
+		}
+		return nil, fmt.Errorf("FindOrAddPodcast() error: %v", err)
+	}
+	return pod, nil
+}
+
+// reconcileEpisode inserts the episode if it is new, otherwise the stored episode
+// is updated in place when the feed changed any of its metadata.
+// the new enclosures of recent episodes found refreshing the feed are fingerprinted & their waveforms
+// generated, not those of the back catalog stored adding or backfilling the podcast.
+// returns whether the episode was new
+func reconcileEpisode(dbClient db.Database, epi *protos.Episode, refreshed bool) (bool, error) {
+	existing, err := FindEpisodeByIdentity(dbClient, epi)
+	if err != nil {
+		return false, err
+	}
+	if existing != nil {
+		epi.Id = existing.Id
+		keepProbed(existing, epi)
+		if proto.Equal(existing, epi) {
+			return false, nil
+		}
+	}
+	err = UpsertEpisode(dbClient, epi)
+	if err != nil {
+		return false, err
+	}
+	if existing == nil || existing.MP3URL != epi.MP3URL {
+		queueChapters(dbClient, epi)
+		if refreshed && isRecent(epi) {
+			queueFingerprint(dbClient, epi)
+			queueWaveform(dbClient, epi)
+		}
+	}
+	return existing == nil, nil
+}
+
+// isRecent returns whether the episode was published within analyzeMaxAge
+func isRecent(epi *protos.Episode) bool {
+	pubDate, err := ptypes.Timestamp(epi.PubDate)
+	return err == nil && time.Since(pubDate) < analyzeMaxAge
+}
+
+// setFeedHints copies the refresh hints given by the feed onto its fetch state
+func setFeedHints(state *models.FetchState, p *models.RSSPodcast) {
+	state.TTL = p.TTL
+	state.UpdatePeriod = p.UpdatePeriod
+	state.UpdateFrequency = p.UpdateFrequency
+}
+
+// saveFetchState upserts the fetch state and logs on failure, used when
+// an update has already failed and the state is only informational
+func saveFetchState(dbClient db.Database, state *models.FetchState) {
+	err := UpsertFetchState(dbClient, state)
+	if err != nil {
+		log.Println("saveFetchState() error:", err)
+	}
+}
+
+// parseRSS takes in reader path and unmarshals the data
+func parseRSS(r io.Reader) (*models.RSSPodcast, error) {
+	// set up rss feed object and decoder
+	var rss models.RSSFeed
+	decoder := xml.NewDecoder(r)
+	decoder.DefaultSpace = "Default"
+
+	// decode
+	err := decoder.Decode(&rss)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rss.RSSPodcast, nil
+}
+
+// convertEpisode takes in id of parent podcast and RSSEpisode
+// and returns a pointer to Episode
+func convertEpisode(pID *protos.ObjectID, e *models.RSSEpisode) *protos.Episode {
+	pubDate, err := parseRFC2822ToUTC(e.PubDate)
+	if err != nil {
+		fmt.Println("convertEpisode() error converting episode:", err)
+	}
+	// no error since we are checking for one above
+	pubTimestamp, _ := ptypes.TimestampProto(*pubDate)
+
+	image := &protos.Image{Title: "", Url: e.Image.HREF}
+
+	dur, err := parseDuration(e.Duration)
+	if err != nil {
+		fmt.Println("convertEpisode() error parsing duration:", err)
+	}
+
+	// feeds often leave the length at 0 when unknown, it is filled in by probing the enclosure
+	length, _ := strconv.ParseInt(strings.TrimSpace(e.Enclosure.Length), 10, 64)
+
+	epi := &protos.Episode{
+		Id:              protos.NewObjectID(),
+		PodcastID:       pID,
+		Title:           e.Title,
+		Description:     e.Description,
+		Subtitle:        e.Subtitle,
+		Author:          e.Author,
+		Type:            e.Type,
+		Image:           image,
+		PubDate:         pubTimestamp,
+		Summary:         e.Summary,
+		Season:          int32(e.Season),
+		Episode:         int32(e.Episode),
+		Category:        convertCategories(e.Category),
+		Explicit:        e.Explicit,
+		MP3URL:          e.Enclosure.MP3,
+		EnclosureType:   strings.TrimSpace(e.Enclosure.Type),
+		EnclosureLength: length,
+		Guid:            strings.TrimSpace(e.GUID),
+		DurationMillis:  dur,
+	}
+	convertEpisodeNamespace(epi, e)
+	return epi
+}
+
+// convertPodcast
+func convertPodcast(url string, p *models.RSSPodcast) *protos.Podcast {
+
+	keywords := strings.Split(p.Keywords, ",")
+	for w := range keywords {
+		keywords[w] = strings.TrimSpace(keywords[w])
+	}
+
+	lBuildDate, err := parseRFC2822ToUTC(p.LastBuildDate)
+	if err != nil {
+		fmt.Println("convertPodcast() couldn't parse podcast build date:", err)
+	}
+	buildTimestamp, _ := ptypes.TimestampProto(*lBuildDate)
+
+	pubDate, err := parseRFC2822ToUTC(p.PubDate)
+	if err != nil {
+		fmt.Println("convertPodcast() couldn't parse podcast pubdate:", err)
+	}
+	pubTimestamp, _ := ptypes.TimestampProto(*pubDate)
+
+	pod := &protos.Podcast{
+		Id:            protos.NewObjectID(),
+		Author:        p.Author,
+		Category:      convertCategories(p.Category),
+		Explicit:      p.Explicit,
+		Image:         &protos.Image{Title: p.Image.Title, Url: p.Image.URL},
+		Keywords:      keywords,
+		Language:      p.Language,
+		LastBuildDate: buildTimestamp,
+		PubDate:       pubTimestamp,
+		Link:          p.Link,
+		Rss:           url,
+		Subtitle:      p.Subtitle,
+		Title:         p.Title,
+		Type:          p.Type,
+	}
+	convertPodcastNamespace(pod, p)
+	return pod
+}
+
+func findTimezoneOffset(tz string) (string, error) {
+	offset, ok := tzMap[tz]
+	if !ok {
+		return "", errors.New("timezone not found")
+	}
+	return offset, nil
+}
+
+// parseRFC2822ToUTC parses the string in RFC2822 date format
+// returns pointer to time object and error
+// returns time.Now() even if error occurs
+func parseRFC2822ToUTC(s string) (*time.Time, error) {
+	if s == "" {
+		t := time.Now()
+		return &t, fmt.Errorf("parseRFC2822ToUTC() no time provided")
+	}
+	if !strings.Contains(s, "+") && !strings.Contains(s, "-") {
+		fields := strings.Fields(s)
+		tz := fields[len(fields)-1]
+		offset, err := findTimezoneOffset(tz)
+		if err != nil {
+			t := time.Now()
+			return &t, err
+		}
+		s = strings.ReplaceAll(s, tz, offset)
+	}
+	t, err := time.Parse("Mon, 02 Jan 2006 15:04:05 -0700", s)
+	if err != nil {
+		return &t, err
+	}
+	return &t, nil
+}
+
+//parseDuration takes in the string duration and returns the duration in millis
+func parseDuration(d string) (int64, error) {
+	if d == "" {
+		return 0, fmt.Errorf("parseDuration() error empty duration string")
+	}
+	// check if they just applied the seconds
+	if !strings.Contains(d, ":") {
+		sec, err := strconv.Atoi(d)
+		if err != nil {
+			return 0, fmt.Errorf("parseDuration() error converting duration of episode: %v", err)
+		}
+		return int64(sec) * int64(1000), nil
+	}
+	var millis int64
+	multiplier := int64(1000)
+
+	// format hh:mm:ss || mm:ss
+	split := strings.Split(d, ":")
+
+	for i := len(split) - 1; i >= 0; i-- {
+		v, _ := strconv.Atoi(split[i])
+		millis += int64(v) * multiplier
+		multiplier *= int64(60)
+	}
+
+	return millis, nil
+}
+
+func convertCategories(cats []models.Category) []*protos.Category {
+	protoCats := make([]*protos.Category, len(cats))
+	for i := range cats {
+		protoCats[i] = convertCategory(cats[i])
+	}
+	return protoCats
+}
+
+func convertCategory(cat models.Category) *protos.Category {
+	newCat := &protos.Category{
+		Text:     cat.Text,
+		Category: convertCategories(cat.Category),
+	}
+	return newCat
+}
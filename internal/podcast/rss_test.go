@@ -16,37 +16,6 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-func TestUpdatePodcasts(t *testing.T) {
-	mockDB := mock.CreateDB()
-	insertOrFail(t, mockDB, database.ColPodcast, &protos.Podcast{Id: protos.NewObjectID(), Title: "Go Time", Author: "Changelog Media", Type: "", Subtitle: "", Link: "https://changelog.com/gotime", Image: &protos.Image{Title: "", Url: ""}, Explicit: "no", Language: "en-us", Keywords: []string{"go", "golang", "open source", "software", "development", "devops", "architecture", "docker", "kubernetes"}, Rss: "https://changelog.com/gotime/feed"})
-	insertOrFail(t, mockDB, database.ColEpisode, &protos.Episode{})
-
-	type args struct {
-		dbClient db.Database
-	}
-	tests := []struct {
-		name    string
-		args    args
-		wantErr bool
-	}{
-		{
-			name: "valid",
-			args: args{
-				dbClient: mockDB,
-			},
-			wantErr: false,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			if err := UpdatePodcasts(tt.args.dbClient); (err != nil) != tt.wantErr {
-				t.Errorf("UpdatePodcasts() error = %v, wantErr %v", err, tt.wantErr)
-			}
-		})
-	}
-}
-
 func Test_updatePodcast(t *testing.T) {
 	mockDB := mock.CreateDB()
 	type args struct {
@@ -120,6 +89,122 @@ func TestAddNewPodcast(t *testing.T) {
 	}
 }
 
+func Test_reconcileEpisode(t *testing.T) {
+	mockDB := mock.CreateDB()
+	podID := protos.NewObjectID()
+	pubDate := ptypes.TimestampNow()
+	existing := &protos.Episode{Id: protos.NewObjectID(), PodcastID: podID, Guid: "guid-1", Title: "Original Title", PubDate: pubDate}
+	insertOrFail(t, mockDB, database.ColEpisode, existing)
+	probed := &protos.Episode{Id: protos.NewObjectID(), PodcastID: podID, Guid: "guid-3", MP3URL: "https://example.com/3.mp3", DurationMillis: 1000, EnclosureType: formatMP3, EnclosureLength: 2000}
+	insertOrFail(t, mockDB, database.ColEpisode, probed)
+
+	oldPubDate, _ := ptypes.TimestampProto(time.Now().Add(-2 * analyzeMaxAge))
+
+	tests := []struct {
+		name         string
+		epi          *protos.Episode
+		refreshed    bool
+		wantID       *protos.ObjectID
+		wantCount    int
+		wantAdded    bool
+		wantDuration int64
+		wantQueued   bool
+		wantAnalyzed bool
+	}{
+		{
+			name:      "unchanged",
+			epi:       &protos.Episode{Id: protos.NewObjectID(), PodcastID: podID, Guid: "guid-1", Title: "Original Title", PubDate: pubDate},
+			wantID:    existing.Id,
+			wantCount: 2,
+		},
+		{
+			name:      "edited_in_place",
+			epi:       &protos.Episode{Id: protos.NewObjectID(), PodcastID: podID, Guid: "guid-1", Title: "Edited Title", PubDate: ptypes.TimestampNow()},
+			wantID:    existing.Id,
+			wantCount: 2,
+		},
+		{
+			name:      "new",
+			epi:       &protos.Episode{Id: protos.ObjectIDFromHex("new_epi"), PodcastID: podID, Guid: "guid-2", Title: "New Episode", PubDate: pubDate},
+			wantID:    protos.ObjectIDFromHex("new_epi"),
+			wantCount: 3,
+			wantAdded: true,
+		},
+		{
+			name:         "probed_enclosure_kept",
+			epi:          &protos.Episode{Id: protos.NewObjectID(), PodcastID: podID, Guid: "guid-3", Title: "Probed", MP3URL: "https://example.com/3.mp3"},
+			wantID:       probed.Id,
+			wantCount:    3,
+			wantDuration: 1000,
+		},
+		{
+			name:       "enclosure_replaced",
+			epi:        &protos.Episode{Id: protos.NewObjectID(), PodcastID: podID, Guid: "guid-3", Title: "Replaced", MP3URL: "https://example.com/3-fixed.mp3"},
+			wantID:     probed.Id,
+			wantCount:  3,
+			wantQueued: true,
+		},
+		{
+			name:         "refreshed_new",
+			epi:          &protos.Episode{Id: protos.ObjectIDFromHex("refreshed_epi"), PodcastID: podID, Guid: "guid-4", Title: "Refreshed", MP3URL: "https://example.com/4.mp3", PubDate: pubDate},
+			refreshed:    true,
+			wantID:       protos.ObjectIDFromHex("refreshed_epi"),
+			wantCount:    4,
+			wantAdded:    true,
+			wantQueued:   true,
+			wantAnalyzed: true,
+		},
+		{
+			name:       "refreshed_old_enclosure_replaced",
+			epi:        &protos.Episode{Id: protos.NewObjectID(), PodcastID: podID, Guid: "guid-3", Title: "Replaced Again", MP3URL: "https://example.com/3-moved.mp3", PubDate: oldPubDate},
+			refreshed:  true,
+			wantID:     probed.Id,
+			wantCount:  4,
+			wantQueued: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, err := reconcileEpisode(mockDB, tt.epi, tt.refreshed)
+			if err != nil {
+				t.Fatalf("reconcileEpisode() error = %v", err)
+			}
+			if added != tt.wantAdded {
+				t.Errorf("reconcileEpisode() added = %v, want %v", added, tt.wantAdded)
+			}
+			if tt.epi.Id.GetHex() != tt.wantID.GetHex() {
+				t.Errorf("reconcileEpisode() id = %v, want %v", tt.epi.Id, tt.wantID)
+			}
+			var episodes []*protos.Episode
+			err = mockDB.FindAll(database.ColEpisode, &episodes, &db.Filter{"podcastid": podID}, nil)
+			if err != nil {
+				t.Fatalf("reconcileEpisode() error finding episodes: %v", err)
+			}
+			if len(episodes) != tt.wantCount {
+				t.Errorf("reconcileEpisode() episode count = %v, want %v", len(episodes), tt.wantCount)
+			}
+			found, err := FindEpisodeByID(mockDB, tt.wantID)
+			if err != nil || found.Title != tt.epi.Title || found.DurationMillis != tt.wantDuration {
+				t.Errorf("reconcileEpisode() stored episode = %v, error = %v", found, err)
+			}
+			// chapter extraction is queued for new enclosures
+			err = mockDB.FindOne(database.ColChapterJob, &models.ChapterJob{}, &db.Filter{"episode_id": tt.wantID, "url": tt.epi.MP3URL}, nil)
+			if (err == nil) != tt.wantQueued {
+				t.Errorf("reconcileEpisode() chapters queued = %v, want %v", err == nil, tt.wantQueued)
+			}
+			// only recent enclosures found refreshing the feed are analyzed
+			err = mockDB.FindOne(database.ColFingerprint, &models.FingerprintJob{}, &db.Filter{"episode_id": tt.wantID, "url": tt.epi.MP3URL}, nil)
+			if (err == nil) != tt.wantAnalyzed {
+				t.Errorf("reconcileEpisode() fingerprint queued = %v, want %v", err == nil, tt.wantAnalyzed)
+			}
+			err = mockDB.FindOne(database.ColWaveformJob, &models.WaveformJob{}, &db.Filter{"episode_id": tt.wantID, "url": tt.epi.MP3URL}, nil)
+			if (err == nil) != tt.wantAnalyzed {
+				t.Errorf("reconcileEpisode() waveform queued = %v, want %v", err == nil, tt.wantAnalyzed)
+			}
+		})
+	}
+}
+
 func Test_parseRSS(t *testing.T) {
 	rssFile, err := os.Open("./test/feed.xml")
 	if err != nil {
@@ -141,7 +226,7 @@ func Test_parseRSS(t *testing.T) {
 			args: args{
 				r: rssFile,
 			},
-			want:    &models.RSSPodcast{ID: primitive.ObjectID{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0}, Title: "Go Time", Author: "Changelog Media", Type: "", Subtitle: "", Summary: "Your source for diverse discussions from around the Go community  Panelists include Mat Ryer, Ashley McNamara, Johnny Boursiquot, Carmen Andoh, Jaana B. Dogan (JBD), Mark Bates, and Jon Calhoun.\n\n\t\tThis show records LIVE every Tuesday at 3pm US Eastern. Join the Golang community and chat with us during the show in the #gotimefm channel of Gophers slack.\n\n\t\tWe discuss cloud infrastructure, distributed systems, microservices, Kubernetes, Docker... oh and also Go!\n\n\t\tSome people search for GoTime or GoTimeFM and can't find the show, so now the strings GoTime and GoTimeFM are in our description too.", Link: "https://changelog.com/gotime", Image: models.Image{Title: "", URL: ""}, Explicit: "no", Language: "en-us", Keywords: "go, golang, open source, software, development, devops, architecture, docker, kubernetes", Category: []models.Category{models.Category{Text: "Technology", Category: []models.Category{models.Category{Text: "Software How-To", Category: []models.Category(nil)}, models.Category{Text: "Tech News", Category: []models.Category(nil)}}}}, PubDate: "", LastBuildDate: "", RSSEpisodes: []models.RSSEpisode{models.RSSEpisode{ID: primitive.ObjectID{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0}, PodcastID: primitive.ObjectID{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0}, Title: "There's a lot to learn about teaching Go", Subtitle: " Mat, Jon, Johnny, & Mark", Author: "Mat Ryer, Jon Calhoun, Johnny Boursiquot, and Mark Bates", Type: "", Image: models.EpiImage{HREF: "https://cdn.changelog.com/uploads/covers/go-time-original.png?v=63725770357"}, Thumbnail: models.EpiThumbnail{URL: ""}, PubDate: "Thu, 01 Oct 2020 15:00:00 +0000", Description: "In this episode we dive into teaching Go, asking questions like, “What techniques work well for teaching programming?”, “What role does community play in education?”, and “What are the best ways to improve at Go as a beginner/intermediate/senior dev?” ", Summary: "In this episode we dive into teaching Go, asking questions like, “What techniques work well for teaching programming?”, “What role does community play in education?”, and “What are the best ways to improve at Go as a beginner/intermediate/senior dev?” ", Season: 0, Episode: 149, Category: []models.Category(nil), Explicit: "no", Enclosure: models.Enclosure{MP3: "https://cdn.changelog.com/uploads/gotime/149/go-time-149.mp3"}, Duration: "1:16:18"}}, NewFeedURL: "", RSS: ""},
+			want:    &models.RSSPodcast{ID: primitive.ObjectID{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0}, Title: "Go Time", Author: "Changelog Media", Type: "", Subtitle: "", Summary: "Your source for diverse discussions from around the Go community  Panelists include Mat Ryer, Ashley McNamara, Johnny Boursiquot, Carmen Andoh, Jaana B. Dogan (JBD), Mark Bates, and Jon Calhoun.\n\n\t\tThis show records LIVE every Tuesday at 3pm US Eastern. Join the Golang community and chat with us during the show in the #gotimefm channel of Gophers slack.\n\n\t\tWe discuss cloud infrastructure, distributed systems, microservices, Kubernetes, Docker... oh and also Go!\n\n\t\tSome people search for GoTime or GoTimeFM and can't find the show, so now the strings GoTime and GoTimeFM are in our description too.", Link: "https://changelog.com/gotime", Image: models.Image{Title: "", URL: ""}, Explicit: "no", Language: "en-us", Keywords: "go, golang, open source, software, development, devops, architecture, docker, kubernetes", Category: []models.Category{models.Category{Text: "Technology", Category: []models.Category{models.Category{Text: "Software How-To", Category: []models.Category(nil)}, models.Category{Text: "Tech News", Category: []models.Category(nil)}}}}, PubDate: "", LastBuildDate: "", RSSEpisodes: []models.RSSEpisode{models.RSSEpisode{ID: primitive.ObjectID{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0}, PodcastID: primitive.ObjectID{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0}, Title: "There's a lot to learn about teaching Go", Subtitle: " Mat, Jon, Johnny, & Mark", Author: "Mat Ryer, Jon Calhoun, Johnny Boursiquot, and Mark Bates", Type: "", Image: models.EpiImage{HREF: "https://cdn.changelog.com/uploads/covers/go-time-original.png?v=63725770357"}, Thumbnail: models.EpiThumbnail{URL: ""}, PubDate: "Thu, 01 Oct 2020 15:00:00 +0000", Description: "In this episode we dive into teaching Go, asking questions like, “What techniques work well for teaching programming?”, “What role does community play in education?”, and “What are the best ways to improve at Go as a beginner/intermediate/senior dev?” ", Summary: "In this episode we dive into teaching Go, asking questions like, “What techniques work well for teaching programming?”, “What role does community play in education?”, and “What are the best ways to improve at Go as a beginner/intermediate/senior dev?” ", Season: 0, Episode: 149, Category: []models.Category(nil), Explicit: "no", Enclosure: models.Enclosure{MP3: "https://cdn.changelog.com/uploads/gotime/149/go-time-149.mp3", Type: "audio/mpeg", Length: "73524584"}, Duration: "1:16:18", GUID: "changelog.com/2/1057"}}, NewFeedURL: "", AtomLinks: []models.AtomLink{models.AtomLink{Rel: "self", Href: "https://changelog.com/gotime/feed", Type: "application/rss+xml"}, models.AtomLink{Rel: "alternate", Href: "https://changelog.com/gotime", Type: "text/html"}}, RSS: ""},
 			wantErr: false,
 		},
 	}
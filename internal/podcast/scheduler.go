@@ -0,0 +1,360 @@
+package podcast
+
+import (
+	"container/heap"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+const (
+	// bounds of the interval between checks of a feed
+	minRefreshInterval = time.Minute * 15
+	maxRefreshInterval = time.Hour * 24
+
+	// number of the latest episodes used to determine how often a podcast publishes
+	cadenceSamples = 10
+	// a feed is checked this many times within its typical gap between episodes
+	cadenceDivisor = 8
+
+	// how often the scheduler looks for podcasts that are not yet scheduled
+	rescanInterval = time.Minute * 15
+
+	// lastResult of a successful check
+	resultSuccess = "success"
+)
+
+// FindFeedSchedule finds the refresh schedule of the podcast's feed via podcast id
+func FindFeedSchedule(dbClient db.Database, podID *protos.ObjectID) (*protos.FeedSchedule, error) {
+	schedule := &protos.FeedSchedule{}
+	err := dbClient.FindOne(database.ColFeedSchedule, schedule, &db.Filter{"podcastid": podID}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("FindFeedSchedule() error: %v", err)
+	}
+	return schedule, nil
+}
+
+// UpsertFeedSchedule upserts the refresh schedule of the podcast's feed
+func UpsertFeedSchedule(dbClient db.Database, schedule *protos.FeedSchedule) error {
+	err := dbClient.Upsert(database.ColFeedSchedule, schedule, &db.Filter{"podcastid": schedule.PodcastID})
+	if err != nil {
+		return fmt.Errorf("error upserting feed schedule: %v", err)
+	}
+	return nil
+}
+
+// Scheduler refreshes each podcast's feed on its own interval, derived from how often
+// the podcast publishes, the refresh hints within the feed and recent failures.
+// Due feeds are handed to a bounded pool of workers in order of their next check
+type Scheduler struct {
+	dbClient db.Database
+	creds    *Credentials // decrypts the feeds of private podcasts, nil if they are disabled
+	workers  int
+	queue    scheduleQueue
+	queued   map[string]bool // podcasts either queued or being refreshed
+	jobs     chan *scheduledFeed
+	results  chan *scheduledFeed
+	stop     chan struct{}
+}
+
+// NewScheduler creates a scheduler that refreshes at most workers feeds at a time,
+// private podcasts are refreshed with their owner's credentials from creds
+func NewScheduler(dbClient db.Database, creds *Credentials, workers int) *Scheduler {
+	if workers < 1 {
+		workers = 1
+	}
+	return &Scheduler{
+		dbClient: dbClient,
+		creds:    creds,
+		workers:  workers,
+		queued:   make(map[string]bool),
+		jobs:     make(chan *scheduledFeed),
+		results:  make(chan *scheduledFeed),
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start runs the scheduler, blocks until Stop is called
+func (s *Scheduler) Start() {
+	for i := 0; i < s.workers; i++ {
+		go s.work()
+	}
+	defer close(s.jobs)
+
+	s.scan()
+	rescan := time.NewTicker(rescanInterval)
+	defer rescan.Stop()
+
+	for {
+		// only offer the next feed to the workers once it is due,
+		// otherwise wait until it is
+		var jobs chan *scheduledFeed
+		var next *scheduledFeed
+		var timer *time.Timer
+		var wait <-chan time.Time
+		if s.queue.Len() > 0 {
+			next = s.queue[0]
+			if d := time.Until(next.next); d > 0 {
+				timer = time.NewTimer(d)
+				wait = timer.C
+			} else {
+				jobs = s.jobs
+			}
+		}
+
+		select {
+		case jobs <- next:
+			heap.Pop(&s.queue)
+		case feed := <-s.results:
+			if feed.gone {
+				delete(s.queued, feed.schedule.PodcastID.GetHex())
+			} else {
+				heap.Push(&s.queue, feed)
+			}
+		case <-wait:
+		case <-rescan.C:
+			s.scan()
+		case <-s.stop:
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// Stop stops the scheduler and its workers, feeds currently being refreshed are finished
+func (s *Scheduler) Stop() {
+	close(s.stop)
+}
+
+// scan queues every podcast which is not yet scheduled, using its stored schedule if there is one
+func (s *Scheduler) scan() {
+	for start, end := 0, 10; ; start, end = end, end+10 {
+		podcasts, err := FindPodcastsByRange(s.dbClient, start, end)
+		if err != nil {
+			log.Println("Scheduler.scan() error finding podcasts:", err)
+			return
+		}
+		if len(podcasts) == 0 {
+			return
+		}
+		for _, pod := range podcasts {
+			if s.queued[pod.Id.GetHex()] {
+				continue
+			}
+			feed := &scheduledFeed{next: time.Now()}
+			schedule, err := FindFeedSchedule(s.dbClient, pod.Id)
+			if err != nil {
+				// never scheduled, check right away
+				schedule = &protos.FeedSchedule{PodcastID: pod.Id}
+			} else if next, err := ptypes.Timestamp(schedule.NextCheck); err == nil {
+				feed.next = next
+			}
+			feed.schedule = schedule
+			s.queued[pod.Id.GetHex()] = true
+			heap.Push(&s.queue, feed)
+		}
+	}
+}
+
+// work refreshes the feeds it receives until the jobs channel is closed
+func (s *Scheduler) work() {
+	for feed := range s.jobs {
+		s.refresh(feed)
+		select {
+		case s.results <- feed:
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// refresh updates the podcast via its feed, then records the outcome and its next check
+func (s *Scheduler) refresh(feed *scheduledFeed) {
+	schedule := feed.schedule
+	pod, err := FindPodcastByID(s.dbClient, schedule.PodcastID)
+	if err != nil {
+		// the podcast was removed, the next scan adds it back if it wasn't
+		log.Println("Scheduler.refresh() error finding podcast:", err)
+		feed.gone = true
+		return
+	}
+
+	if pod.Private {
+		err = updatePrivatePodcast(s.dbClient, s.creds, pod)
+	} else {
+		err = updatePodcast(s.dbClient, pod)
+	}
+	health, healthErr := recordFeedHealth(s.dbClient, pod, err)
+	if healthErr != nil {
+		log.Println("Scheduler.refresh() error:", healthErr)
+	}
+	schedule.LastCheck = ptypes.TimestampNow()
+	if err != nil {
+		log.Printf("Scheduler.refresh() error updating podcast %v: %v\n", pod.Title, err)
+		schedule.LastResult = err.Error()
+		schedule.Failures++
+	} else {
+		schedule.LastResult = resultSuccess
+		schedule.Failures = 0
+	}
+
+	interval := s.interval(pod.Id, int(schedule.Failures))
+	if health.Quarantined && interval < quarantineInterval {
+		interval = quarantineInterval
+	}
+	// updates are pushed, only poll in case one was missed
+	if webSubActive(s.dbClient, pod.Id) && interval < webSubFallbackInterval {
+		interval = webSubFallbackInterval
+	}
+	feed.next = time.Now().Add(interval)
+	schedule.NextCheck, _ = ptypes.TimestampProto(feed.next)
+	schedule.IntervalMillis = interval.Milliseconds()
+
+	err = UpsertFeedSchedule(s.dbClient, schedule)
+	if err != nil {
+		log.Println("Scheduler.refresh() error:", err)
+	}
+}
+
+// interval gathers the podcast's latest publish dates and feed hints to determine its next interval
+func (s *Scheduler) interval(podID *protos.ObjectID, failures int) time.Duration {
+	var pubDates []time.Time
+	episodes, err := FindEpisodesByRange(s.dbClient, podID, 0, cadenceSamples)
+	if err != nil {
+		log.Println("Scheduler.interval() error finding episodes:", err)
+	}
+	for _, epi := range episodes {
+		pubDate, err := ptypes.Timestamp(epi.PubDate)
+		if err == nil {
+			pubDates = append(pubDates, pubDate)
+		}
+	}
+	state, err := FindFetchState(s.dbClient, podID)
+	if err != nil {
+		state = nil
+	}
+	return nextInterval(pubDates, state, failures)
+}
+
+// nextInterval returns how long to wait before checking a feed again.
+// The interval is a fraction of the podcast's typical gap between episodes, never shorter
+// than the feed's own <ttl> or <sy:updatePeriod> and doubled for every consecutive failure
+func nextInterval(pubDates []time.Time, state *models.FetchState, failures int) time.Duration {
+	interval := maxRefreshInterval
+	if cadence := publishCadence(pubDates); cadence > 0 {
+		interval = cadence / cadenceDivisor
+	}
+	if interval < minRefreshInterval {
+		interval = minRefreshInterval
+	}
+	if interval > maxRefreshInterval {
+		interval = maxRefreshInterval
+	}
+
+	// the feed asked not to be checked more often than its hint
+	limit := maxRefreshInterval
+	if hint := feedHintInterval(state); hint > interval {
+		interval = hint
+		limit = hint
+	}
+
+	// back off exponentially on consecutive failures
+	for i := 0; i < failures && interval < limit; i++ {
+		interval *= 2
+	}
+	if interval > limit {
+		interval = limit
+	}
+	return interval
+}
+
+// publishCadence returns the median gap between the publish dates, 0 if there are not enough
+func publishCadence(pubDates []time.Time) time.Duration {
+	dates := make([]time.Time, len(pubDates))
+	copy(dates, pubDates)
+	sort.Slice(dates, func(i, j int) bool { return dates[i].After(dates[j]) })
+
+	var gaps []time.Duration
+	for i := 1; i < len(dates); i++ {
+		gap := dates[i-1].Sub(dates[i])
+		if gap > 0 {
+			gaps = append(gaps, gap)
+		}
+	}
+	if len(gaps) == 0 {
+		return 0
+	}
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i] < gaps[j] })
+	return gaps[len(gaps)/2]
+}
+
+// feedHintInterval returns the minimum interval requested by the feed's <ttl>
+// or <sy:updatePeriod> & <sy:updateFrequency>, whichever is longer
+func feedHintInterval(state *models.FetchState) time.Duration {
+	if state == nil {
+		return 0
+	}
+	ttl := time.Duration(state.TTL) * time.Minute
+
+	var period time.Duration
+	switch strings.ToLower(strings.TrimSpace(state.UpdatePeriod)) {
+	case "hourly":
+		period = time.Hour
+	case "daily":
+		period = time.Hour * 24
+	case "weekly":
+		period = time.Hour * 24 * 7
+	case "monthly":
+		period = time.Hour * 24 * 30
+	case "yearly":
+		period = time.Hour * 24 * 365
+	}
+	if state.UpdateFrequency > 1 {
+		period /= time.Duration(state.UpdateFrequency)
+	}
+
+	if ttl > period {
+		return ttl
+	}
+	return period
+}
+
+// scheduledFeed is a podcast's schedule within the scheduler's queue
+type scheduledFeed struct {
+	schedule *protos.FeedSchedule
+	next     time.Time
+	gone     bool // podcast no longer exists
+}
+
+// scheduleQueue is a min-heap of feeds ordered by their next check
+type scheduleQueue []*scheduledFeed
+
+func (q scheduleQueue) Len() int { return len(q) }
+
+func (q scheduleQueue) Less(i, j int) bool { return q[i].next.Before(q[j].next) }
+
+func (q scheduleQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *scheduleQueue) Push(x interface{}) { *q = append(*q, x.(*scheduledFeed)) }
+
+func (q *scheduleQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	feed := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return feed
+}
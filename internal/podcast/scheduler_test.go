@@ -0,0 +1,148 @@
+package podcast
+
+import (
+	"container/heap"
+	"testing"
+	"time"
+
+	"github.com/sschwartz96/stockpile/mock"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+func Test_nextInterval(t *testing.T) {
+	now := time.Now()
+	// weekly returns a pub date for each of the last n weeks
+	weekly := func(n int) []time.Time {
+		dates := make([]time.Time, n)
+		for i := range dates {
+			dates[i] = now.Add(-time.Hour * 24 * 7 * time.Duration(i))
+		}
+		return dates
+	}
+	type args struct {
+		pubDates []time.Time
+		state    *models.FetchState
+		failures int
+	}
+	tests := []struct {
+		name string
+		args args
+		want time.Duration
+	}{
+		{
+			name: "no_episodes",
+			args: args{},
+			want: maxRefreshInterval,
+		},
+		{
+			name: "weekly",
+			args: args{pubDates: weekly(10)},
+			want: time.Hour * 21,
+		},
+		{
+			name: "monthly",
+			args: args{pubDates: []time.Time{now, now.Add(-time.Hour * 24 * 30)}},
+			want: maxRefreshInterval,
+		},
+		{
+			name: "hourly",
+			args: args{pubDates: []time.Time{now, now.Add(-time.Hour), now.Add(-time.Hour * 2)}},
+			want: minRefreshInterval,
+		},
+		{
+			name: "daily",
+			args: args{pubDates: []time.Time{now.Add(-time.Hour * 48), now, now.Add(-time.Hour * 24)}},
+			want: time.Hour * 3,
+		},
+		{
+			name: "ttl",
+			args: args{pubDates: []time.Time{now, now.Add(-time.Hour)}, state: &models.FetchState{TTL: 60}},
+			want: time.Hour,
+		},
+		{
+			name: "update_period",
+			args: args{pubDates: []time.Time{now, now.Add(-time.Hour)}, state: &models.FetchState{UpdatePeriod: "daily", UpdateFrequency: 2}},
+			want: time.Hour * 12,
+		},
+		{
+			name: "update_period_beyond_max",
+			args: args{state: &models.FetchState{UpdatePeriod: "weekly"}},
+			want: time.Hour * 24 * 7,
+		},
+		{
+			name: "backoff",
+			args: args{pubDates: []time.Time{now, now.Add(-time.Hour)}, failures: 3},
+			want: minRefreshInterval * 8,
+		},
+		{
+			name: "backoff_capped",
+			args: args{pubDates: []time.Time{now, now.Add(-time.Hour)}, failures: 20},
+			want: maxRefreshInterval,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextInterval(tt.args.pubDates, tt.args.state, tt.args.failures); got != tt.want {
+				t.Errorf("nextInterval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_scheduleQueue(t *testing.T) {
+	now := time.Now()
+	var q scheduleQueue
+	for _, d := range []time.Duration{time.Hour, -time.Hour, time.Minute, 0} {
+		heap.Push(&q, &scheduledFeed{next: now.Add(d)})
+	}
+	last := time.Time{}
+	for q.Len() > 0 {
+		feed := heap.Pop(&q).(*scheduledFeed)
+		if feed.next.Before(last) {
+			t.Errorf("scheduleQueue popped %v after %v", feed.next, last)
+		}
+		last = feed.next
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	server := createFeedServer(t)
+	defer server.Close()
+
+	mockDB := mock.CreateDB()
+	goodPod := &protos.Podcast{Id: protos.NewObjectID(), Title: "Go Time", Rss: server.URL + "/feed"}
+	badPod := &protos.Podcast{Id: protos.NewObjectID(), Title: "Missing", Rss: server.URL + "/missing"}
+	insertOrFail(t, mockDB, database.ColPodcast, goodPod)
+	insertOrFail(t, mockDB, database.ColPodcast, badPod)
+	insertOrFail(t, mockDB, database.ColEpisode, &protos.Episode{Id: protos.NewObjectID(), PodcastID: protos.NewObjectID()})
+
+	scheduler := NewScheduler(mockDB, nil, 2)
+	go scheduler.Start()
+	defer scheduler.Stop()
+
+	// waitForSchedule polls until the podcast's feed has been checked
+	waitForSchedule := func(podID *protos.ObjectID) *protos.FeedSchedule {
+		deadline := time.Now().Add(time.Second * 10)
+		for time.Now().Before(deadline) {
+			schedule, err := FindFeedSchedule(mockDB, podID)
+			if err == nil && schedule.LastCheck != nil {
+				return schedule
+			}
+			time.Sleep(time.Millisecond * 50)
+		}
+		t.Fatalf("Scheduler did not check podcast %v", podID)
+		return nil
+	}
+
+	good := waitForSchedule(goodPod.Id)
+	if good.LastResult != resultSuccess || good.Failures != 0 || good.IntervalMillis <= 0 || good.NextCheck == nil {
+		t.Errorf("Scheduler schedule of good feed = %v", good)
+	}
+
+	bad := waitForSchedule(badPod.Id)
+	if bad.LastResult == resultSuccess || bad.Failures != 1 {
+		t.Errorf("Scheduler schedule of bad feed = %v", bad)
+	}
+}
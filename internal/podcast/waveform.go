@@ -0,0 +1,406 @@
+package podcast
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"math"
+	"time"
+
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/protos"
+	"github.com/tcolgate/mp3"
+)
+
+const (
+	// waveformRate is the peaks per second of audio
+	waveformRate = 10
+	// silenceThreshold is the rms of the granule levels, as 16 bit samples, below which audio is silent, -45 dBFS
+	silenceThreshold = 184
+	// minSilenceMillis is the shortest silence in the silence map
+	minSilenceMillis = 2000
+
+	// how often queued waveforms are checked for
+	waveformInterval = 10 * time.Minute
+	// waveforms per check
+	waveformBatch = 10
+	// failed waveforms are retried this many times, backing off by waveformInterval each time
+	maxWaveformAttempts = 3
+)
+
+// queueWaveform queues generating the waveform of the episode's enclosure if it's an mp3
+func queueWaveform(dbClient db.Database, epi *protos.Episode) {
+	if !isMP3Enclosure(epi) {
+		return
+	}
+	job := &models.WaveformJob{EpisodeID: epi.Id, URL: epi.MP3URL, Due: time.Now()}
+	err := dbClient.Upsert(database.ColWaveformJob, job, &db.Filter{"episode_id": epi.Id})
+	if err != nil {
+		log.Println("queueWaveform() error upserting job:", err)
+	}
+}
+
+// FindWaveform finds the waveform & silence map of the episode's enclosure
+func FindWaveform(dbClient db.Database, epiID *protos.ObjectID) (*protos.Waveform, error) {
+	waveform := &protos.Waveform{}
+	err := dbClient.FindOne(database.ColWaveform, waveform, &db.Filter{"episodeid": epiID}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("FindWaveform() error: %v", err)
+	}
+	return waveform, nil
+}
+
+// UpsertWaveform inserts or replaces the waveform of its episode
+func UpsertWaveform(dbClient db.Database, waveform *protos.Waveform) error {
+	err := dbClient.Upsert(database.ColWaveform, waveform, &db.Filter{"episodeid": waveform.EpisodeID})
+	if err != nil {
+		return fmt.Errorf("UpsertWaveform() error: %v", err)
+	}
+	return nil
+}
+
+// GenerateWaveform downloads the mp3 enclosure at url, returning its waveform and silence map. Its frames
+// aren't decoded, the level of each granule is estimated from its side info by granuleLevels
+func GenerateWaveform(url string) (*protos.Waveform, error) {
+	resp, err := getEnclosure(url)
+	if err != nil {
+		return nil, fmt.Errorf("GenerateWaveform() error: %v", err)
+	}
+	defer resp.Body.Close()
+	body := bufio.NewReader(io.LimitReader(resp.Body, maxDecodeSize))
+	// sync bytes within the ID3 tag's pictures would be taken for frames
+	head, _ := body.Peek(10)
+	_, err = body.Discard(int(id3Size(head)))
+	if err != nil {
+		return nil, fmt.Errorf("GenerateWaveform() error skipping ID3 tag: %v", err)
+	}
+
+	var w *waveformBuilder
+	var frame mp3.Frame
+	skipped := 0
+	dec := mp3.NewDecoder(body)
+	for {
+		err = dec.Decode(&frame, &skipped)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("GenerateWaveform() error reading frame: %v", err)
+		}
+		if w == nil {
+			if isHeaderFrame(&frame) {
+				continue
+			}
+			w = newWaveformBuilder(int(frame.Header().SampleRate()))
+		}
+		levels, err := granuleLevels(&frame)
+		if err != nil {
+			return nil, fmt.Errorf("GenerateWaveform() error: %v", err)
+		}
+		for _, level := range levels {
+			w.write(level, granuleSamples)
+		}
+	}
+	if w == nil {
+		return nil, errors.New("GenerateWaveform() error: no MPEG audio frames found")
+	}
+	return w.finish(w.samples * 1000 / int64(w.sampleRate)), nil
+}
+
+// isHeaderFrame returns whether the frame holds a Xing/Info or VBRI header instead of audio
+func isHeaderFrame(frame *mp3.Frame) bool {
+	data, _ := ioutil.ReadAll(frame.Reader())
+	if _, ok := xingSamples(data, frame); ok {
+		return true
+	}
+	_, ok := vbriSamples(data, frame)
+	return ok
+}
+
+// granuleSamples is the samples of each channel in a layer III granule
+const granuleSamples = 576
+
+// huffmanMax is the largest value of each huffman table of the big values, the tables from 16 add linbits to 15
+var huffmanMax = [32]int{
+	0, 1, 2, 2, 0, 3, 3, 5, 5, 5, 7, 7, 7, 15, 0, 15,
+	16, 18, 22, 30, 78, 270, 1038, 8206, 30, 46, 78, 142, 270, 526, 2062, 8206,
+}
+
+// granuleLevels estimates the peak amplitude, as a 16 bit sample, of each granule of the layer III frame from its
+// side info. A channel's spectral values are scaled by its global gain and bounded by the largest value of the
+// huffman tables selected for them, scalefactors only lower them. A granule with nothing coded is silent
+func granuleLevels(frame *mp3.Frame) ([]float64, error) {
+	h := frame.Header()
+	if h.Layer() != mp3.Layer3 {
+		return nil, fmt.Errorf("granuleLevels() error: unsupported %v", h.Layer())
+	}
+	mpeg1 := h.Version() == mp3.MPEG1
+	channels := 2
+	if h.ChannelMode() == mp3.SingleChannel {
+		channels = 1
+	}
+
+	r := &bitReader{data: frame.SideInfo()}
+	// main_data_begin, private bits & scfsi
+	switch {
+	case mpeg1 && channels == 1:
+		r.skip(9 + 5 + 4)
+	case mpeg1:
+		r.skip(9 + 3 + 8)
+	case channels == 1:
+		r.skip(8 + 1)
+	default:
+		r.skip(8 + 2)
+	}
+	levels := make([]float64, frame.Samples()/granuleSamples)
+	for gr := range levels {
+		for ch := 0; ch < channels; ch++ {
+			part23Length := r.read(12)
+			bigValues := r.read(9)
+			globalGain := r.read(8)
+			// scalefac_compress
+			if mpeg1 {
+				r.skip(4)
+			} else {
+				r.skip(9)
+			}
+			var tables []int
+			if r.read(1) == 1 {
+				// block type & mixed block flag, 2 table selects, subblock gains
+				r.skip(3)
+				tables = []int{r.read(5), r.read(5)}
+				r.skip(9)
+			} else {
+				// 3 table selects, region counts
+				tables = []int{r.read(5), r.read(5), r.read(5)}
+				r.skip(7)
+			}
+			// preflag, scalefac_scale & count1table_select
+			if mpeg1 {
+				r.skip(3)
+			} else {
+				r.skip(2)
+			}
+
+			if part23Length == 0 {
+				continue
+			}
+			// the values after the big values are at most 1
+			max := 1
+			for _, t := range tables {
+				if bigValues > 0 && huffmanMax[t] > max {
+					max = huffmanMax[t]
+				}
+			}
+			level := 32768 * math.Pow(2, float64(globalGain-210)/4) * math.Pow(float64(max), 4.0/3)
+			levels[gr] = math.Max(levels[gr], math.Min(32768, level))
+		}
+	}
+	return levels, nil
+}
+
+// bitReader reads big endian bit fields
+type bitReader struct {
+	data []byte
+	pos  int
+}
+
+// read reads the next n bits, bits past the end of data are 0
+func (r *bitReader) read(n int) int {
+	v := 0
+	for i := 0; i < n; i++ {
+		v <<= 1
+		if r.pos/8 < len(r.data) {
+			v |= int(r.data[r.pos/8]>>(7-uint(r.pos%8))) & 1
+		}
+		r.pos++
+	}
+	return v
+}
+
+// skip skips the next n bits
+func (r *bitReader) skip(n int) {
+	r.pos += n
+}
+
+// waveformBuilder builds the waveform of audio, the peak of each 1/waveformRate seconds,
+// and its silence map, the periods of at least minSilenceMillis with an rms level below silenceThreshold
+type waveformBuilder struct {
+	sampleRate int
+	samples    int64
+
+	// the period being measured
+	peak       float64
+	sumSquares float64
+	count      int // samples
+
+	periods    int
+	silentFrom int // period the current silence started at, -1 if not silent
+	waveform   *protos.Waveform
+}
+
+func newWaveformBuilder(sampleRate int) *waveformBuilder {
+	return &waveformBuilder{
+		sampleRate: sampleRate,
+		silentFrom: -1,
+		waveform:   &protos.Waveform{PeaksPerSecond: waveformRate, Peaks: []byte{}},
+	}
+}
+
+// write adds the next samples, of the peak amplitude
+func (w *waveformBuilder) write(amplitude float64, samples int) {
+	w.peak = math.Max(w.peak, amplitude)
+	w.sumSquares += amplitude * amplitude * float64(samples)
+	w.count += samples
+	w.samples += int64(samples)
+	// the period is complete once the samples reach the following one, a period is longer than a granule
+	if w.samples*waveformRate/int64(w.sampleRate) != (w.samples-int64(samples))*waveformRate/int64(w.sampleRate) {
+		w.endPeriod()
+	}
+}
+
+// endPeriod adds the period's peak, scaled to 0-255, and starts or ends a silence
+func (w *waveformBuilder) endPeriod() {
+	if w.count == 0 {
+		return
+	}
+	w.waveform.Peaks = append(w.waveform.Peaks, byte(math.Min(255, math.Round(w.peak*255/32768))))
+	if math.Sqrt(w.sumSquares/float64(w.count)) < silenceThreshold {
+		if w.silentFrom < 0 {
+			w.silentFrom = w.periods
+		}
+	} else {
+		w.endSilence()
+	}
+	w.periods++
+	w.peak, w.sumSquares, w.count = 0, 0, 0
+}
+
+// endSilence adds the current silence to the silence map if it's long enough
+func (w *waveformBuilder) endSilence() {
+	if w.silentFrom >= 0 {
+		start, end := periodMillis(w.silentFrom), periodMillis(w.periods)
+		if end-start >= minSilenceMillis {
+			w.waveform.Silences = append(w.waveform.Silences, &protos.Silence{StartMillis: start, EndMillis: end})
+		}
+	}
+	w.silentFrom = -1
+}
+
+// finish ends the final period & silence, returning the waveform of audio of durationMillis
+func (w *waveformBuilder) finish(durationMillis int64) *protos.Waveform {
+	w.endPeriod()
+	w.endSilence()
+	// the final period may be partial
+	if n := len(w.waveform.Silences); n > 0 && w.waveform.Silences[n-1].EndMillis > durationMillis {
+		w.waveform.Silences[n-1].EndMillis = durationMillis
+	}
+	w.waveform.DurationMillis = durationMillis
+	return w.waveform
+}
+
+// periodMillis is the offset in millis of the i'th period of the waveform
+func periodMillis(i int) int64 {
+	return int64(i) * 1000 / waveformRate
+}
+
+// WaveformGenerator generates the waveforms of the enclosures of new episodes of subscribed podcasts in the background
+type WaveformGenerator struct {
+	dbClient db.Database
+	stop     chan struct{}
+}
+
+// NewWaveformGenerator creates a waveform generator
+func NewWaveformGenerator(dbClient db.Database) *WaveformGenerator {
+	return &WaveformGenerator{dbClient: dbClient, stop: make(chan struct{})}
+}
+
+// Start generates the queued waveforms every waveformInterval, blocks until Stop is called
+func (g *WaveformGenerator) Start() {
+	g.generateQueued()
+	ticker := time.NewTicker(waveformInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			g.generateQueued()
+		case <-g.stop:
+			return
+		}
+	}
+}
+
+// Stop stops the generator, the waveform being generated is finished
+func (g *WaveformGenerator) Stop() {
+	close(g.stop)
+}
+
+// generateQueued generates the queued waveforms that are due, one at a time as each enclosure is downloaded whole
+func (g *WaveformGenerator) generateQueued() {
+	var jobs []*models.WaveformJob
+	opts := db.CreateOptions().SetSort("due", 1).SetLimit(waveformBatch)
+	err := g.dbClient.FindAll(database.ColWaveformJob, &jobs, nil, opts)
+	if err != nil {
+		log.Println("WaveformGenerator.generateQueued() error finding jobs:", err)
+		return
+	}
+	now := time.Now()
+	for _, job := range jobs {
+		select {
+		case <-g.stop:
+			return
+		default:
+		}
+		if job.Due.After(now) {
+			break
+		}
+		err = waveformJob(g.dbClient, job)
+		if err != nil {
+			log.Printf("WaveformGenerator.generateQueued() error generating waveform of %v: %v\n", job.URL, err)
+		}
+	}
+}
+
+// waveformJob generates & stores the waveform of the job's enclosure
+func waveformJob(dbClient db.Database, job *models.WaveformJob) error {
+	epi, err := FindEpisodeByID(dbClient, job.EpisodeID)
+	// the enclosure may have been replaced, its own job is queued
+	if err != nil || epi.MP3URL != job.URL || !hasSubscribers(dbClient, epi.PodcastID) {
+		return deleteWaveformJob(dbClient, job)
+	}
+
+	waveform, err := GenerateWaveform(job.URL)
+	if err == nil {
+		waveform.EpisodeID = job.EpisodeID
+		err = UpsertWaveform(dbClient, waveform)
+	}
+	if err != nil {
+		job.Attempts++
+		if job.Attempts >= maxWaveformAttempts {
+			if deleteErr := deleteWaveformJob(dbClient, job); deleteErr != nil {
+				log.Println("waveformJob() error:", deleteErr)
+			}
+			return fmt.Errorf("waveformJob() giving up: %v", err)
+		}
+		job.Due = time.Now().Add(waveformInterval * time.Duration(job.Attempts))
+		if upsertErr := dbClient.Upsert(database.ColWaveformJob, job, &db.Filter{"episode_id": job.EpisodeID, "url": job.URL}); upsertErr != nil {
+			log.Println("waveformJob() error upserting job:", upsertErr)
+		}
+		return fmt.Errorf("waveformJob() error: %v", err)
+	}
+	return deleteWaveformJob(dbClient, job)
+}
+
+// deleteWaveformJob deletes the job, unless the episode's enclosure was replaced and queued again
+func deleteWaveformJob(dbClient db.Database, job *models.WaveformJob) error {
+	err := dbClient.Delete(database.ColWaveformJob, &db.Filter{"episode_id": job.EpisodeID, "url": job.URL})
+	if err != nil {
+		return fmt.Errorf("deleteWaveformJob() error: %v", err)
+	}
+	return nil
+}
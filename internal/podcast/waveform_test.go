@@ -0,0 +1,207 @@
+package podcast
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/stockpile/mock"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+func TestGenerateWaveform(t *testing.T) {
+	sample, err := ioutil.ReadFile("./test/sample.mp3")
+	if err != nil {
+		t.Fatalf("TestGenerateWaveform() error reading sample: %v", err)
+	}
+	frames := splitFrames(t, sample)
+	// 230 silent frames are 6 seconds, 40 are 1 second
+	enclosures := map[string][]byte{
+		"/sample.mp3":   sample,
+		"/preroll.mp3":  spliceSilence(frames, 0, 230),
+		"/pause.mp3":    spliceSilence(frames, len(frames)/2, 40),
+		"/trailing.mp3": spliceSilence(frames, len(frames), 230),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		enclosure, ok := enclosures[req.URL.Path]
+		if !ok {
+			http.NotFound(res, req)
+			return
+		}
+		res.Write(enclosure)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		path         string
+		wantDuration int64
+		wantSilences []*protos.Silence
+		wantErr      bool
+	}{
+		{name: "sample", path: "/sample.mp3", wantDuration: 10031},
+		{name: "preroll", path: "/preroll.mp3", wantDuration: 16039, wantSilences: []*protos.Silence{{StartMillis: 0, EndMillis: 6000}}},
+		{name: "short_pause", path: "/pause.mp3", wantDuration: 11075},
+		{name: "trailing", path: "/trailing.mp3", wantDuration: 16039, wantSilences: []*protos.Silence{{StartMillis: 10100, EndMillis: 16039}}},
+		{name: "not_found", path: "/missing.mp3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GenerateWaveform(server.URL + tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GenerateWaveform() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.DurationMillis != tt.wantDuration || got.PeaksPerSecond != waveformRate {
+				t.Errorf("GenerateWaveform() duration = %v, peaks per second = %v, want %v", got.DurationMillis, got.PeaksPerSecond, tt.wantDuration)
+			}
+			// a peak every period, the last partial
+			if want := (tt.wantDuration + 99) / 100; int64(len(got.Peaks)) != want {
+				t.Errorf("GenerateWaveform() %v peaks, want %v", len(got.Peaks), want)
+			}
+			if !reflect.DeepEqual(got.Silences, tt.wantSilences) {
+				t.Errorf("GenerateWaveform() silences = %v, want %v", got.Silences, tt.wantSilences)
+			}
+			// the silences are flat, the sample's 10 seconds aren't
+			loud := 0
+			for i, peak := range got.Peaks {
+				for _, s := range got.Silences {
+					if periodMillis(i) >= s.StartMillis && periodMillis(i) < s.EndMillis && peak != 0 {
+						t.Errorf("GenerateWaveform() peak %d = %v, in silence %v", i, peak, s)
+					}
+				}
+				if peak > 0 {
+					loud++
+				}
+			}
+			if loud < 95 {
+				t.Errorf("GenerateWaveform() %v peaks above 0, want the sample's", loud)
+			}
+		})
+	}
+}
+
+func Test_granuleLevels(t *testing.T) {
+	sample, err := ioutil.ReadFile("./test/sample.mp3")
+	if err != nil {
+		t.Fatalf("Test_granuleLevels() error reading sample: %v", err)
+	}
+	loud := splitFrames(t, sample)[10]
+	// 40 lower global gains are 60dB quieter, the joint stereo frame's 2 granules of 2 channels follow
+	// main_data_begin, private bits & scfsi, each of their side info is 59 bits starting with 21 before the gain
+	quiet := append([]byte{}, loud...)
+	for i := 0; i < 4; i++ {
+		pos := 4*8 + 20 + i*59 + 21
+		r := &bitReader{data: quiet, pos: pos}
+		gain := r.read(8) - 40
+		for b := 0; b < 8; b++ {
+			bit := byte(gain>>uint(7-b)) & 1
+			quiet[(pos+b)/8] = quiet[(pos+b)/8]&^(0x80>>uint((pos+b)%8)) | bit<<uint(7-(pos+b)%8)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		frame   []byte
+		wantMin float64
+		wantMax float64
+	}{
+		{name: "loud", frame: loud, wantMin: silenceThreshold, wantMax: 32768},
+		{name: "quiet", frame: quiet, wantMin: 1, wantMax: silenceThreshold},
+		{name: "silent", frame: mp3Frames(sampleHeader, 1), wantMin: 0, wantMax: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frame, err := decodeFrame(tt.frame)
+			if err != nil {
+				t.Fatalf("decodeFrame() error = %v", err)
+			}
+			got, err := granuleLevels(frame)
+			if err != nil || len(got) != 2 {
+				t.Fatalf("granuleLevels() = %v, error = %v", got, err)
+			}
+			for _, level := range got {
+				if level < tt.wantMin || level > tt.wantMax {
+					t.Errorf("granuleLevels() = %v, want between %v and %v", got, tt.wantMin, tt.wantMax)
+				}
+			}
+		})
+	}
+}
+
+func Test_waveformBuilder(t *testing.T) {
+	// a second of quiet noise, 3 seconds of silence and a second at full scale, at 1kHz in blocks of 10 samples
+	w := newWaveformBuilder(1000)
+	for i := 0; i < 100; i++ {
+		w.write(100, 10)
+	}
+	for i := 0; i < 300; i++ {
+		w.write(0, 10)
+	}
+	for i := 0; i < 100; i++ {
+		w.write(32767, 10)
+	}
+	got := w.finish(5000)
+	if len(got.Peaks) != 50 || got.Peaks[0] != 1 || got.Peaks[20] != 0 || got.Peaks[49] != 255 {
+		t.Errorf("waveformBuilder peaks = %v", got.Peaks)
+	}
+	// the quiet noise is below the silence threshold
+	want := []*protos.Silence{{StartMillis: 0, EndMillis: 4000}}
+	if !reflect.DeepEqual(got.Silences, want) {
+		t.Errorf("waveformBuilder silences = %v, want %v", got.Silences, want)
+	}
+}
+
+func Test_waveformJob(t *testing.T) {
+	server, _ := sampleServer(t)
+	defer server.Close()
+	mockDB := mock.CreateDB()
+	subscribed := protos.NewObjectID()
+	insertOrFail(t, mockDB, database.ColSubscription, &protos.Subscription{Id: protos.NewObjectID(), PodcastID: subscribed})
+
+	tests := []struct {
+		name         string
+		podID        *protos.ObjectID
+		path         string
+		wantWaveform bool
+	}{
+		{name: "subscribed", podID: subscribed, path: "/preroll.mp3", wantWaveform: true},
+		{name: "unsubscribed", podID: protos.NewObjectID(), path: "/preroll.mp3", wantWaveform: false},
+		{name: "not_audio", podID: subscribed, path: "/text.mp3", wantWaveform: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			epi := &protos.Episode{Id: protos.NewObjectID(), PodcastID: tt.podID, MP3URL: server.URL + tt.path}
+			insertOrFail(t, mockDB, database.ColEpisode, epi)
+			queueWaveform(mockDB, epi)
+
+			// generated, or retried until it gives up
+			for i := 0; i < maxWaveformAttempts; i++ {
+				job := &models.WaveformJob{}
+				err := mockDB.FindOne(database.ColWaveformJob, job, &db.Filter{"episode_id": epi.Id}, nil)
+				if err != nil {
+					break
+				}
+				waveformJob(mockDB, job)
+			}
+			err := mockDB.FindOne(database.ColWaveformJob, &models.WaveformJob{}, &db.Filter{"episode_id": epi.Id}, nil)
+			if err == nil {
+				t.Errorf("waveformJob() left the job queued")
+			}
+			waveform, err := FindWaveform(mockDB, epi.Id)
+			if (err == nil) != tt.wantWaveform {
+				t.Fatalf("waveformJob() stored waveform = %v, want %v", err == nil, tt.wantWaveform)
+			}
+			if tt.wantWaveform && len(waveform.Silences) != 1 {
+				t.Errorf("waveformJob() stored silences = %v", waveform.Silences)
+			}
+		})
+	}
+}
@@ -0,0 +1,337 @@
+package podcast
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// states of a WebSub subscription
+const (
+	webSubPending      = "pending" // requested, waiting for the hub to verify it
+	webSubSubscribed   = "subscribed"
+	webSubUnsubscribed = "unsubscribed" // the feed no longer advertises a hub or the hub denied it
+)
+
+const (
+	// lease requested from hubs
+	webSubLeaseSeconds = 60 * 60 * 24 * 7
+	// leases are renewed this long before they expire
+	webSubRenewBefore = time.Hour * 24
+	// unanswered subscription requests are sent again after
+	webSubRetry = time.Hour
+	// how often subscriptions are checked for requesting & renewing
+	webSubCheckInterval = time.Minute * 5
+	// feeds with an active subscription are still polled this often, in case a push is missed
+	webSubFallbackInterval = time.Hour * 24
+	// MaxPushBody is the largest body accepted from a hub
+	MaxPushBody = 10 << 20
+)
+
+var (
+	// ErrWebSubSignature is returned when pushed content's X-Hub-Signature is missing or invalid
+	ErrWebSubSignature = errors.New("invalid X-Hub-Signature")
+	// ErrWebSubNotSubscribed is returned when content is pushed for a feed that is not subscribed
+	ErrWebSubNotSubscribed = errors.New("feed is not subscribed")
+)
+
+// FindWebSub finds the WebSub subscription of the podcast's feed via podcast id
+func FindWebSub(dbClient db.Database, podID *protos.ObjectID) (*models.WebSubSubscription, error) {
+	sub := &models.WebSubSubscription{}
+	err := dbClient.FindOne(database.ColWebSub, sub, &db.Filter{"podcast_id": podID}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("FindWebSub() error: %v", err)
+	}
+	return sub, nil
+}
+
+// UpsertWebSub upserts the WebSub subscription of the podcast's feed
+func UpsertWebSub(dbClient db.Database, sub *models.WebSubSubscription) error {
+	err := dbClient.Upsert(database.ColWebSub, sub, &db.Filter{"podcast_id": sub.PodcastID})
+	if err != nil {
+		return fmt.Errorf("error upserting websub subscription: %v", err)
+	}
+	return nil
+}
+
+// requestWebSub records the hub advertised by the feed via <atom:link rel="hub">,
+// the WebSub subscriber subscribes to it on its next check
+func requestWebSub(dbClient db.Database, pod *protos.Podcast, rssPod *models.RSSPodcast) {
+	hub := strings.TrimSpace(atomLink(rssPod.AtomLinks, "hub"))
+	topic := strings.TrimSpace(atomLink(rssPod.AtomLinks, "self"))
+	if topic == "" {
+		topic = pod.Rss
+	}
+
+	sub, err := FindWebSub(dbClient, pod.Id)
+	if err != nil {
+		if hub == "" {
+			return
+		}
+		sub = &models.WebSubSubscription{PodcastID: pod.Id}
+	} else if sub.Hub == hub && sub.Topic == topic {
+		return
+	}
+
+	sub.Hub, sub.Topic = hub, topic
+	sub.RequestedAt = time.Time{}
+	sub.State = webSubPending
+	if hub == "" {
+		// let the lease run out, the feed is polled again
+		sub.State = webSubUnsubscribed
+	}
+	err = UpsertWebSub(dbClient, sub)
+	if err != nil {
+		log.Println("requestWebSub() error:", err)
+	}
+}
+
+// webSubActive checks whether the podcast's feed has an active WebSub subscription
+func webSubActive(dbClient db.Database, podID *protos.ObjectID) bool {
+	sub, err := FindWebSub(dbClient, podID)
+	return err == nil && sub.State == webSubSubscribed && sub.LeaseExpires.After(time.Now())
+}
+
+// WebSub subscribes to the WebSub (PubSubHubbub) hubs advertised by feeds so their updates are
+// pushed instead of polled. Hubs verify subscriptions & push content through the /websub/ route
+type WebSub struct {
+	dbClient    db.Database
+	callbackURL string
+	client      *http.Client
+	stop        chan struct{}
+}
+
+// NewWebSub creates a WebSub subscriber whose callbacks are served under baseURL/websub/,
+// nothing is subscribed if baseURL is empty
+func NewWebSub(dbClient db.Database, baseURL string) *WebSub {
+	callbackURL := ""
+	if baseURL != "" {
+		callbackURL = strings.TrimSuffix(baseURL, "/") + "/websub/"
+	}
+	return &WebSub{
+		dbClient:    dbClient,
+		callbackURL: callbackURL,
+		client:      &http.Client{Timeout: time.Second * 30},
+		stop:        make(chan struct{}),
+	}
+}
+
+// Start requests pending subscriptions and renews leases before they expire,
+// blocks until Stop is called
+func (w *WebSub) Start() {
+	if w.callbackURL == "" {
+		log.Println("WebSub.Start() no base url configured, feeds are only polled")
+		return
+	}
+	w.check()
+	ticker := time.NewTicker(webSubCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.check()
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// Stop stops the subscriber
+func (w *WebSub) Stop() {
+	close(w.stop)
+}
+
+// check subscribes to every hub that is due
+func (w *WebSub) check() {
+	var subs []*models.WebSubSubscription
+	err := w.dbClient.FindAll(database.ColWebSub, &subs, nil, nil)
+	if err != nil {
+		log.Println("WebSub.check() error finding subscriptions:", err)
+		return
+	}
+	now := time.Now()
+	for _, sub := range subs {
+		if !webSubDue(sub, now) {
+			continue
+		}
+		err = w.subscribe(sub)
+		if err != nil {
+			log.Printf("WebSub.check() error subscribing to %v via %v: %v\n", sub.Topic, sub.Hub, err)
+		}
+	}
+}
+
+// webSubDue checks whether the subscription needs to be requested or renewed
+func webSubDue(sub *models.WebSubSubscription, now time.Time) bool {
+	retry := now.Sub(sub.RequestedAt) >= webSubRetry
+	switch sub.State {
+	case webSubPending:
+		return retry
+	case webSubSubscribed:
+		return retry && now.Add(webSubRenewBefore).After(sub.LeaseExpires)
+	}
+	return false
+}
+
+// subscribe asks the hub to subscribe the podcast's callback to the topic, the hub then verifies
+// the request through the callback before the subscription is active
+func (w *WebSub) subscribe(sub *models.WebSubSubscription) error {
+	if sub.Secret == "" {
+		secret, err := createWebSubSecret()
+		if err != nil {
+			return err
+		}
+		sub.Secret = secret
+	}
+	// the secret must be stored before the hub can push content signed with it
+	sub.RequestedAt = time.Now()
+	err := UpsertWebSub(w.dbClient, sub)
+	if err != nil {
+		return fmt.Errorf("subscribe() error: %v", err)
+	}
+
+	form := url.Values{
+		"hub.mode":          {"subscribe"},
+		"hub.topic":         {sub.Topic},
+		"hub.callback":      {w.callbackURL + sub.PodcastID.GetHex()},
+		"hub.secret":        {sub.Secret},
+		"hub.lease_seconds": {strconv.Itoa(webSubLeaseSeconds)},
+	}
+	resp, err := w.client.PostForm(sub.Hub, form)
+	if err != nil {
+		return fmt.Errorf("subscribe() error requesting subscription: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("subscribe() hub responded: %s", resp.Status)
+	}
+	return nil
+}
+
+// Verify handles the hub's verification of intent for the podcast's subscription.
+// returns the challenge to echo back if the subscription was requested
+func (w *WebSub) Verify(podID *protos.ObjectID, mode, topic, challenge string, leaseSeconds int) (string, error) {
+	sub, err := FindWebSub(w.dbClient, podID)
+	if err != nil {
+		return "", fmt.Errorf("Verify() error: %v", err)
+	}
+	if topic != sub.Topic {
+		return "", fmt.Errorf("Verify() error unknown topic: %v", topic)
+	}
+
+	switch mode {
+	case "subscribe":
+		if sub.State == webSubUnsubscribed {
+			return "", errors.New("Verify() error subscription was not requested")
+		}
+		sub.State = webSubSubscribed
+		sub.VerifiedAt = time.Now()
+		sub.LeaseExpires = sub.VerifiedAt.Add(time.Duration(leaseSeconds) * time.Second)
+	case "unsubscribe":
+		if sub.State != webSubUnsubscribed {
+			return "", errors.New("Verify() error unsubscribe was not requested")
+		}
+		return challenge, nil
+	case "denied":
+		log.Printf("Verify() hub %v denied subscription to %v\n", sub.Hub, sub.Topic)
+		sub.State = webSubUnsubscribed
+	default:
+		return "", fmt.Errorf("Verify() error unknown mode: %v", mode)
+	}
+
+	err = UpsertWebSub(w.dbClient, sub)
+	if err != nil {
+		return "", fmt.Errorf("Verify() error: %v", err)
+	}
+	return challenge, nil
+}
+
+// Receive updates the podcast right away from the feed content pushed by its hub,
+// content without a valid X-Hub-Signature is ignored
+func (w *WebSub) Receive(podID *protos.ObjectID, body []byte, signature string) error {
+	sub, err := FindWebSub(w.dbClient, podID)
+	if err != nil || sub.State != webSubSubscribed {
+		return ErrWebSubNotSubscribed
+	}
+	if !validHubSignature(sub.Secret, body, signature) {
+		return ErrWebSubSignature
+	}
+	pod, err := FindPodcastByID(w.dbClient, podID)
+	if err != nil {
+		return fmt.Errorf("Receive() error: %v", err)
+	}
+	return pushFeed(w.dbClient, pod, body)
+}
+
+// pushFeed updates the podcast from a feed body pushed by its hub
+func pushFeed(dbClient db.Database, pod *protos.Podcast, body []byte) error {
+	state, err := FindFetchState(dbClient, pod.Id)
+	if err != nil {
+		state = &models.FetchState{PodcastID: pod.Id}
+	}
+	hash := hashFeed(body)
+	if hash == state.ContentHash {
+		return nil
+	}
+	// the next poll is skipped once it fetches the same body
+	fetched := *state
+	fetched.ContentHash = hash
+	fetched.MovedTo = ""
+	return ingestFeed(dbClient, pod, state, &fetched, body)
+}
+
+// validHubSignature verifies the X-Hub-Signature header, "method=signature" where signature
+// is the hex encoded HMAC of the body keyed with the subscription's secret
+func validHubSignature(secret string, body []byte, signature string) bool {
+	parts := strings.SplitN(strings.TrimSpace(signature), "=", 2)
+	if secret == "" || len(parts) != 2 {
+		return false
+	}
+	var hashFunc func() hash.Hash
+	switch strings.ToLower(parts[0]) {
+	case "sha1":
+		hashFunc = sha1.New
+	case "sha256":
+		hashFunc = sha256.New
+	case "sha384":
+		hashFunc = sha512.New384
+	case "sha512":
+		hashFunc = sha512.New
+	default:
+		return false
+	}
+	got, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(hashFunc, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// createWebSubSecret creates the random secret a hub signs pushed content with
+func createWebSubSecret() (string, error) {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	if err != nil {
+		return "", fmt.Errorf("createWebSubSecret() error: %v", err)
+	}
+	return hex.EncodeToString(key), nil
+}
@@ -0,0 +1,187 @@
+package podcast
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/stockpile/mock"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// signBody returns the X-Hub-Signature of the body
+func signBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestWebSub(t *testing.T) {
+	// the hub records the subscription requests it receives
+	var requests []url.Values
+	var mutex sync.Mutex
+	hub := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if err := req.ParseForm(); err != nil {
+			res.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mutex.Lock()
+		requests = append(requests, req.PostForm)
+		mutex.Unlock()
+		res.WriteHeader(http.StatusAccepted)
+	}))
+	defer hub.Close()
+
+	var feedURL string
+	links := func() string {
+		return `<atom:link rel="hub" href="` + hub.URL + `" /><atom:link rel="self" href="` + feedURL + `" />`
+	}
+	feed := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.Write([]byte(pagedFeed(links(), 1)))
+	}))
+	defer feed.Close()
+	feedURL = feed.URL + "/feed"
+
+	mockDB := mock.CreateDB()
+	insertOrFail(t, mockDB, database.ColEpisode, &protos.Episode{Id: protos.NewObjectID(), PodcastID: protos.NewObjectID()})
+	if err := AddNewPodcast(mockDB, feedURL); err != nil {
+		t.Fatalf("AddNewPodcast() error = %v", err)
+	}
+	pod, err := FindPodcastByFeed(mockDB, feedURL)
+	if err != nil {
+		t.Fatalf("AddNewPodcast() error finding podcast: %v", err)
+	}
+	sub, err := FindWebSub(mockDB, pod.Id)
+	if err != nil || sub.State != webSubPending || sub.Hub != hub.URL || sub.Topic != feedURL {
+		t.Fatalf("AddNewPodcast() websub subscription = %v, error = %v", sub, err)
+	}
+
+	// subscribe
+	webSub := NewWebSub(mockDB, "https://syncapod.com/")
+	webSub.check()
+	mutex.Lock()
+	if len(requests) != 1 {
+		t.Fatalf("WebSub.check() hub requests = %v, want 1", len(requests))
+	}
+	form := requests[0]
+	mutex.Unlock()
+	if form.Get("hub.mode") != "subscribe" || form.Get("hub.topic") != feedURL ||
+		form.Get("hub.callback") != "https://syncapod.com/websub/"+pod.Id.GetHex() || form.Get("hub.secret") == "" {
+		t.Errorf("WebSub.check() subscription request = %v", form)
+	}
+	secret := form.Get("hub.secret")
+	if webSubActive(mockDB, pod.Id) {
+		t.Errorf("webSubActive() = true before the hub verified the subscription")
+	}
+
+	// verification of intent
+	verifyTests := []struct {
+		name    string
+		mode    string
+		topic   string
+		wantErr bool
+	}{
+		{name: "unknown_topic", mode: "subscribe", topic: "https://example.com/other", wantErr: true},
+		{name: "unrequested_unsubscribe", mode: "unsubscribe", topic: feedURL, wantErr: true},
+		{name: "subscribe", mode: "subscribe", topic: feedURL},
+	}
+	for _, tt := range verifyTests {
+		t.Run(tt.name, func(t *testing.T) {
+			challenge, err := webSub.Verify(pod.Id, tt.mode, tt.topic, "challenge-"+tt.name, 3600)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WebSub.Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && challenge != "challenge-"+tt.name {
+				t.Errorf("WebSub.Verify() challenge = %v", challenge)
+			}
+		})
+	}
+	if !webSubActive(mockDB, pod.Id) {
+		t.Errorf("webSubActive() = false after the hub verified the subscription")
+	}
+
+	// content distribution
+	pushed := []byte(pagedFeed(links(), 2, 1))
+	receiveTests := []struct {
+		name      string
+		signature string
+		wantErr   error
+		wantCount int
+	}{
+		{name: "missing_signature", wantErr: ErrWebSubSignature, wantCount: 1},
+		{name: "wrong_secret", signature: signBody("wrong", pushed), wantErr: ErrWebSubSignature, wantCount: 1},
+		{name: "valid", signature: signBody(secret, pushed), wantCount: 2},
+	}
+	for _, tt := range receiveTests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := webSub.Receive(pod.Id, pushed, tt.signature)
+			if err != tt.wantErr {
+				t.Fatalf("WebSub.Receive() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var episodes []*protos.Episode
+			err = mockDB.FindAll(database.ColEpisode, &episodes, &db.Filter{"podcastid": pod.Id}, nil)
+			if err != nil || len(episodes) != tt.wantCount {
+				t.Errorf("WebSub.Receive() episode count = %v, want %v, error = %v", len(episodes), tt.wantCount, err)
+			}
+		})
+	}
+	if err := webSub.Receive(protos.NewObjectID(), pushed, signBody(secret, pushed)); err != ErrWebSubNotSubscribed {
+		t.Errorf("WebSub.Receive() unknown podcast error = %v, want %v", err, ErrWebSubNotSubscribed)
+	}
+}
+
+func Test_webSubDue(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		sub  *models.WebSubSubscription
+		want bool
+	}{
+		{name: "never_requested", sub: &models.WebSubSubscription{State: webSubPending}, want: true},
+		{name: "recently_requested", sub: &models.WebSubSubscription{State: webSubPending, RequestedAt: now.Add(-time.Minute)}},
+		{name: "unanswered", sub: &models.WebSubSubscription{State: webSubPending, RequestedAt: now.Add(-webSubRetry)}, want: true},
+		{name: "lease_expiring", sub: &models.WebSubSubscription{State: webSubSubscribed, RequestedAt: now.Add(-time.Hour * 24 * 6), LeaseExpires: now.Add(time.Hour)}, want: true},
+		{name: "lease_valid", sub: &models.WebSubSubscription{State: webSubSubscribed, RequestedAt: now.Add(-time.Hour), LeaseExpires: now.Add(time.Hour * 24 * 6)}},
+		{name: "unsubscribed", sub: &models.WebSubSubscription{State: webSubUnsubscribed}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := webSubDue(tt.sub, now); got != tt.want {
+				t.Errorf("webSubDue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_validHubSignature(t *testing.T) {
+	body := []byte("<rss></rss>")
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		want      bool
+	}{
+		{name: "sha1", secret: "secret", signature: "sha1=726615dee00060708f7ee0f32bb119b70cea7bbe", want: true},
+		{name: "wrong_signature", secret: "secret", signature: "sha1=8b7d9eb7a1b2e0bd2c45e6b4aeb0a02e68a5f2d8"},
+		{name: "sha256", secret: "secret", signature: signBody("secret", body), want: true},
+		{name: "unknown_method", secret: "secret", signature: "md5=abc"},
+		{name: "not_hex", secret: "secret", signature: "sha256=zz"},
+		{name: "no_secret", signature: signBody("", body)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validHubSignature(tt.secret, body, tt.signature); got != tt.want {
+				t.Errorf("validHubSignature() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
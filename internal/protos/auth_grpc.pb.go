@@ -20,6 +20,10 @@ type AuthClient interface {
 	Authenticate(ctx context.Context, in *AuthReq, opts ...grpc.CallOption) (*AuthRes, error)
 	Authorize(ctx context.Context, in *AuthReq, opts ...grpc.CallOption) (*AuthRes, error)
 	Logout(ctx context.Context, in *AuthReq, opts ...grpc.CallOption) (*AuthRes, error)
+	Register(ctx context.Context, in *RegisterReq, opts ...grpc.CallOption) (*AuthRes, error)
+	VerifyEmail(ctx context.Context, in *TokenReq, opts ...grpc.CallOption) (*AuthRes, error)
+	RequestPasswordReset(ctx context.Context, in *AuthReq, opts ...grpc.CallOption) (*AuthRes, error)
+	ResetPassword(ctx context.Context, in *TokenReq, opts ...grpc.CallOption) (*AuthRes, error)
 }
 
 type authClient struct {
@@ -57,6 +61,42 @@ func (c *authClient) Logout(ctx context.Context, in *AuthReq, opts ...grpc.CallO
 	return out, nil
 }
 
+func (c *authClient) Register(ctx context.Context, in *RegisterReq, opts ...grpc.CallOption) (*AuthRes, error) {
+	out := new(AuthRes)
+	err := c.cc.Invoke(ctx, "/protos.Auth/Register", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) VerifyEmail(ctx context.Context, in *TokenReq, opts ...grpc.CallOption) (*AuthRes, error) {
+	out := new(AuthRes)
+	err := c.cc.Invoke(ctx, "/protos.Auth/VerifyEmail", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) RequestPasswordReset(ctx context.Context, in *AuthReq, opts ...grpc.CallOption) (*AuthRes, error) {
+	out := new(AuthRes)
+	err := c.cc.Invoke(ctx, "/protos.Auth/RequestPasswordReset", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) ResetPassword(ctx context.Context, in *TokenReq, opts ...grpc.CallOption) (*AuthRes, error) {
+	out := new(AuthRes)
+	err := c.cc.Invoke(ctx, "/protos.Auth/ResetPassword", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthServer is the server API for Auth service.
 // All implementations must embed UnimplementedAuthServer
 // for forward compatibility
@@ -64,6 +104,10 @@ type AuthServer interface {
 	Authenticate(context.Context, *AuthReq) (*AuthRes, error)
 	Authorize(context.Context, *AuthReq) (*AuthRes, error)
 	Logout(context.Context, *AuthReq) (*AuthRes, error)
+	Register(context.Context, *RegisterReq) (*AuthRes, error)
+	VerifyEmail(context.Context, *TokenReq) (*AuthRes, error)
+	RequestPasswordReset(context.Context, *AuthReq) (*AuthRes, error)
+	ResetPassword(context.Context, *TokenReq) (*AuthRes, error)
 	mustEmbedUnimplementedAuthServer()
 }
 
@@ -80,6 +124,18 @@ func (UnimplementedAuthServer) Authorize(context.Context, *AuthReq) (*AuthRes, e
 func (UnimplementedAuthServer) Logout(context.Context, *AuthReq) (*AuthRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
 }
+func (UnimplementedAuthServer) Register(context.Context, *RegisterReq) (*AuthRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+}
+func (UnimplementedAuthServer) VerifyEmail(context.Context, *TokenReq) (*AuthRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyEmail not implemented")
+}
+func (UnimplementedAuthServer) RequestPasswordReset(context.Context, *AuthReq) (*AuthRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestPasswordReset not implemented")
+}
+func (UnimplementedAuthServer) ResetPassword(context.Context, *TokenReq) (*AuthRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetPassword not implemented")
+}
 func (UnimplementedAuthServer) mustEmbedUnimplementedAuthServer() {}
 
 // UnsafeAuthServer may be embedded to opt out of forward compatibility for this service.
@@ -147,6 +203,78 @@ func _Auth_Logout_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Auth_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Auth/Register",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).Register(ctx, req.(*RegisterReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_VerifyEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).VerifyEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Auth/VerifyEmail",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).VerifyEmail(ctx, req.(*TokenReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_RequestPasswordReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).RequestPasswordReset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Auth/RequestPasswordReset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).RequestPasswordReset(ctx, req.(*AuthReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_ResetPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).ResetPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Auth/ResetPassword",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).ResetPassword(ctx, req.(*TokenReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Auth_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protos.Auth",
 	HandlerType: (*AuthServer)(nil),
@@ -163,6 +291,22 @@ var _Auth_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Logout",
 			Handler:    _Auth_Logout_Handler,
 		},
+		{
+			MethodName: "Register",
+			Handler:    _Auth_Register_Handler,
+		},
+		{
+			MethodName: "VerifyEmail",
+			Handler:    _Auth_VerifyEmail_Handler,
+		},
+		{
+			MethodName: "RequestPasswordReset",
+			Handler:    _Auth_RequestPasswordReset_Handler,
+		},
+		{
+			MethodName: "ResetPassword",
+			Handler:    _Auth_ResetPassword_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "auth.proto",
@@ -155,6 +155,17 @@ type Podcast struct {
 	PubDate       *timestamp.Timestamp `protobuf:"bytes,12,opt,name=pubDate,proto3" json:"pubDate,omitempty"`
 	LastBuildDate *timestamp.Timestamp `protobuf:"bytes,13,opt,name=lastBuildDate,proto3" json:"lastBuildDate,omitempty"`
 	Rss           string               `protobuf:"bytes,14,opt,name=rss,proto3" json:"rss,omitempty"`
+	// podcasting 2.0 namespace
+	Guid        string     `protobuf:"bytes,15,opt,name=guid,proto3" json:"guid,omitempty"`
+	Locked      bool       `protobuf:"varint,16,opt,name=locked,proto3" json:"locked,omitempty"`
+	LockedOwner string     `protobuf:"bytes,17,opt,name=lockedOwner,proto3" json:"lockedOwner,omitempty"`
+	Persons     []*Person  `protobuf:"bytes,18,rep,name=persons,proto3" json:"persons,omitempty"`
+	Funding     []*Funding `protobuf:"bytes,19,rep,name=funding,proto3" json:"funding,omitempty"`
+	Location    *Location  `protobuf:"bytes,20,opt,name=location,proto3" json:"location,omitempty"`
+	Value       *Value     `protobuf:"bytes,21,opt,name=value,proto3" json:"value,omitempty"`
+	// private feeds are fetched with their owner's credentials and only visible to them
+	Private bool      `protobuf:"varint,22,opt,name=private,proto3" json:"private,omitempty"`
+	OwnerID *ObjectID `protobuf:"bytes,23,opt,name=ownerID,proto3" json:"ownerID,omitempty"`
 }
 
 func (x *Podcast) Reset() {
@@ -287,6 +298,69 @@ func (x *Podcast) GetRss() string {
 	return ""
 }
 
+func (x *Podcast) GetGuid() string {
+	if x != nil {
+		return x.Guid
+	}
+	return ""
+}
+
+func (x *Podcast) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+func (x *Podcast) GetLockedOwner() string {
+	if x != nil {
+		return x.LockedOwner
+	}
+	return ""
+}
+
+func (x *Podcast) GetPersons() []*Person {
+	if x != nil {
+		return x.Persons
+	}
+	return nil
+}
+
+func (x *Podcast) GetFunding() []*Funding {
+	if x != nil {
+		return x.Funding
+	}
+	return nil
+}
+
+func (x *Podcast) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *Podcast) GetValue() *Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *Podcast) GetPrivate() bool {
+	if x != nil {
+		return x.Private
+	}
+	return false
+}
+
+func (x *Podcast) GetOwnerID() *ObjectID {
+	if x != nil {
+		return x.OwnerID
+	}
+	return nil
+}
+
 type Episode struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -308,6 +382,22 @@ type Episode struct {
 	MP3URL         string               `protobuf:"bytes,14,opt,name=MP3URL,proto3" json:"MP3URL,omitempty"`
 	DurationMillis int64                `protobuf:"varint,15,opt,name=durationMillis,proto3" json:"durationMillis,omitempty"`
 	Subtitle       string               `protobuf:"bytes,16,opt,name=subtitle,proto3" json:"subtitle,omitempty"`
+	// podcasting 2.0 namespace
+	Transcripts         []*Transcript         `protobuf:"bytes,17,rep,name=transcripts,proto3" json:"transcripts,omitempty"`
+	Chapters            *Chapters             `protobuf:"bytes,18,opt,name=chapters,proto3" json:"chapters,omitempty"`
+	Persons             []*Person             `protobuf:"bytes,19,rep,name=persons,proto3" json:"persons,omitempty"`
+	SeasonName          string                `protobuf:"bytes,20,opt,name=seasonName,proto3" json:"seasonName,omitempty"`
+	Soundbites          []*Soundbite          `protobuf:"bytes,21,rep,name=soundbites,proto3" json:"soundbites,omitempty"`
+	Location            *Location             `protobuf:"bytes,22,opt,name=location,proto3" json:"location,omitempty"`
+	Value               *Value                `protobuf:"bytes,23,opt,name=value,proto3" json:"value,omitempty"`
+	AlternateEnclosures []*AlternateEnclosure `protobuf:"bytes,24,rep,name=alternateEnclosures,proto3" json:"alternateEnclosures,omitempty"`
+	// guid of the episode within the rss feed
+	Guid string `protobuf:"bytes,25,opt,name=guid,proto3" json:"guid,omitempty"`
+	// mime type & size in bytes of the MP3URL enclosure
+	EnclosureType   string `protobuf:"bytes,26,opt,name=enclosureType,proto3" json:"enclosureType,omitempty"`
+	EnclosureLength int64  `protobuf:"varint,27,opt,name=enclosureLength,proto3" json:"enclosureLength,omitempty"`
+	// chapters embedded in the enclosure's ID3 tag or MP4 chpl box
+	EmbeddedChapters []*Chapter `protobuf:"bytes,28,rep,name=embeddedChapters,proto3" json:"embeddedChapters,omitempty"`
 }
 
 func (x *Episode) Reset() {
@@ -454,109 +544,120 @@ func (x *Episode) GetSubtitle() string {
 	return ""
 }
 
-// start & end represen the amount of episodes to return
-type Request struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	//string token = 1;
-	PodcastID *ObjectID `protobuf:"bytes,2,opt,name=podcastID,proto3" json:"podcastID,omitempty"`
-	EpisodeID *ObjectID `protobuf:"bytes,3,opt,name=episodeID,proto3" json:"episodeID,omitempty"`
-	Start     int64     `protobuf:"varint,4,opt,name=start,proto3" json:"start,omitempty"`
-	End       int64     `protobuf:"varint,5,opt,name=end,proto3" json:"end,omitempty"`
+func (x *Episode) GetTranscripts() []*Transcript {
+	if x != nil {
+		return x.Transcripts
+	}
+	return nil
 }
 
-func (x *Request) Reset() {
-	*x = Request{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_podcast_proto_msgTypes[4]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Episode) GetChapters() *Chapters {
+	if x != nil {
+		return x.Chapters
 	}
+	return nil
 }
 
-func (x *Request) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Episode) GetPersons() []*Person {
+	if x != nil {
+		return x.Persons
+	}
+	return nil
 }
 
-func (*Request) ProtoMessage() {}
+func (x *Episode) GetSeasonName() string {
+	if x != nil {
+		return x.SeasonName
+	}
+	return ""
+}
 
-func (x *Request) ProtoReflect() protoreflect.Message {
-	mi := &file_podcast_proto_msgTypes[4]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Episode) GetSoundbites() []*Soundbite {
+	if x != nil {
+		return x.Soundbites
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use Request.ProtoReflect.Descriptor instead.
-func (*Request) Descriptor() ([]byte, []int) {
-	return file_podcast_proto_rawDescGZIP(), []int{4}
+func (x *Episode) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
 }
 
-func (x *Request) GetPodcastID() *ObjectID {
+func (x *Episode) GetValue() *Value {
 	if x != nil {
-		return x.PodcastID
+		return x.Value
 	}
 	return nil
 }
 
-func (x *Request) GetEpisodeID() *ObjectID {
+func (x *Episode) GetAlternateEnclosures() []*AlternateEnclosure {
 	if x != nil {
-		return x.EpisodeID
+		return x.AlternateEnclosures
 	}
 	return nil
 }
 
-func (x *Request) GetStart() int64 {
+func (x *Episode) GetGuid() string {
 	if x != nil {
-		return x.Start
+		return x.Guid
 	}
-	return 0
+	return ""
 }
 
-func (x *Request) GetEnd() int64 {
+func (x *Episode) GetEnclosureType() string {
 	if x != nil {
-		return x.End
+		return x.EnclosureType
+	}
+	return ""
+}
+
+func (x *Episode) GetEnclosureLength() int64 {
+	if x != nil {
+		return x.EnclosureLength
 	}
 	return 0
 }
 
-type UserEpisodeReq struct {
+func (x *Episode) GetEmbeddedChapters() []*Chapter {
+	if x != nil {
+		return x.EmbeddedChapters
+	}
+	return nil
+}
+
+// Person is someone involved with a podcast or episode, such as a host or guest
+type Person struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	//string token = 1;
-	PodcastID *ObjectID            `protobuf:"bytes,2,opt,name=podcastID,proto3" json:"podcastID,omitempty"`
-	EpisodeID *ObjectID            `protobuf:"bytes,3,opt,name=episodeID,proto3" json:"episodeID,omitempty"`
-	Offset    int64                `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
-	LastSeen  *timestamp.Timestamp `protobuf:"bytes,5,opt,name=lastSeen,proto3" json:"lastSeen,omitempty"`
-	Played    bool                 `protobuf:"varint,6,opt,name=played,proto3" json:"played,omitempty"`
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Role  string `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	Group string `protobuf:"bytes,3,opt,name=group,proto3" json:"group,omitempty"`
+	Img   string `protobuf:"bytes,4,opt,name=img,proto3" json:"img,omitempty"`
+	Href  string `protobuf:"bytes,5,opt,name=href,proto3" json:"href,omitempty"`
 }
 
-func (x *UserEpisodeReq) Reset() {
-	*x = UserEpisodeReq{}
+func (x *Person) Reset() {
+	*x = Person{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_podcast_proto_msgTypes[5]
+		mi := &file_podcast_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UserEpisodeReq) String() string {
+func (x *Person) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserEpisodeReq) ProtoMessage() {}
+func (*Person) ProtoMessage() {}
 
-func (x *UserEpisodeReq) ProtoReflect() protoreflect.Message {
-	mi := &file_podcast_proto_msgTypes[5]
+func (x *Person) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -567,72 +668,73 @@ func (x *UserEpisodeReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserEpisodeReq.ProtoReflect.Descriptor instead.
-func (*UserEpisodeReq) Descriptor() ([]byte, []int) {
-	return file_podcast_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use Person.ProtoReflect.Descriptor instead.
+func (*Person) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *UserEpisodeReq) GetPodcastID() *ObjectID {
+func (x *Person) GetName() string {
 	if x != nil {
-		return x.PodcastID
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *UserEpisodeReq) GetEpisodeID() *ObjectID {
+func (x *Person) GetRole() string {
 	if x != nil {
-		return x.EpisodeID
+		return x.Role
 	}
-	return nil
+	return ""
 }
 
-func (x *UserEpisodeReq) GetOffset() int64 {
+func (x *Person) GetGroup() string {
 	if x != nil {
-		return x.Offset
+		return x.Group
 	}
-	return 0
+	return ""
 }
 
-func (x *UserEpisodeReq) GetLastSeen() *timestamp.Timestamp {
+func (x *Person) GetImg() string {
 	if x != nil {
-		return x.LastSeen
+		return x.Img
 	}
-	return nil
+	return ""
 }
 
-func (x *UserEpisodeReq) GetPlayed() bool {
+func (x *Person) GetHref() string {
 	if x != nil {
-		return x.Played
+		return x.Href
 	}
-	return false
+	return ""
 }
 
-type Response struct {
+// Funding is a link to donate or support the podcast
+type Funding struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Success bool   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Url  string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Text string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
 }
 
-func (x *Response) Reset() {
-	*x = Response{}
+func (x *Funding) Reset() {
+	*x = Funding{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_podcast_proto_msgTypes[6]
+		mi := &file_podcast_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Response) String() string {
+func (x *Funding) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Response) ProtoMessage() {}
+func (*Funding) ProtoMessage() {}
 
-func (x *Response) ProtoReflect() protoreflect.Message {
-	mi := &file_podcast_proto_msgTypes[6]
+func (x *Funding) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -643,52 +745,53 @@ func (x *Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Response.ProtoReflect.Descriptor instead.
-func (*Response) Descriptor() ([]byte, []int) {
-	return file_podcast_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use Funding.ProtoReflect.Descriptor instead.
+func (*Funding) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *Response) GetSuccess() bool {
+func (x *Funding) GetUrl() string {
 	if x != nil {
-		return x.Success
+		return x.Url
 	}
-	return false
+	return ""
 }
 
-func (x *Response) GetMessage() string {
+func (x *Funding) GetText() string {
 	if x != nil {
-		return x.Message
+		return x.Text
 	}
 	return ""
 }
 
-type LastPlayedRes struct {
+// Location is what the podcast or episode is about or where it was recorded
+type Location struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Podcast *Podcast `protobuf:"bytes,1,opt,name=podcast,proto3" json:"podcast,omitempty"`
-	Episode *Episode `protobuf:"bytes,2,opt,name=episode,proto3" json:"episode,omitempty"`
-	Millis  int64    `protobuf:"varint,3,opt,name=millis,proto3" json:"millis,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Geo  string `protobuf:"bytes,2,opt,name=geo,proto3" json:"geo,omitempty"`
+	Osm  string `protobuf:"bytes,3,opt,name=osm,proto3" json:"osm,omitempty"`
 }
 
-func (x *LastPlayedRes) Reset() {
-	*x = LastPlayedRes{}
+func (x *Location) Reset() {
+	*x = Location{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_podcast_proto_msgTypes[7]
+		mi := &file_podcast_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *LastPlayedRes) String() string {
+func (x *Location) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LastPlayedRes) ProtoMessage() {}
+func (*Location) ProtoMessage() {}
 
-func (x *LastPlayedRes) ProtoReflect() protoreflect.Message {
-	mi := &file_podcast_proto_msgTypes[7]
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -699,58 +802,61 @@ func (x *LastPlayedRes) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LastPlayedRes.ProtoReflect.Descriptor instead.
-func (*LastPlayedRes) Descriptor() ([]byte, []int) {
-	return file_podcast_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *LastPlayedRes) GetPodcast() *Podcast {
+func (x *Location) GetName() string {
 	if x != nil {
-		return x.Podcast
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *LastPlayedRes) GetEpisode() *Episode {
+func (x *Location) GetGeo() string {
 	if x != nil {
-		return x.Episode
+		return x.Geo
 	}
-	return nil
+	return ""
 }
 
-func (x *LastPlayedRes) GetMillis() int64 {
+func (x *Location) GetOsm() string {
 	if x != nil {
-		return x.Millis
+		return x.Osm
 	}
-	return 0
+	return ""
 }
 
-type Subscriptions struct {
+// Value describes how listeners can pay the recipients while listening
+type Value struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Subscriptions []*Subscription `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
-	Podcasts      []*Podcast      `protobuf:"bytes,2,rep,name=podcasts,proto3" json:"podcasts,omitempty"`
+	Type       string            `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Method     string            `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	Suggested  string            `protobuf:"bytes,3,opt,name=suggested,proto3" json:"suggested,omitempty"`
+	Recipients []*ValueRecipient `protobuf:"bytes,4,rep,name=recipients,proto3" json:"recipients,omitempty"`
 }
 
-func (x *Subscriptions) Reset() {
-	*x = Subscriptions{}
+func (x *Value) Reset() {
+	*x = Value{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_podcast_proto_msgTypes[8]
+		mi := &file_podcast_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Subscriptions) String() string {
+func (x *Value) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Subscriptions) ProtoMessage() {}
+func (*Value) ProtoMessage() {}
 
-func (x *Subscriptions) ProtoReflect() protoreflect.Message {
-	mi := &file_podcast_proto_msgTypes[8]
+func (x *Value) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -761,50 +867,70 @@ func (x *Subscriptions) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Subscriptions.ProtoReflect.Descriptor instead.
-func (*Subscriptions) Descriptor() ([]byte, []int) {
-	return file_podcast_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use Value.ProtoReflect.Descriptor instead.
+func (*Value) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *Subscriptions) GetSubscriptions() []*Subscription {
+func (x *Value) GetType() string {
 	if x != nil {
-		return x.Subscriptions
+		return x.Type
 	}
-	return nil
+	return ""
 }
 
-func (x *Subscriptions) GetPodcasts() []*Podcast {
+func (x *Value) GetMethod() string {
 	if x != nil {
-		return x.Podcasts
+		return x.Method
+	}
+	return ""
+}
+
+func (x *Value) GetSuggested() string {
+	if x != nil {
+		return x.Suggested
+	}
+	return ""
+}
+
+func (x *Value) GetRecipients() []*ValueRecipient {
+	if x != nil {
+		return x.Recipients
 	}
 	return nil
 }
 
-type Episodes struct {
+type ValueRecipient struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Episodes []*Episode `protobuf:"bytes,1,rep,name=episodes,proto3" json:"episodes,omitempty"`
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CustomKey   string `protobuf:"bytes,2,opt,name=customKey,proto3" json:"customKey,omitempty"`
+	CustomValue string `protobuf:"bytes,3,opt,name=customValue,proto3" json:"customValue,omitempty"`
+	Type        string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Address     string `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
+	Split       int32  `protobuf:"varint,6,opt,name=split,proto3" json:"split,omitempty"`
+	Fee         bool   `protobuf:"varint,7,opt,name=fee,proto3" json:"fee,omitempty"`
 }
 
-func (x *Episodes) Reset() {
-	*x = Episodes{}
+func (x *ValueRecipient) Reset() {
+	*x = ValueRecipient{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_podcast_proto_msgTypes[9]
+		mi := &file_podcast_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Episodes) String() string {
+func (x *ValueRecipient) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Episodes) ProtoMessage() {}
+func (*ValueRecipient) ProtoMessage() {}
 
-func (x *Episodes) ProtoReflect() protoreflect.Message {
-	mi := &file_podcast_proto_msgTypes[9]
+func (x *ValueRecipient) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -815,18 +941,2321 @@ func (x *Episodes) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Episodes.ProtoReflect.Descriptor instead.
-func (*Episodes) Descriptor() ([]byte, []int) {
-	return file_podcast_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ValueRecipient.ProtoReflect.Descriptor instead.
+func (*ValueRecipient) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *Episodes) GetEpisodes() []*Episode {
+func (x *ValueRecipient) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ValueRecipient) GetCustomKey() string {
+	if x != nil {
+		return x.CustomKey
+	}
+	return ""
+}
+
+func (x *ValueRecipient) GetCustomValue() string {
+	if x != nil {
+		return x.CustomValue
+	}
+	return ""
+}
+
+func (x *ValueRecipient) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ValueRecipient) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ValueRecipient) GetSplit() int32 {
+	if x != nil {
+		return x.Split
+	}
+	return 0
+}
+
+func (x *ValueRecipient) GetFee() bool {
+	if x != nil {
+		return x.Fee
+	}
+	return false
+}
+
+type Transcript struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url      string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Type     string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Language string `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+	Rel      string `protobuf:"bytes,4,opt,name=rel,proto3" json:"rel,omitempty"`
+}
+
+func (x *Transcript) Reset() {
+	*x = Transcript{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Transcript) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transcript) ProtoMessage() {}
+
+func (x *Transcript) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transcript.ProtoReflect.Descriptor instead.
+func (*Transcript) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Transcript) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Transcript) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Transcript) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Transcript) GetRel() string {
+	if x != nil {
+		return x.Rel
+	}
+	return ""
+}
+
+type Chapters struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url  string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+}
+
+func (x *Chapters) Reset() {
+	*x = Chapters{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Chapters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Chapters) ProtoMessage() {}
+
+func (x *Chapters) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Chapters.ProtoReflect.Descriptor instead.
+func (*Chapters) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Chapters) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Chapters) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+// Chapter is a chapter embedded in the episode's enclosure
+type Chapter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartMillis int64  `protobuf:"varint,1,opt,name=startMillis,proto3" json:"startMillis,omitempty"`
+	EndMillis   int64  `protobuf:"varint,2,opt,name=endMillis,proto3" json:"endMillis,omitempty"`
+	Title       string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Url         string `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	// url of the chapter's image, or a data uri of an image embedded in the enclosure
+	Img string `protobuf:"bytes,5,opt,name=img,proto3" json:"img,omitempty"`
+}
+
+func (x *Chapter) Reset() {
+	*x = Chapter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Chapter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Chapter) ProtoMessage() {}
+
+func (x *Chapter) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Chapter.ProtoReflect.Descriptor instead.
+func (*Chapter) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Chapter) GetStartMillis() int64 {
+	if x != nil {
+		return x.StartMillis
+	}
+	return 0
+}
+
+func (x *Chapter) GetEndMillis() int64 {
+	if x != nil {
+		return x.EndMillis
+	}
+	return 0
+}
+
+func (x *Chapter) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Chapter) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Chapter) GetImg() string {
+	if x != nil {
+		return x.Img
+	}
+	return ""
+}
+
+type ChapterList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Chapters []*Chapter `protobuf:"bytes,1,rep,name=chapters,proto3" json:"chapters,omitempty"`
+}
+
+func (x *ChapterList) Reset() {
+	*x = ChapterList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChapterList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChapterList) ProtoMessage() {}
+
+func (x *ChapterList) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChapterList.ProtoReflect.Descriptor instead.
+func (*ChapterList) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ChapterList) GetChapters() []*Chapter {
+	if x != nil {
+		return x.Chapters
+	}
+	return nil
+}
+
+// AdMarker is an ad dynamically inserted into a variant of the episode's enclosure
+type AdMarker struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartMillis int64 `protobuf:"varint,1,opt,name=startMillis,proto3" json:"startMillis,omitempty"`
+	EndMillis   int64 `protobuf:"varint,2,opt,name=endMillis,proto3" json:"endMillis,omitempty"`
+}
+
+func (x *AdMarker) Reset() {
+	*x = AdMarker{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AdMarker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdMarker) ProtoMessage() {}
+
+func (x *AdMarker) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdMarker.ProtoReflect.Descriptor instead.
+func (*AdMarker) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AdMarker) GetStartMillis() int64 {
+	if x != nil {
+		return x.StartMillis
+	}
+	return 0
+}
+
+func (x *AdMarker) GetEndMillis() int64 {
+	if x != nil {
+		return x.EndMillis
+	}
+	return 0
+}
+
+type AdMarkerList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Markers []*AdMarker `protobuf:"bytes,1,rep,name=markers,proto3" json:"markers,omitempty"`
+}
+
+func (x *AdMarkerList) Reset() {
+	*x = AdMarkerList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AdMarkerList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdMarkerList) ProtoMessage() {}
+
+func (x *AdMarkerList) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdMarkerList.ProtoReflect.Descriptor instead.
+func (*AdMarkerList) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *AdMarkerList) GetMarkers() []*AdMarker {
+	if x != nil {
+		return x.Markers
+	}
+	return nil
+}
+
+// Waveform is the peak amplitude of the episode's audio & its long silences
+type Waveform struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EpisodeID      *ObjectID `protobuf:"bytes,1,opt,name=episodeID,proto3" json:"episodeID,omitempty"`
+	DurationMillis int64     `protobuf:"varint,2,opt,name=durationMillis,proto3" json:"durationMillis,omitempty"`
+	PeaksPerSecond int32     `protobuf:"varint,3,opt,name=peaksPerSecond,proto3" json:"peaksPerSecond,omitempty"`
+	// peak amplitude of each period of the audio, scaled to 0-255
+	Peaks    []byte     `protobuf:"bytes,4,opt,name=peaks,proto3" json:"peaks,omitempty"`
+	Silences []*Silence `protobuf:"bytes,5,rep,name=silences,proto3" json:"silences,omitempty"`
+}
+
+func (x *Waveform) Reset() {
+	*x = Waveform{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Waveform) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Waveform) ProtoMessage() {}
+
+func (x *Waveform) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Waveform.ProtoReflect.Descriptor instead.
+func (*Waveform) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Waveform) GetEpisodeID() *ObjectID {
+	if x != nil {
+		return x.EpisodeID
+	}
+	return nil
+}
+
+func (x *Waveform) GetDurationMillis() int64 {
+	if x != nil {
+		return x.DurationMillis
+	}
+	return 0
+}
+
+func (x *Waveform) GetPeaksPerSecond() int32 {
+	if x != nil {
+		return x.PeaksPerSecond
+	}
+	return 0
+}
+
+func (x *Waveform) GetPeaks() []byte {
+	if x != nil {
+		return x.Peaks
+	}
+	return nil
+}
+
+func (x *Waveform) GetSilences() []*Silence {
+	if x != nil {
+		return x.Silences
+	}
+	return nil
+}
+
+type Silence struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartMillis int64 `protobuf:"varint,1,opt,name=startMillis,proto3" json:"startMillis,omitempty"`
+	EndMillis   int64 `protobuf:"varint,2,opt,name=endMillis,proto3" json:"endMillis,omitempty"`
+}
+
+func (x *Silence) Reset() {
+	*x = Silence{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Silence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Silence) ProtoMessage() {}
+
+func (x *Silence) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Silence.ProtoReflect.Descriptor instead.
+func (*Silence) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Silence) GetStartMillis() int64 {
+	if x != nil {
+		return x.StartMillis
+	}
+	return 0
+}
+
+func (x *Silence) GetEndMillis() int64 {
+	if x != nil {
+		return x.EndMillis
+	}
+	return 0
+}
+
+// Soundbite is a highlight of the episode
+type Soundbite struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartMillis    int64  `protobuf:"varint,1,opt,name=startMillis,proto3" json:"startMillis,omitempty"`
+	DurationMillis int64  `protobuf:"varint,2,opt,name=durationMillis,proto3" json:"durationMillis,omitempty"`
+	Title          string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+}
+
+func (x *Soundbite) Reset() {
+	*x = Soundbite{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Soundbite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Soundbite) ProtoMessage() {}
+
+func (x *Soundbite) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Soundbite.ProtoReflect.Descriptor instead.
+func (*Soundbite) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Soundbite) GetStartMillis() int64 {
+	if x != nil {
+		return x.StartMillis
+	}
+	return 0
+}
+
+func (x *Soundbite) GetDurationMillis() int64 {
+	if x != nil {
+		return x.DurationMillis
+	}
+	return 0
+}
+
+func (x *Soundbite) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+// AlternateEnclosure is another version of the episode's media, such as a different bitrate or video
+type AlternateEnclosure struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type    string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Length  int64    `protobuf:"varint,2,opt,name=length,proto3" json:"length,omitempty"`
+	Bitrate int64    `protobuf:"varint,3,opt,name=bitrate,proto3" json:"bitrate,omitempty"`
+	Height  int32    `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	Lang    string   `protobuf:"bytes,5,opt,name=lang,proto3" json:"lang,omitempty"`
+	Title   string   `protobuf:"bytes,6,opt,name=title,proto3" json:"title,omitempty"`
+	Rel     string   `protobuf:"bytes,7,opt,name=rel,proto3" json:"rel,omitempty"`
+	Codecs  string   `protobuf:"bytes,8,opt,name=codecs,proto3" json:"codecs,omitempty"`
+	Default bool     `protobuf:"varint,9,opt,name=default,proto3" json:"default,omitempty"`
+	Sources []string `protobuf:"bytes,10,rep,name=sources,proto3" json:"sources,omitempty"`
+}
+
+func (x *AlternateEnclosure) Reset() {
+	*x = AlternateEnclosure{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AlternateEnclosure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlternateEnclosure) ProtoMessage() {}
+
+func (x *AlternateEnclosure) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlternateEnclosure.ProtoReflect.Descriptor instead.
+func (*AlternateEnclosure) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AlternateEnclosure) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AlternateEnclosure) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *AlternateEnclosure) GetBitrate() int64 {
+	if x != nil {
+		return x.Bitrate
+	}
+	return 0
+}
+
+func (x *AlternateEnclosure) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *AlternateEnclosure) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *AlternateEnclosure) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *AlternateEnclosure) GetRel() string {
+	if x != nil {
+		return x.Rel
+	}
+	return ""
+}
+
+func (x *AlternateEnclosure) GetCodecs() string {
+	if x != nil {
+		return x.Codecs
+	}
+	return ""
+}
+
+func (x *AlternateEnclosure) GetDefault() bool {
+	if x != nil {
+		return x.Default
+	}
+	return false
+}
+
+func (x *AlternateEnclosure) GetSources() []string {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+// start & end represen the amount of episodes to return
+type Request struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//string token = 1;
+	PodcastID *ObjectID `protobuf:"bytes,2,opt,name=podcastID,proto3" json:"podcastID,omitempty"`
+	EpisodeID *ObjectID `protobuf:"bytes,3,opt,name=episodeID,proto3" json:"episodeID,omitempty"`
+	Start     int64     `protobuf:"varint,4,opt,name=start,proto3" json:"start,omitempty"`
+	End       int64     `protobuf:"varint,5,opt,name=end,proto3" json:"end,omitempty"`
+	// length in bytes of the enclosure variant the client downloaded, identifies the variant's timeline
+	EnclosureLength int64 `protobuf:"varint,6,opt,name=enclosureLength,proto3" json:"enclosureLength,omitempty"`
+}
+
+func (x *Request) Reset() {
+	*x = Request{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request) ProtoMessage() {}
+
+func (x *Request) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request.ProtoReflect.Descriptor instead.
+func (*Request) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Request) GetPodcastID() *ObjectID {
+	if x != nil {
+		return x.PodcastID
+	}
+	return nil
+}
+
+func (x *Request) GetEpisodeID() *ObjectID {
+	if x != nil {
+		return x.EpisodeID
+	}
+	return nil
+}
+
+func (x *Request) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *Request) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *Request) GetEnclosureLength() int64 {
+	if x != nil {
+		return x.EnclosureLength
+	}
+	return 0
+}
+
+type UserEpisodeReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//string token = 1;
+	PodcastID *ObjectID            `protobuf:"bytes,2,opt,name=podcastID,proto3" json:"podcastID,omitempty"`
+	EpisodeID *ObjectID            `protobuf:"bytes,3,opt,name=episodeID,proto3" json:"episodeID,omitempty"`
+	Offset    int64                `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	LastSeen  *timestamp.Timestamp `protobuf:"bytes,5,opt,name=lastSeen,proto3" json:"lastSeen,omitempty"`
+	Played    bool                 `protobuf:"varint,6,opt,name=played,proto3" json:"played,omitempty"`
+	// length in bytes of the enclosure variant the offset is within
+	EnclosureLength int64 `protobuf:"varint,7,opt,name=enclosureLength,proto3" json:"enclosureLength,omitempty"`
+	// the registered device updating the playback
+	DeviceID *ObjectID `protobuf:"bytes,8,opt,name=deviceID,proto3" json:"deviceID,omitempty"`
+}
+
+func (x *UserEpisodeReq) Reset() {
+	*x = UserEpisodeReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserEpisodeReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserEpisodeReq) ProtoMessage() {}
+
+func (x *UserEpisodeReq) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserEpisodeReq.ProtoReflect.Descriptor instead.
+func (*UserEpisodeReq) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UserEpisodeReq) GetPodcastID() *ObjectID {
+	if x != nil {
+		return x.PodcastID
+	}
+	return nil
+}
+
+func (x *UserEpisodeReq) GetEpisodeID() *ObjectID {
+	if x != nil {
+		return x.EpisodeID
+	}
+	return nil
+}
+
+func (x *UserEpisodeReq) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *UserEpisodeReq) GetLastSeen() *timestamp.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+func (x *UserEpisodeReq) GetPlayed() bool {
+	if x != nil {
+		return x.Played
+	}
+	return false
+}
+
+func (x *UserEpisodeReq) GetEnclosureLength() int64 {
+	if x != nil {
+		return x.EnclosureLength
+	}
+	return 0
+}
+
+func (x *UserEpisodeReq) GetDeviceID() *ObjectID {
+	if x != nil {
+		return x.DeviceID
+	}
+	return nil
+}
+
+// UserEpisodeRes is the result of updating the playback, a stale update is not applied
+// and userEpisode is the newer playback stored instead
+type UserEpisodeRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Success     bool         `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message     string       `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	UserEpisode *UserEpisode `protobuf:"bytes,3,opt,name=userEpisode,proto3" json:"userEpisode,omitempty"`
+}
+
+func (x *UserEpisodeRes) Reset() {
+	*x = UserEpisodeRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserEpisodeRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserEpisodeRes) ProtoMessage() {}
+
+func (x *UserEpisodeRes) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserEpisodeRes.ProtoReflect.Descriptor instead.
+func (*UserEpisodeRes) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UserEpisodeRes) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UserEpisodeRes) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *UserEpisodeRes) GetUserEpisode() *UserEpisode {
+	if x != nil {
+		return x.UserEpisode
+	}
+	return nil
+}
+
+type Devices struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Devices []*Device `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+}
+
+func (x *Devices) Reset() {
+	*x = Devices{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Devices) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Devices) ProtoMessage() {}
+
+func (x *Devices) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Devices.ProtoReflect.Descriptor instead.
+func (*Devices) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Devices) GetDevices() []*Device {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+type DeviceReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceID *ObjectID `protobuf:"bytes,1,opt,name=deviceID,proto3" json:"deviceID,omitempty"`
+}
+
+func (x *DeviceReq) Reset() {
+	*x = DeviceReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeviceReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceReq) ProtoMessage() {}
+
+func (x *DeviceReq) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceReq.ProtoReflect.Descriptor instead.
+func (*DeviceReq) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DeviceReq) GetDeviceID() *ObjectID {
+	if x != nil {
+		return x.DeviceID
+	}
+	return nil
+}
+
+// SyncReq requests the changes since the cursor of the previous sync,
+// an empty cursor requests all of the user's subscriptions and playbacks
+type SyncReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cursor string `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+}
+
+func (x *SyncReq) Reset() {
+	*x = SyncReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncReq) ProtoMessage() {}
+
+func (x *SyncReq) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncReq.ProtoReflect.Descriptor instead.
+func (*SyncReq) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SyncReq) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type SyncRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Changes []*Change `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	// opaque, passed to the next sync
+	Cursor string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+}
+
+func (x *SyncRes) Reset() {
+	*x = SyncRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncRes) ProtoMessage() {}
+
+func (x *SyncRes) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncRes.ProtoReflect.Descriptor instead.
+func (*SyncRes) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SyncRes) GetChanges() []*Change {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *SyncRes) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Success bool   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *Response) Reset() {
+	*x = Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Response) ProtoMessage() {}
+
+func (x *Response) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Response.ProtoReflect.Descriptor instead.
+func (*Response) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Response) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *Response) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type LastPlayedRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Podcast *Podcast `protobuf:"bytes,1,opt,name=podcast,proto3" json:"podcast,omitempty"`
+	Episode *Episode `protobuf:"bytes,2,opt,name=episode,proto3" json:"episode,omitempty"`
+	Millis  int64    `protobuf:"varint,3,opt,name=millis,proto3" json:"millis,omitempty"`
+}
+
+func (x *LastPlayedRes) Reset() {
+	*x = LastPlayedRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LastPlayedRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LastPlayedRes) ProtoMessage() {}
+
+func (x *LastPlayedRes) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LastPlayedRes.ProtoReflect.Descriptor instead.
+func (*LastPlayedRes) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *LastPlayedRes) GetPodcast() *Podcast {
+	if x != nil {
+		return x.Podcast
+	}
+	return nil
+}
+
+func (x *LastPlayedRes) GetEpisode() *Episode {
+	if x != nil {
+		return x.Episode
+	}
+	return nil
+}
+
+func (x *LastPlayedRes) GetMillis() int64 {
+	if x != nil {
+		return x.Millis
+	}
+	return 0
+}
+
+type Subscriptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Subscriptions []*Subscription `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	Podcasts      []*Podcast      `protobuf:"bytes,2,rep,name=podcasts,proto3" json:"podcasts,omitempty"`
+}
+
+func (x *Subscriptions) Reset() {
+	*x = Subscriptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Subscriptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subscriptions) ProtoMessage() {}
+
+func (x *Subscriptions) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subscriptions.ProtoReflect.Descriptor instead.
+func (*Subscriptions) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Subscriptions) GetSubscriptions() []*Subscription {
+	if x != nil {
+		return x.Subscriptions
+	}
+	return nil
+}
+
+func (x *Subscriptions) GetPodcasts() []*Podcast {
+	if x != nil {
+		return x.Podcasts
+	}
+	return nil
+}
+
+// SubscribeReq subscribes to a podcast by id, or by rss url adding the podcast if it's new
+type SubscribeReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PodcastID *ObjectID `protobuf:"bytes,1,opt,name=podcastID,proto3" json:"podcastID,omitempty"`
+	Url       string    `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+}
+
+func (x *SubscribeReq) Reset() {
+	*x = SubscribeReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeReq) ProtoMessage() {}
+
+func (x *SubscribeReq) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeReq.ProtoReflect.Descriptor instead.
+func (*SubscribeReq) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SubscribeReq) GetPodcastID() *ObjectID {
+	if x != nil {
+		return x.PodcastID
+	}
+	return nil
+}
+
+func (x *SubscribeReq) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+// SubscriptionReq replaces the settings of the subscription to the podcast
+type SubscriptionReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PodcastID *ObjectID             `protobuf:"bytes,1,opt,name=podcastID,proto3" json:"podcastID,omitempty"`
+	Settings  *SubscriptionSettings `protobuf:"bytes,2,opt,name=settings,proto3" json:"settings,omitempty"`
+}
+
+func (x *SubscriptionReq) Reset() {
+	*x = SubscriptionReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscriptionReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionReq) ProtoMessage() {}
+
+func (x *SubscriptionReq) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionReq.ProtoReflect.Descriptor instead.
+func (*SubscriptionReq) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SubscriptionReq) GetPodcastID() *ObjectID {
+	if x != nil {
+		return x.PodcastID
+	}
+	return nil
+}
+
+func (x *SubscriptionReq) GetSettings() *SubscriptionSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+// QueueReq queues, moves or removes the episode in the user's queue
+type QueueReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EpisodeID *ObjectID `protobuf:"bytes,1,opt,name=episodeID,proto3" json:"episodeID,omitempty"`
+	// Enqueue queues the episode to play next instead of last
+	Next bool `protobuf:"varint,2,opt,name=next,proto3" json:"next,omitempty"`
+	// ReorderQueue moves the episode to the position, positions past the end move it last
+	Position int32 `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"`
+}
+
+func (x *QueueReq) Reset() {
+	*x = QueueReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueueReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueReq) ProtoMessage() {}
+
+func (x *QueueReq) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueReq.ProtoReflect.Descriptor instead.
+func (*QueueReq) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *QueueReq) GetEpisodeID() *ObjectID {
+	if x != nil {
+		return x.EpisodeID
+	}
+	return nil
+}
+
+func (x *QueueReq) GetNext() bool {
+	if x != nil {
+		return x.Next
+	}
+	return false
+}
+
+func (x *QueueReq) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type Playlists struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Playlists []*Playlist `protobuf:"bytes,1,rep,name=playlists,proto3" json:"playlists,omitempty"`
+}
+
+func (x *Playlists) Reset() {
+	*x = Playlists{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Playlists) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Playlists) ProtoMessage() {}
+
+func (x *Playlists) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Playlists.ProtoReflect.Descriptor instead.
+func (*Playlists) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Playlists) GetPlaylists() []*Playlist {
+	if x != nil {
+		return x.Playlists
+	}
+	return nil
+}
+
+// PlaylistReq requests the playlist, start & end are the range of its episodes to evaluate
+type PlaylistReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlaylistID *ObjectID `protobuf:"bytes,1,opt,name=playlistID,proto3" json:"playlistID,omitempty"`
+	Start      int64     `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	End        int64     `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+}
+
+func (x *PlaylistReq) Reset() {
+	*x = PlaylistReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlaylistReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaylistReq) ProtoMessage() {}
+
+func (x *PlaylistReq) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaylistReq.ProtoReflect.Descriptor instead.
+func (*PlaylistReq) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *PlaylistReq) GetPlaylistID() *ObjectID {
+	if x != nil {
+		return x.PlaylistID
+	}
+	return nil
+}
+
+func (x *PlaylistReq) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *PlaylistReq) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+// PlaylistEpisodes is a page of the episodes of a playlist, more is whether episodes match past the page
+type PlaylistEpisodes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Episodes []*Episode `protobuf:"bytes,1,rep,name=episodes,proto3" json:"episodes,omitempty"`
+	More     bool       `protobuf:"varint,3,opt,name=more,proto3" json:"more,omitempty"`
+}
+
+func (x *PlaylistEpisodes) Reset() {
+	*x = PlaylistEpisodes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlaylistEpisodes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaylistEpisodes) ProtoMessage() {}
+
+func (x *PlaylistEpisodes) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaylistEpisodes.ProtoReflect.Descriptor instead.
+func (*PlaylistEpisodes) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *PlaylistEpisodes) GetEpisodes() []*Episode {
+	if x != nil {
+		return x.Episodes
+	}
+	return nil
+}
+
+func (x *PlaylistEpisodes) GetMore() bool {
+	if x != nil {
+		return x.More
+	}
+	return false
+}
+
+// OPML is an OPML 1.0 or 2.0 document of podcast subscriptions
+type OPML struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Document []byte `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"`
+}
+
+func (x *OPML) Reset() {
+	*x = OPML{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OPML) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OPML) ProtoMessage() {}
+
+func (x *OPML) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OPML.ProtoReflect.Descriptor instead.
+func (*OPML) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *OPML) GetDocument() []byte {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+type ImportReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ImportID *ObjectID `protobuf:"bytes,1,opt,name=importID,proto3" json:"importID,omitempty"`
+}
+
+func (x *ImportReq) Reset() {
+	*x = ImportReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportReq) ProtoMessage() {}
+
+func (x *ImportReq) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportReq.ProtoReflect.Descriptor instead.
+func (*ImportReq) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ImportReq) GetImportID() *ObjectID {
+	if x != nil {
+		return x.ImportID
+	}
+	return nil
+}
+
+// ImportProgress is the progress of subscribing to the feeds of an OPML document
+type ImportProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     *ObjectID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty" bson:"_id,omitempty"`
+	UserID *ObjectID `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"`
+	Total  int32     `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	// feeds subscribed to or failed so far
+	Done       int32                `protobuf:"varint,4,opt,name=done,proto3" json:"done,omitempty"`
+	Subscribed int32                `protobuf:"varint,5,opt,name=subscribed,proto3" json:"subscribed,omitempty"`
+	Failures   []*ImportFailure     `protobuf:"bytes,6,rep,name=failures,proto3" json:"failures,omitempty"`
+	Finished   bool                 `protobuf:"varint,7,opt,name=finished,proto3" json:"finished,omitempty"`
+	Started    *timestamp.Timestamp `protobuf:"bytes,8,opt,name=started,proto3" json:"started,omitempty"`
+}
+
+func (x *ImportProgress) Reset() {
+	*x = ImportProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportProgress) ProtoMessage() {}
+
+func (x *ImportProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportProgress.ProtoReflect.Descriptor instead.
+func (*ImportProgress) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ImportProgress) GetId() *ObjectID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *ImportProgress) GetUserID() *ObjectID {
+	if x != nil {
+		return x.UserID
+	}
+	return nil
+}
+
+func (x *ImportProgress) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ImportProgress) GetDone() int32 {
+	if x != nil {
+		return x.Done
+	}
+	return 0
+}
+
+func (x *ImportProgress) GetSubscribed() int32 {
+	if x != nil {
+		return x.Subscribed
+	}
+	return 0
+}
+
+func (x *ImportProgress) GetFailures() []*ImportFailure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+func (x *ImportProgress) GetFinished() bool {
+	if x != nil {
+		return x.Finished
+	}
+	return false
+}
+
+func (x *ImportProgress) GetStarted() *timestamp.Timestamp {
+	if x != nil {
+		return x.Started
+	}
+	return nil
+}
+
+// ImportFailure is a feed that couldn't be subscribed to
+type ImportFailure struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url   string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Title string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *ImportFailure) Reset() {
+	*x = ImportFailure{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportFailure) ProtoMessage() {}
+
+func (x *ImportFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportFailure.ProtoReflect.Descriptor instead.
+func (*ImportFailure) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ImportFailure) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ImportFailure) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ImportFailure) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type Episodes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Episodes []*Episode `protobuf:"bytes,1,rep,name=episodes,proto3" json:"episodes,omitempty"`
+}
+
+func (x *Episodes) Reset() {
+	*x = Episodes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Episodes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Episodes) ProtoMessage() {}
+
+func (x *Episodes) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Episodes.ProtoReflect.Descriptor instead.
+func (*Episodes) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *Episodes) GetEpisodes() []*Episode {
 	if x != nil {
 		return x.Episodes
 	}
 	return nil
 }
 
+// FeedSchedule is when the podcast's feed will next be checked and the outcome of the last check
+type FeedSchedule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PodcastID      *ObjectID            `protobuf:"bytes,1,opt,name=podcastID,proto3" json:"podcastID,omitempty"`
+	NextCheck      *timestamp.Timestamp `protobuf:"bytes,2,opt,name=nextCheck,proto3" json:"nextCheck,omitempty"`
+	LastCheck      *timestamp.Timestamp `protobuf:"bytes,3,opt,name=lastCheck,proto3" json:"lastCheck,omitempty"`
+	LastResult     string               `protobuf:"bytes,4,opt,name=lastResult,proto3" json:"lastResult,omitempty"`
+	Failures       int32                `protobuf:"varint,5,opt,name=failures,proto3" json:"failures,omitempty"`
+	IntervalMillis int64                `protobuf:"varint,6,opt,name=intervalMillis,proto3" json:"intervalMillis,omitempty"`
+}
+
+func (x *FeedSchedule) Reset() {
+	*x = FeedSchedule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FeedSchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedSchedule) ProtoMessage() {}
+
+func (x *FeedSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedSchedule.ProtoReflect.Descriptor instead.
+func (*FeedSchedule) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *FeedSchedule) GetPodcastID() *ObjectID {
+	if x != nil {
+		return x.PodcastID
+	}
+	return nil
+}
+
+func (x *FeedSchedule) GetNextCheck() *timestamp.Timestamp {
+	if x != nil {
+		return x.NextCheck
+	}
+	return nil
+}
+
+func (x *FeedSchedule) GetLastCheck() *timestamp.Timestamp {
+	if x != nil {
+		return x.LastCheck
+	}
+	return nil
+}
+
+func (x *FeedSchedule) GetLastResult() string {
+	if x != nil {
+		return x.LastResult
+	}
+	return ""
+}
+
+func (x *FeedSchedule) GetFailures() int32 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+func (x *FeedSchedule) GetIntervalMillis() int64 {
+	if x != nil {
+		return x.IntervalMillis
+	}
+	return 0
+}
+
+// FeedHealth is the health of a podcast's feed, quarantined feeds are checked less often
+type FeedHealth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PodcastID           *ObjectID            `protobuf:"bytes,1,opt,name=podcastID,proto3" json:"podcastID,omitempty"`
+	Title               string               `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Rss                 string               `protobuf:"bytes,3,opt,name=rss,proto3" json:"rss,omitempty"`
+	ConsecutiveFailures int32                `protobuf:"varint,4,opt,name=consecutiveFailures,proto3" json:"consecutiveFailures,omitempty"`
+	LastErrorClass      string               `protobuf:"bytes,5,opt,name=lastErrorClass,proto3" json:"lastErrorClass,omitempty"`
+	LastError           string               `protobuf:"bytes,6,opt,name=lastError,proto3" json:"lastError,omitempty"`
+	LastFailure         *timestamp.Timestamp `protobuf:"bytes,7,opt,name=lastFailure,proto3" json:"lastFailure,omitempty"`
+	LastSuccess         *timestamp.Timestamp `protobuf:"bytes,8,opt,name=lastSuccess,proto3" json:"lastSuccess,omitempty"`
+	Quarantined         bool                 `protobuf:"varint,9,opt,name=quarantined,proto3" json:"quarantined,omitempty"`
+}
+
+func (x *FeedHealth) Reset() {
+	*x = FeedHealth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FeedHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedHealth) ProtoMessage() {}
+
+func (x *FeedHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedHealth.ProtoReflect.Descriptor instead.
+func (*FeedHealth) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *FeedHealth) GetPodcastID() *ObjectID {
+	if x != nil {
+		return x.PodcastID
+	}
+	return nil
+}
+
+func (x *FeedHealth) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *FeedHealth) GetRss() string {
+	if x != nil {
+		return x.Rss
+	}
+	return ""
+}
+
+func (x *FeedHealth) GetConsecutiveFailures() int32 {
+	if x != nil {
+		return x.ConsecutiveFailures
+	}
+	return 0
+}
+
+func (x *FeedHealth) GetLastErrorClass() string {
+	if x != nil {
+		return x.LastErrorClass
+	}
+	return ""
+}
+
+func (x *FeedHealth) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *FeedHealth) GetLastFailure() *timestamp.Timestamp {
+	if x != nil {
+		return x.LastFailure
+	}
+	return nil
+}
+
+func (x *FeedHealth) GetLastSuccess() *timestamp.Timestamp {
+	if x != nil {
+		return x.LastSuccess
+	}
+	return nil
+}
+
+func (x *FeedHealth) GetQuarantined() bool {
+	if x != nil {
+		return x.Quarantined
+	}
+	return false
+}
+
+// PrivateFeedReq is the url & optional basic auth credentials of a private feed
+type PrivateFeedReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url      string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Password string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+}
+
+func (x *PrivateFeedReq) Reset() {
+	*x = PrivateFeedReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrivateFeedReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrivateFeedReq) ProtoMessage() {}
+
+func (x *PrivateFeedReq) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrivateFeedReq.ProtoReflect.Descriptor instead.
+func (*PrivateFeedReq) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *PrivateFeedReq) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *PrivateFeedReq) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *PrivateFeedReq) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type FeedHealthList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Feeds []*FeedHealth `protobuf:"bytes,1,rep,name=feeds,proto3" json:"feeds,omitempty"`
+}
+
+func (x *FeedHealthList) Reset() {
+	*x = FeedHealthList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_podcast_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FeedHealthList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedHealthList) ProtoMessage() {}
+
+func (x *FeedHealthList) ProtoReflect() protoreflect.Message {
+	mi := &file_podcast_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedHealthList.ProtoReflect.Descriptor instead.
+func (*FeedHealthList) Descriptor() ([]byte, []int) {
+	return file_podcast_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *FeedHealthList) GetFeeds() []*FeedHealth {
+	if x != nil {
+		return x.Feeds
+	}
+	return nil
+}
+
 var File_podcast_proto protoreflect.FileDescriptor
 
 var file_podcast_proto_rawDesc = []byte{
@@ -843,7 +3272,7 @@ var file_podcast_proto_rawDesc = []byte{
 	0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x2c, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72,
 	0x79, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
 	0x2e, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67,
-	0x6f, 0x72, 0x79, 0x22, 0xce, 0x03, 0x0a, 0x07, 0x50, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x12,
+	0x6f, 0x72, 0x79, 0x22, 0x8a, 0x06, 0x0a, 0x07, 0x50, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x12,
 	0x20, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x44, 0x52, 0x02, 0x69,
 	0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
@@ -872,109 +3301,509 @@ var file_podcast_proto_rawDesc = []byte{
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
 	0x61, 0x6d, 0x70, 0x52, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x44, 0x61,
 	0x74, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x73, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x72, 0x73, 0x73, 0x22, 0x8c, 0x04, 0x0a, 0x07, 0x45, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65,
-	0x12, 0x20, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x44, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x2e, 0x0a, 0x09, 0x70, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x44, 0x18,
+	0x03, 0x72, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x67, 0x75, 0x69, 0x64, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x67, 0x75, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x6b,
+	0x65, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64,
+	0x12, 0x20, 0x0a, 0x0b, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x18,
+	0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x4f, 0x77, 0x6e,
+	0x65, 0x72, 0x12, 0x28, 0x0a, 0x07, 0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x73, 0x18, 0x12, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x50, 0x65, 0x72,
+	0x73, 0x6f, 0x6e, 0x52, 0x07, 0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x73, 0x12, 0x29, 0x0a, 0x07,
+	0x66, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x13, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x46, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x07,
+	0x66, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x2c, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x73, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x15,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72,
+	0x69, 0x76, 0x61, 0x74, 0x65, 0x18, 0x16, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x72, 0x69,
+	0x76, 0x61, 0x74, 0x65, 0x12, 0x2a, 0x0a, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x44, 0x18,
+	0x17, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x44, 0x52, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x44,
+	0x22, 0xaf, 0x08, 0x0a, 0x07, 0x45, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x73, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2e,
+	0x0a, 0x09, 0x70, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x49, 0x44, 0x52, 0x09, 0x70, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x44, 0x12, 0x14,
+	0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
+	0x69, 0x74, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x12, 0x23, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x05,
+	0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x34, 0x0a, 0x07, 0x70, 0x75, 0x62, 0x44, 0x61, 0x74, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x07, 0x70, 0x75, 0x62, 0x44, 0x61, 0x74, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a,
+	0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12,
+	0x18, 0x0a, 0x07, 0x65, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x65, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x12, 0x2c, 0x0a, 0x08, 0x63, 0x61, 0x74,
+	0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x52, 0x08, 0x63,
+	0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x70, 0x6c, 0x69,
+	0x63, 0x69, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x70, 0x6c, 0x69,
+	0x63, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x4d, 0x50, 0x33, 0x55, 0x52, 0x4c, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x4d, 0x50, 0x33, 0x55, 0x52, 0x4c, 0x12, 0x26, 0x0a, 0x0e, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0e, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x6c,
+	0x6c, 0x69, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x75, 0x62, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18,
+	0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x75, 0x62, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12,
+	0x34, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x73, 0x18, 0x11,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x73, 0x12, 0x2c, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x70, 0x74, 0x65, 0x72,
+	0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
+	0x2e, 0x43, 0x68, 0x61, 0x70, 0x74, 0x65, 0x72, 0x73, 0x52, 0x08, 0x63, 0x68, 0x61, 0x70, 0x74,
+	0x65, 0x72, 0x73, 0x12, 0x28, 0x0a, 0x07, 0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x73, 0x18, 0x13,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x50, 0x65,
+	0x72, 0x73, 0x6f, 0x6e, 0x52, 0x07, 0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x73, 0x12, 0x1e, 0x0a,
+	0x0a, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x31, 0x0a,
+	0x0a, 0x73, 0x6f, 0x75, 0x6e, 0x64, 0x62, 0x69, 0x74, 0x65, 0x73, 0x18, 0x15, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53, 0x6f, 0x75, 0x6e, 0x64,
+	0x62, 0x69, 0x74, 0x65, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x6e, 0x64, 0x62, 0x69, 0x74, 0x65, 0x73,
+	0x12, 0x2c, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x16, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x12, 0x4c, 0x0a, 0x13, 0x61, 0x6c, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x74, 0x65,
+	0x45, 0x6e, 0x63, 0x6c, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x73, 0x18, 0x18, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x41, 0x6c, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x74, 0x65, 0x45, 0x6e, 0x63, 0x6c, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x52, 0x13, 0x61, 0x6c,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x63, 0x6c, 0x6f, 0x73, 0x75, 0x72, 0x65,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x67, 0x75, 0x69, 0x64, 0x18, 0x19, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x67, 0x75, 0x69, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x65, 0x6e, 0x63, 0x6c, 0x6f, 0x73, 0x75,
+	0x72, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x65, 0x6e,
+	0x63, 0x6c, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x65,
+	0x6e, 0x63, 0x6c, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x1b,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x65, 0x6e, 0x63, 0x6c, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x4c,
+	0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x3b, 0x0a, 0x10, 0x65, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x65,
+	0x64, 0x43, 0x68, 0x61, 0x70, 0x74, 0x65, 0x72, 0x73, 0x18, 0x1c, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x68, 0x61, 0x70, 0x74, 0x65, 0x72,
+	0x52, 0x10, 0x65, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x65, 0x64, 0x43, 0x68, 0x61, 0x70, 0x74, 0x65,
+	0x72, 0x73, 0x22, 0x6c, 0x0a, 0x06, 0x50, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x72, 0x6f, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x6d,
+	0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x69, 0x6d, 0x67, 0x12, 0x12, 0x0a, 0x04,
+	0x68, 0x72, 0x65, 0x66, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x72, 0x65, 0x66,
+	0x22, 0x2f, 0x0a, 0x07, 0x46, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x75,
+	0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78,
+	0x74, 0x22, 0x42, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x10, 0x0a, 0x03, 0x67, 0x65, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x67, 0x65, 0x6f, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x73, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6f, 0x73, 0x6d, 0x22, 0x89, 0x01, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x36, 0x0a, 0x0a, 0x72, 0x65, 0x63,
+	0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x65, 0x63, 0x69,
+	0x70, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74,
+	0x73, 0x22, 0xba, 0x01, 0x0a, 0x0e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x65, 0x63, 0x69, 0x70,
+	0x69, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x75, 0x73, 0x74,
+	0x6f, 0x6d, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x75, 0x73,
+	0x74, 0x6f, 0x6d, 0x4b, 0x65, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x75, 0x73,
+	0x74, 0x6f, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x66, 0x65, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x66, 0x65, 0x65, 0x22, 0x60,
+	0x0a, 0x0a, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x72, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x72, 0x65, 0x6c,
+	0x22, 0x30, 0x0a, 0x08, 0x43, 0x68, 0x61, 0x70, 0x74, 0x65, 0x72, 0x73, 0x12, 0x10, 0x0a, 0x03,
+	0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x22, 0x83, 0x01, 0x0a, 0x07, 0x43, 0x68, 0x61, 0x70, 0x74, 0x65, 0x72, 0x12, 0x20,
+	0x0a, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73,
+	0x12, 0x1c, 0x0a, 0x09, 0x65, 0x6e, 0x64, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x6e, 0x64, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
+	0x69, 0x74, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x6d, 0x67, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x69, 0x6d, 0x67, 0x22, 0x3a, 0x0a, 0x0b, 0x43, 0x68, 0x61, 0x70,
+	0x74, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x70, 0x74,
+	0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x73, 0x2e, 0x43, 0x68, 0x61, 0x70, 0x74, 0x65, 0x72, 0x52, 0x08, 0x63, 0x68, 0x61, 0x70,
+	0x74, 0x65, 0x72, 0x73, 0x22, 0x4a, 0x0a, 0x08, 0x41, 0x64, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x72,
+	0x12, 0x20, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4d, 0x69, 0x6c, 0x6c,
+	0x69, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x6e, 0x64, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x6e, 0x64, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73,
+	0x22, 0x3a, 0x0a, 0x0c, 0x41, 0x64, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74,
+	0x12, 0x2a, 0x0a, 0x07, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x41, 0x64, 0x4d, 0x61, 0x72,
+	0x6b, 0x65, 0x72, 0x52, 0x07, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x72, 0x73, 0x22, 0xcd, 0x01, 0x0a,
+	0x08, 0x57, 0x61, 0x76, 0x65, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x2e, 0x0a, 0x09, 0x65, 0x70, 0x69,
+	0x73, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x44, 0x52, 0x09,
+	0x65, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x12, 0x26, 0x0a, 0x0e, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0e, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x6c, 0x6c, 0x69,
+	0x73, 0x12, 0x26, 0x0a, 0x0e, 0x70, 0x65, 0x61, 0x6b, 0x73, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x70, 0x65, 0x61, 0x6b, 0x73,
+	0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x65, 0x61,
+	0x6b, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x70, 0x65, 0x61, 0x6b, 0x73, 0x12,
+	0x2b, 0x0a, 0x08, 0x73, 0x69, 0x6c, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53, 0x69, 0x6c, 0x65, 0x6e,
+	0x63, 0x65, 0x52, 0x08, 0x73, 0x69, 0x6c, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x22, 0x49, 0x0a, 0x07,
+	0x53, 0x69, 0x6c, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x6e, 0x64,
+	0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x6e,
+	0x64, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x22, 0x6b, 0x0a, 0x09, 0x53, 0x6f, 0x75, 0x6e, 0x64,
+	0x62, 0x69, 0x74, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4d, 0x69, 0x6c,
+	0x6c, 0x69, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e,
+	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
+	0x69, 0x74, 0x6c, 0x65, 0x22, 0xfa, 0x01, 0x0a, 0x12, 0x41, 0x6c, 0x74, 0x65, 0x72, 0x6e, 0x61,
+	0x74, 0x65, 0x45, 0x6e, 0x63, 0x6c, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x69, 0x74, 0x72, 0x61,
+	0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x62, 0x69, 0x74, 0x72, 0x61, 0x74,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x61, 0x6e,
+	0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x61, 0x6e, 0x67, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69,
+	0x74, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x65, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x72, 0x65, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x73, 0x22, 0xbb, 0x01, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2e, 0x0a,
+	0x09, 0x70, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x49, 0x44, 0x52, 0x09, 0x70, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x44, 0x12, 0x2e, 0x0a,
+	0x09, 0x65, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x49, 0x44, 0x52, 0x09, 0x65, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x65, 0x6e, 0x63, 0x6c, 0x6f, 0x73, 0x75,
+	0x72, 0x65, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f,
+	0x65, 0x6e, 0x63, 0x6c, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22,
+	0xb0, 0x02, 0x0a, 0x0e, 0x55, 0x73, 0x65, 0x72, 0x45, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x52,
+	0x65, 0x71, 0x12, 0x2e, 0x0a, 0x09, 0x70, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x44, 0x18,
 	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4f,
 	0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x44, 0x52, 0x09, 0x70, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74,
-	0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x75, 0x74, 0x68,
-	0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72,
-	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x74, 0x79, 0x70, 0x65, 0x12, 0x23, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x49, 0x6d, 0x61,
-	0x67, 0x65, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x34, 0x0a, 0x07, 0x70, 0x75, 0x62,
-	0x44, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x70, 0x75, 0x62, 0x44, 0x61, 0x74, 0x65, 0x12,
-	0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x09, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73,
-	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x65, 0x61,
-	0x73, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x18, 0x0b,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x65, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x12, 0x2c, 0x0a,
-	0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72,
-	0x79, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x65,
-	0x78, 0x70, 0x6c, 0x69, 0x63, 0x69, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65,
-	0x78, 0x70, 0x6c, 0x69, 0x63, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x4d, 0x50, 0x33, 0x55, 0x52,
-	0x4c, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x4d, 0x50, 0x33, 0x55, 0x52, 0x4c, 0x12,
-	0x26, 0x0a, 0x0e, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x6c, 0x6c, 0x69,
-	0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x75, 0x62, 0x74, 0x69,
-	0x74, 0x6c, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x75, 0x62, 0x74, 0x69,
-	0x74, 0x6c, 0x65, 0x22, 0x91, 0x01, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x2e, 0x0a, 0x09, 0x70, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4f, 0x62, 0x6a, 0x65,
-	0x63, 0x74, 0x49, 0x44, 0x52, 0x09, 0x70, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x44, 0x12,
-	0x2e, 0x0a, 0x09, 0x65, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4f, 0x62, 0x6a, 0x65,
-	0x63, 0x74, 0x49, 0x44, 0x52, 0x09, 0x65, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x12,
-	0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
-	0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x22, 0xd8, 0x01, 0x0a, 0x0e, 0x55, 0x73, 0x65, 0x72,
-	0x45, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x12, 0x2e, 0x0a, 0x09, 0x70, 0x6f,
-	0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x44, 0x52,
-	0x09, 0x70, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x44, 0x12, 0x2e, 0x0a, 0x09, 0x65, 0x70,
-	0x69, 0x73, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x44, 0x52,
-	0x09, 0x65, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66,
-	0x66, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73,
-	0x65, 0x74, 0x12, 0x36, 0x0a, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x18, 0x05,
+	0x49, 0x44, 0x12, 0x2e, 0x0a, 0x09, 0x65, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x44, 0x52, 0x09, 0x65, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65,
+	0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x36, 0x0a, 0x08, 0x6c, 0x61,
+	0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65,
+	0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x65, 0x6e,
+	0x63, 0x6c, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0f, 0x65, 0x6e, 0x63, 0x6c, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x4c, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x12, 0x2c, 0x0a, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x44,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x44, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x49, 0x44, 0x22, 0x7b, 0x0a, 0x0e, 0x55, 0x73, 0x65, 0x72, 0x45, 0x70, 0x69, 0x73, 0x6f, 0x64,
+	0x65, 0x52, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x35, 0x0a, 0x0b, 0x75, 0x73, 0x65, 0x72,
+	0x45, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x45, 0x70, 0x69, 0x73, 0x6f,
+	0x64, 0x65, 0x52, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x45, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x22,
+	0x33, 0x0a, 0x07, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x07, 0x64, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x07, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x22, 0x39, 0x0a, 0x09, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65,
+	0x71, 0x12, 0x2c, 0x0a, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x49, 0x44, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x44, 0x22,
+	0x21, 0x0a, 0x07, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75,
+	0x72, 0x73, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x22, 0x4b, 0x0a, 0x07, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x12, 0x28, 0x0a,
+	0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x07,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22,
+	0x3e, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73,
+	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0x7d, 0x0a, 0x0d, 0x4c, 0x61, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x52, 0x65, 0x73,
+	0x12, 0x29, 0x0a, 0x07, 0x70, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x50, 0x6f, 0x64, 0x63, 0x61,
+	0x73, 0x74, 0x52, 0x07, 0x70, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x07, 0x65,
+	0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x45, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x52, 0x07, 0x65,
+	0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x69, 0x6c, 0x6c, 0x69, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x22, 0x78,
+	0x0a, 0x0d, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x3a, 0x0a, 0x0d, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x73, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2b, 0x0a, 0x08, 0x70,
+	0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x50, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x08,
+	0x70, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x73, 0x22, 0x50, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x12, 0x2e, 0x0a, 0x09, 0x70, 0x6f, 0x64, 0x63,
+	0x61, 0x73, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x44, 0x52, 0x09, 0x70,
+	0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x44, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x7b, 0x0a, 0x0f, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x2e, 0x0a,
+	0x09, 0x70, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x49, 0x44, 0x52, 0x09, 0x70, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x44, 0x12, 0x38, 0x0a,
+	0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x08, 0x73,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x6a, 0x0a, 0x08, 0x51, 0x75, 0x65, 0x75, 0x65,
+	0x52, 0x65, 0x71, 0x12, 0x2e, 0x0a, 0x09, 0x65, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x44, 0x52, 0x09, 0x65, 0x70, 0x69, 0x73, 0x6f, 0x64,
+	0x65, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x04, 0x6e, 0x65, 0x78, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0x3b, 0x0a, 0x09, 0x50, 0x6c, 0x61, 0x79, 0x6c, 0x69, 0x73, 0x74, 0x73,
+	0x12, 0x2e, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x6c, 0x69, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x50, 0x6c, 0x61,
+	0x79, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x6c, 0x69, 0x73, 0x74, 0x73,
+	0x22, 0x67, 0x0a, 0x0b, 0x50, 0x6c, 0x61, 0x79, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x12,
+	0x30, 0x0a, 0x0a, 0x70, 0x6c, 0x61, 0x79, 0x6c, 0x69, 0x73, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x49, 0x44, 0x52, 0x0a, 0x70, 0x6c, 0x61, 0x79, 0x6c, 0x69, 0x73, 0x74, 0x49,
+	0x44, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x22, 0x60, 0x0a, 0x10, 0x50, 0x6c, 0x61,
+	0x79, 0x6c, 0x69, 0x73, 0x74, 0x45, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x2b, 0x0a,
+	0x08, 0x65, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x45, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65,
+	0x52, 0x08, 0x65, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f,
+	0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6d, 0x6f, 0x72, 0x65, 0x4a, 0x04,
+	0x08, 0x02, 0x10, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x22, 0x0a, 0x04, 0x4f,
+	0x50, 0x4d, 0x4c, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x22,
+	0x39, 0x0a, 0x09, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x12, 0x2c, 0x0a, 0x08,
+	0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x44,
+	0x52, 0x08, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x44, 0x22, 0xab, 0x02, 0x0a, 0x0e, 0x49,
+	0x6d, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x20, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x73, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x28, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49,
+	0x44, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12,
+	0x12, 0x0a, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x64,
+	0x6f, 0x6e, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x64, 0x12, 0x31, 0x0a, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x49,
+	0x6d, 0x70, 0x6f, 0x72, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x08, 0x66, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68,
+	0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68,
+	0x65, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x22, 0x4d, 0x0a, 0x0d, 0x49, 0x6d, 0x70, 0x6f,
+	0x72, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x74,
+	0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x37, 0x0a, 0x08, 0x45, 0x70, 0x69, 0x73, 0x6f,
+	0x64, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x08, 0x65, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x45,
+	0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x52, 0x08, 0x65, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x73,
+	0x22, 0x96, 0x02, 0x0a, 0x0c, 0x46, 0x65, 0x65, 0x64, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
+	0x65, 0x12, 0x2e, 0x0a, 0x09, 0x70, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x49, 0x44, 0x52, 0x09, 0x70, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49,
+	0x44, 0x12, 0x38, 0x0a, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x18, 0x02,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6c,
-	0x61, 0x79, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x79,
-	0x65, 0x64, 0x22, 0x3e, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x22, 0x7d, 0x0a, 0x0d, 0x4c, 0x61, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x64,
-	0x52, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x07, 0x70, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x50, 0x6f,
-	0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x07, 0x70, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x12, 0x29,
-	0x0a, 0x07, 0x65, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x45, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65,
-	0x52, 0x07, 0x65, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x69, 0x6c,
-	0x6c, 0x69, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6d, 0x69, 0x6c, 0x6c, 0x69,
-	0x73, 0x22, 0x78, 0x0a, 0x0d, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x12, 0x3a, 0x0a, 0x0d, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x73, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x0d, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2b,
-	0x0a, 0x08, 0x70, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x50, 0x6f, 0x64, 0x63, 0x61, 0x73,
-	0x74, 0x52, 0x08, 0x70, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x73, 0x22, 0x37, 0x0a, 0x08, 0x45,
-	0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x08, 0x65, 0x70, 0x69, 0x73, 0x6f,
-	0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x73, 0x2e, 0x45, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x52, 0x08, 0x65, 0x70, 0x69, 0x73,
-	0x6f, 0x64, 0x65, 0x73, 0x32, 0xe3, 0x02, 0x0a, 0x03, 0x50, 0x6f, 0x64, 0x12, 0x30, 0x0a, 0x0a,
-	0x47, 0x65, 0x74, 0x50, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x12, 0x0f, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x50, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x22, 0x00, 0x12, 0x32,
-	0x0a, 0x0b, 0x47, 0x65, 0x74, 0x45, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x0f, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x45, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x73,
-	0x22, 0x00, 0x12, 0x38, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x45, 0x70, 0x69,
-	0x73, 0x6f, 0x64, 0x65, 0x12, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x55,
-	0x73, 0x65, 0x72, 0x45, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x11,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x45, 0x70, 0x69, 0x73, 0x6f, 0x64,
-	0x65, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x45,
-	0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a,
+	0x52, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x38, 0x0a, 0x09, 0x6c,
+	0x61, 0x73, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x1e, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
+	0x73, 0x12, 0x26, 0x0a, 0x0e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x4d, 0x69, 0x6c,
+	0x6c, 0x69, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x22, 0xfa, 0x02, 0x0a, 0x0a, 0x46, 0x65,
+	0x65, 0x64, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x2e, 0x0a, 0x09, 0x70, 0x6f, 0x64, 0x63,
+	0x61, 0x73, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x44, 0x52, 0x09, 0x70,
+	0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x72, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x72, 0x73, 0x73,
+	0x12, 0x30, 0x0a, 0x13, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x74, 0x69, 0x76, 0x65, 0x46,
+	0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x63,
+	0x6f, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x74, 0x69, 0x76, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72,
+	0x65, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43,
+	0x6c, 0x61, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6c, 0x61, 0x73, 0x74,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x61,
+	0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c,
+	0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x3c, 0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74,
+	0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x46,
+	0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x75,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x75, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x71, 0x75, 0x61, 0x72, 0x61, 0x6e, 0x74, 0x69,
+	0x6e, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x71, 0x75, 0x61, 0x72, 0x61,
+	0x6e, 0x74, 0x69, 0x6e, 0x65, 0x64, 0x22, 0x5a, 0x0a, 0x0e, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74,
+	0x65, 0x46, 0x65, 0x65, 0x64, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73,
+	0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73,
+	0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f,
+	0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f,
+	0x72, 0x64, 0x22, 0x3a, 0x0a, 0x0e, 0x46, 0x65, 0x65, 0x64, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68,
+	0x4c, 0x69, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x05, 0x66, 0x65, 0x65, 0x64, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x46, 0x65, 0x65,
+	0x64, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x05, 0x66, 0x65, 0x65, 0x64, 0x73, 0x32, 0xc5,
+	0x0e, 0x0a, 0x03, 0x50, 0x6f, 0x64, 0x12, 0x30, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x64,
+	0x63, 0x61, 0x73, 0x74, 0x12, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x50,
+	0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x22, 0x00, 0x12, 0x32, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x45,
+	0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
+	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x73, 0x2e, 0x45, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x0e,
+	0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x45, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x12, 0x0f,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x45, 0x70, 0x69,
+	0x73, 0x6f, 0x64, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x11, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x55, 0x73, 0x65, 0x72, 0x45, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x45, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65,
+	0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x55, 0x73, 0x65,
+	0x72, 0x45, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x3c, 0x0a,
 	0x10, 0x47, 0x65, 0x74, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
 	0x73, 0x12, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65,
 	0x73, 0x74, 0x1a, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53, 0x75, 0x62, 0x73,
-	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x11, 0x47,
-	0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x61, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x64,
-	0x12, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4c, 0x61, 0x73, 0x74, 0x50,
-	0x6c, 0x61, 0x79, 0x65, 0x64, 0x52, 0x65, 0x73, 0x22, 0x00, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x3b,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x09, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x73, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x14,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x00, 0x12, 0x32, 0x0a, 0x0b, 0x55, 0x6e, 0x73, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x12, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x73, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22,
+	0x00, 0x12, 0x3d, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x61, 0x73, 0x74,
+	0x50, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x12, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
+	0x2e, 0x4c, 0x61, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x52, 0x65, 0x73, 0x22, 0x00,
+	0x12, 0x3a, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x46, 0x65, 0x65, 0x64, 0x53, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x12, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x46, 0x65,
+	0x65, 0x64, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x11,
+	0x47, 0x65, 0x74, 0x55, 0x6e, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x46, 0x65, 0x65, 0x64,
+	0x73, 0x12, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x46, 0x65, 0x65, 0x64,
+	0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x11,
+	0x41, 0x64, 0x64, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x64, 0x63, 0x61, 0x73,
+	0x74, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x50, 0x72, 0x69, 0x76, 0x61,
+	0x74, 0x65, 0x46, 0x65, 0x65, 0x64, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x73, 0x2e, 0x50, 0x6f, 0x64, 0x63, 0x61, 0x73, 0x74, 0x22, 0x00, 0x12, 0x35, 0x0a, 0x0b,
+	0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x70, 0x74, 0x65, 0x72, 0x73, 0x12, 0x0f, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x68, 0x61, 0x70, 0x74, 0x65, 0x72, 0x4c, 0x69, 0x73,
+	0x74, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x41, 0x64, 0x4d, 0x61, 0x72, 0x6b,
+	0x65, 0x72, 0x73, 0x12, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x41, 0x64,
+	0x4d, 0x61, 0x72, 0x6b, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x12, 0x32, 0x0a, 0x0b,
+	0x47, 0x65, 0x74, 0x57, 0x61, 0x76, 0x65, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x0f, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x57, 0x61, 0x76, 0x65, 0x66, 0x6f, 0x72, 0x6d, 0x22, 0x00,
+	0x12, 0x34, 0x0a, 0x0a, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x4f, 0x50, 0x4d, 0x4c, 0x12, 0x0c,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4f, 0x50, 0x4d, 0x4c, 0x1a, 0x16, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x72, 0x6f, 0x67,
+	0x72, 0x65, 0x73, 0x73, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x49, 0x6d, 0x70,
+	0x6f, 0x72, 0x74, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x11, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x16,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x72,
+	0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x22, 0x00, 0x12, 0x2d, 0x0a, 0x0a, 0x45, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x4f, 0x50, 0x4d, 0x4c, 0x12, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
+	0x2e, 0x4f, 0x50, 0x4d, 0x4c, 0x22, 0x00, 0x12, 0x2c, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x51, 0x75,
+	0x65, 0x75, 0x65, 0x12, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x51, 0x75,
+	0x65, 0x75, 0x65, 0x22, 0x00, 0x12, 0x2c, 0x0a, 0x07, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65,
+	0x12, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x52,
+	0x65, 0x71, 0x1a, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x51, 0x75, 0x65, 0x75,
+	0x65, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x0c, 0x52, 0x65, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x51, 0x75,
+	0x65, 0x75, 0x65, 0x12, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x51, 0x75, 0x65,
+	0x75, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x51,
+	0x75, 0x65, 0x75, 0x65, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x0f, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x46, 0x72, 0x6f, 0x6d, 0x51, 0x75, 0x65, 0x75, 0x65, 0x12, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x73, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x0d, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x22, 0x00, 0x12, 0x2e, 0x0a, 0x08,
+	0x50, 0x6f, 0x70, 0x51, 0x75, 0x65, 0x75, 0x65, 0x12, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x73, 0x2e, 0x45, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x0c,
+	0x47, 0x65, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x6c, 0x69, 0x73, 0x74, 0x73, 0x12, 0x0f, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x6c, 0x69, 0x73, 0x74, 0x73,
+	0x22, 0x00, 0x12, 0x36, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6c, 0x61, 0x79,
+	0x6c, 0x69, 0x73, 0x74, 0x12, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x50, 0x6c,
+	0x61, 0x79, 0x6c, 0x69, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x50, 0x6c, 0x61, 0x79, 0x6c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x0e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x10, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x6c, 0x69, 0x73, 0x74, 0x1a, 0x10,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x6c, 0x69, 0x73, 0x74,
+	0x22, 0x00, 0x12, 0x39, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x6c, 0x61, 0x79,
+	0x6c, 0x69, 0x73, 0x74, 0x12, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x50, 0x6c,
+	0x61, 0x79, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a,
+	0x10, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x6c, 0x69, 0x73,
+	0x74, 0x12, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x6c,
+	0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x50, 0x6c, 0x61, 0x79, 0x6c, 0x69, 0x73, 0x74, 0x45, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x73,
+	0x22, 0x00, 0x12, 0x32, 0x0a, 0x0e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x1a, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x22, 0x00, 0x12, 0x30, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x44, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x12, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x22, 0x00, 0x12, 0x35, 0x0a, 0x0c, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x73, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x2a, 0x0a, 0x04, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
+	0x2e, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x73, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x0d, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x0f, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x45, 0x70, 0x69, 0x73, 0x6f,
+	0x64, 0x65, 0x22, 0x00, 0x30, 0x01, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x3b, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -989,62 +3818,197 @@ func file_podcast_proto_rawDescGZIP() []byte {
 	return file_podcast_proto_rawDescData
 }
 
-var file_podcast_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_podcast_proto_msgTypes = make([]protoimpl.MessageInfo, 44)
 var file_podcast_proto_goTypes = []interface{}{
-	(*Image)(nil),               // 0: protos.Image
-	(*Category)(nil),            // 1: protos.Category
-	(*Podcast)(nil),             // 2: protos.Podcast
-	(*Episode)(nil),             // 3: protos.Episode
-	(*Request)(nil),             // 4: protos.Request
-	(*UserEpisodeReq)(nil),      // 5: protos.UserEpisodeReq
-	(*Response)(nil),            // 6: protos.Response
-	(*LastPlayedRes)(nil),       // 7: protos.LastPlayedRes
-	(*Subscriptions)(nil),       // 8: protos.Subscriptions
-	(*Episodes)(nil),            // 9: protos.Episodes
-	(*ObjectID)(nil),            // 10: protos.ObjectID
-	(*timestamp.Timestamp)(nil), // 11: google.protobuf.Timestamp
-	(*Subscription)(nil),        // 12: protos.Subscription
-	(*UserEpisode)(nil),         // 13: protos.UserEpisode
+	(*Image)(nil),                // 0: protos.Image
+	(*Category)(nil),             // 1: protos.Category
+	(*Podcast)(nil),              // 2: protos.Podcast
+	(*Episode)(nil),              // 3: protos.Episode
+	(*Person)(nil),               // 4: protos.Person
+	(*Funding)(nil),              // 5: protos.Funding
+	(*Location)(nil),             // 6: protos.Location
+	(*Value)(nil),                // 7: protos.Value
+	(*ValueRecipient)(nil),       // 8: protos.ValueRecipient
+	(*Transcript)(nil),           // 9: protos.Transcript
+	(*Chapters)(nil),             // 10: protos.Chapters
+	(*Chapter)(nil),              // 11: protos.Chapter
+	(*ChapterList)(nil),          // 12: protos.ChapterList
+	(*AdMarker)(nil),             // 13: protos.AdMarker
+	(*AdMarkerList)(nil),         // 14: protos.AdMarkerList
+	(*Waveform)(nil),             // 15: protos.Waveform
+	(*Silence)(nil),              // 16: protos.Silence
+	(*Soundbite)(nil),            // 17: protos.Soundbite
+	(*AlternateEnclosure)(nil),   // 18: protos.AlternateEnclosure
+	(*Request)(nil),              // 19: protos.Request
+	(*UserEpisodeReq)(nil),       // 20: protos.UserEpisodeReq
+	(*UserEpisodeRes)(nil),       // 21: protos.UserEpisodeRes
+	(*Devices)(nil),              // 22: protos.Devices
+	(*DeviceReq)(nil),            // 23: protos.DeviceReq
+	(*SyncReq)(nil),              // 24: protos.SyncReq
+	(*SyncRes)(nil),              // 25: protos.SyncRes
+	(*Response)(nil),             // 26: protos.Response
+	(*LastPlayedRes)(nil),        // 27: protos.LastPlayedRes
+	(*Subscriptions)(nil),        // 28: protos.Subscriptions
+	(*SubscribeReq)(nil),         // 29: protos.SubscribeReq
+	(*SubscriptionReq)(nil),      // 30: protos.SubscriptionReq
+	(*QueueReq)(nil),             // 31: protos.QueueReq
+	(*Playlists)(nil),            // 32: protos.Playlists
+	(*PlaylistReq)(nil),          // 33: protos.PlaylistReq
+	(*PlaylistEpisodes)(nil),     // 34: protos.PlaylistEpisodes
+	(*OPML)(nil),                 // 35: protos.OPML
+	(*ImportReq)(nil),            // 36: protos.ImportReq
+	(*ImportProgress)(nil),       // 37: protos.ImportProgress
+	(*ImportFailure)(nil),        // 38: protos.ImportFailure
+	(*Episodes)(nil),             // 39: protos.Episodes
+	(*FeedSchedule)(nil),         // 40: protos.FeedSchedule
+	(*FeedHealth)(nil),           // 41: protos.FeedHealth
+	(*PrivateFeedReq)(nil),       // 42: protos.PrivateFeedReq
+	(*FeedHealthList)(nil),       // 43: protos.FeedHealthList
+	(*ObjectID)(nil),             // 44: protos.ObjectID
+	(*timestamp.Timestamp)(nil),  // 45: google.protobuf.Timestamp
+	(*UserEpisode)(nil),          // 46: protos.UserEpisode
+	(*Device)(nil),               // 47: protos.Device
+	(*Change)(nil),               // 48: protos.Change
+	(*Subscription)(nil),         // 49: protos.Subscription
+	(*SubscriptionSettings)(nil), // 50: protos.SubscriptionSettings
+	(*Playlist)(nil),             // 51: protos.Playlist
+	(*Queue)(nil),                // 52: protos.Queue
 }
 var file_podcast_proto_depIdxs = []int32{
 	1,  // 0: protos.Category.category:type_name -> protos.Category
-	10, // 1: protos.Podcast.id:type_name -> protos.ObjectID
+	44, // 1: protos.Podcast.id:type_name -> protos.ObjectID
 	0,  // 2: protos.Podcast.image:type_name -> protos.Image
 	1,  // 3: protos.Podcast.category:type_name -> protos.Category
-	11, // 4: protos.Podcast.pubDate:type_name -> google.protobuf.Timestamp
-	11, // 5: protos.Podcast.lastBuildDate:type_name -> google.protobuf.Timestamp
-	10, // 6: protos.Episode.id:type_name -> protos.ObjectID
-	10, // 7: protos.Episode.podcastID:type_name -> protos.ObjectID
-	0,  // 8: protos.Episode.image:type_name -> protos.Image
-	11, // 9: protos.Episode.pubDate:type_name -> google.protobuf.Timestamp
-	1,  // 10: protos.Episode.category:type_name -> protos.Category
-	10, // 11: protos.Request.podcastID:type_name -> protos.ObjectID
-	10, // 12: protos.Request.episodeID:type_name -> protos.ObjectID
-	10, // 13: protos.UserEpisodeReq.podcastID:type_name -> protos.ObjectID
-	10, // 14: protos.UserEpisodeReq.episodeID:type_name -> protos.ObjectID
-	11, // 15: protos.UserEpisodeReq.lastSeen:type_name -> google.protobuf.Timestamp
-	2,  // 16: protos.LastPlayedRes.podcast:type_name -> protos.Podcast
-	3,  // 17: protos.LastPlayedRes.episode:type_name -> protos.Episode
-	12, // 18: protos.Subscriptions.subscriptions:type_name -> protos.Subscription
-	2,  // 19: protos.Subscriptions.podcasts:type_name -> protos.Podcast
-	3,  // 20: protos.Episodes.episodes:type_name -> protos.Episode
-	4,  // 21: protos.Pod.GetPodcast:input_type -> protos.Request
-	4,  // 22: protos.Pod.GetEpisodes:input_type -> protos.Request
-	4,  // 23: protos.Pod.GetUserEpisode:input_type -> protos.Request
-	5,  // 24: protos.Pod.UpdateUserEpisode:input_type -> protos.UserEpisodeReq
-	4,  // 25: protos.Pod.GetSubscriptions:input_type -> protos.Request
-	4,  // 26: protos.Pod.GetUserLastPlayed:input_type -> protos.Request
-	2,  // 27: protos.Pod.GetPodcast:output_type -> protos.Podcast
-	9,  // 28: protos.Pod.GetEpisodes:output_type -> protos.Episodes
-	13, // 29: protos.Pod.GetUserEpisode:output_type -> protos.UserEpisode
-	6,  // 30: protos.Pod.UpdateUserEpisode:output_type -> protos.Response
-	8,  // 31: protos.Pod.GetSubscriptions:output_type -> protos.Subscriptions
-	7,  // 32: protos.Pod.GetUserLastPlayed:output_type -> protos.LastPlayedRes
-	27, // [27:33] is the sub-list for method output_type
-	21, // [21:27] is the sub-list for method input_type
-	21, // [21:21] is the sub-list for extension type_name
-	21, // [21:21] is the sub-list for extension extendee
-	0,  // [0:21] is the sub-list for field type_name
+	45, // 4: protos.Podcast.pubDate:type_name -> google.protobuf.Timestamp
+	45, // 5: protos.Podcast.lastBuildDate:type_name -> google.protobuf.Timestamp
+	4,  // 6: protos.Podcast.persons:type_name -> protos.Person
+	5,  // 7: protos.Podcast.funding:type_name -> protos.Funding
+	6,  // 8: protos.Podcast.location:type_name -> protos.Location
+	7,  // 9: protos.Podcast.value:type_name -> protos.Value
+	44, // 10: protos.Podcast.ownerID:type_name -> protos.ObjectID
+	44, // 11: protos.Episode.id:type_name -> protos.ObjectID
+	44, // 12: protos.Episode.podcastID:type_name -> protos.ObjectID
+	0,  // 13: protos.Episode.image:type_name -> protos.Image
+	45, // 14: protos.Episode.pubDate:type_name -> google.protobuf.Timestamp
+	1,  // 15: protos.Episode.category:type_name -> protos.Category
+	9,  // 16: protos.Episode.transcripts:type_name -> protos.Transcript
+	10, // 17: protos.Episode.chapters:type_name -> protos.Chapters
+	4,  // 18: protos.Episode.persons:type_name -> protos.Person
+	17, // 19: protos.Episode.soundbites:type_name -> protos.Soundbite
+	6,  // 20: protos.Episode.location:type_name -> protos.Location
+	7,  // 21: protos.Episode.value:type_name -> protos.Value
+	18, // 22: protos.Episode.alternateEnclosures:type_name -> protos.AlternateEnclosure
+	11, // 23: protos.Episode.embeddedChapters:type_name -> protos.Chapter
+	8,  // 24: protos.Value.recipients:type_name -> protos.ValueRecipient
+	11, // 25: protos.ChapterList.chapters:type_name -> protos.Chapter
+	13, // 26: protos.AdMarkerList.markers:type_name -> protos.AdMarker
+	44, // 27: protos.Waveform.episodeID:type_name -> protos.ObjectID
+	16, // 28: protos.Waveform.silences:type_name -> protos.Silence
+	44, // 29: protos.Request.podcastID:type_name -> protos.ObjectID
+	44, // 30: protos.Request.episodeID:type_name -> protos.ObjectID
+	44, // 31: protos.UserEpisodeReq.podcastID:type_name -> protos.ObjectID
+	44, // 32: protos.UserEpisodeReq.episodeID:type_name -> protos.ObjectID
+	45, // 33: protos.UserEpisodeReq.lastSeen:type_name -> google.protobuf.Timestamp
+	44, // 34: protos.UserEpisodeReq.deviceID:type_name -> protos.ObjectID
+	46, // 35: protos.UserEpisodeRes.userEpisode:type_name -> protos.UserEpisode
+	47, // 36: protos.Devices.devices:type_name -> protos.Device
+	44, // 37: protos.DeviceReq.deviceID:type_name -> protos.ObjectID
+	48, // 38: protos.SyncRes.changes:type_name -> protos.Change
+	2,  // 39: protos.LastPlayedRes.podcast:type_name -> protos.Podcast
+	3,  // 40: protos.LastPlayedRes.episode:type_name -> protos.Episode
+	49, // 41: protos.Subscriptions.subscriptions:type_name -> protos.Subscription
+	2,  // 42: protos.Subscriptions.podcasts:type_name -> protos.Podcast
+	44, // 43: protos.SubscribeReq.podcastID:type_name -> protos.ObjectID
+	44, // 44: protos.SubscriptionReq.podcastID:type_name -> protos.ObjectID
+	50, // 45: protos.SubscriptionReq.settings:type_name -> protos.SubscriptionSettings
+	44, // 46: protos.QueueReq.episodeID:type_name -> protos.ObjectID
+	51, // 47: protos.Playlists.playlists:type_name -> protos.Playlist
+	44, // 48: protos.PlaylistReq.playlistID:type_name -> protos.ObjectID
+	3,  // 49: protos.PlaylistEpisodes.episodes:type_name -> protos.Episode
+	44, // 50: protos.ImportReq.importID:type_name -> protos.ObjectID
+	44, // 51: protos.ImportProgress.id:type_name -> protos.ObjectID
+	44, // 52: protos.ImportProgress.userID:type_name -> protos.ObjectID
+	38, // 53: protos.ImportProgress.failures:type_name -> protos.ImportFailure
+	45, // 54: protos.ImportProgress.started:type_name -> google.protobuf.Timestamp
+	3,  // 55: protos.Episodes.episodes:type_name -> protos.Episode
+	44, // 56: protos.FeedSchedule.podcastID:type_name -> protos.ObjectID
+	45, // 57: protos.FeedSchedule.nextCheck:type_name -> google.protobuf.Timestamp
+	45, // 58: protos.FeedSchedule.lastCheck:type_name -> google.protobuf.Timestamp
+	44, // 59: protos.FeedHealth.podcastID:type_name -> protos.ObjectID
+	45, // 60: protos.FeedHealth.lastFailure:type_name -> google.protobuf.Timestamp
+	45, // 61: protos.FeedHealth.lastSuccess:type_name -> google.protobuf.Timestamp
+	41, // 62: protos.FeedHealthList.feeds:type_name -> protos.FeedHealth
+	19, // 63: protos.Pod.GetPodcast:input_type -> protos.Request
+	19, // 64: protos.Pod.GetEpisodes:input_type -> protos.Request
+	19, // 65: protos.Pod.GetUserEpisode:input_type -> protos.Request
+	20, // 66: protos.Pod.UpdateUserEpisode:input_type -> protos.UserEpisodeReq
+	19, // 67: protos.Pod.GetSubscriptions:input_type -> protos.Request
+	29, // 68: protos.Pod.Subscribe:input_type -> protos.SubscribeReq
+	19, // 69: protos.Pod.Unsubscribe:input_type -> protos.Request
+	30, // 70: protos.Pod.UpdateSubscription:input_type -> protos.SubscriptionReq
+	19, // 71: protos.Pod.GetUserLastPlayed:input_type -> protos.Request
+	19, // 72: protos.Pod.GetFeedSchedule:input_type -> protos.Request
+	19, // 73: protos.Pod.GetUnhealthyFeeds:input_type -> protos.Request
+	42, // 74: protos.Pod.AddPrivatePodcast:input_type -> protos.PrivateFeedReq
+	19, // 75: protos.Pod.GetChapters:input_type -> protos.Request
+	19, // 76: protos.Pod.GetAdMarkers:input_type -> protos.Request
+	19, // 77: protos.Pod.GetWaveform:input_type -> protos.Request
+	35, // 78: protos.Pod.ImportOPML:input_type -> protos.OPML
+	36, // 79: protos.Pod.GetImportProgress:input_type -> protos.ImportReq
+	19, // 80: protos.Pod.ExportOPML:input_type -> protos.Request
+	19, // 81: protos.Pod.GetQueue:input_type -> protos.Request
+	31, // 82: protos.Pod.Enqueue:input_type -> protos.QueueReq
+	31, // 83: protos.Pod.ReorderQueue:input_type -> protos.QueueReq
+	31, // 84: protos.Pod.RemoveFromQueue:input_type -> protos.QueueReq
+	19, // 85: protos.Pod.PopQueue:input_type -> protos.Request
+	19, // 86: protos.Pod.GetPlaylists:input_type -> protos.Request
+	51, // 87: protos.Pod.CreatePlaylist:input_type -> protos.Playlist
+	51, // 88: protos.Pod.UpdatePlaylist:input_type -> protos.Playlist
+	33, // 89: protos.Pod.DeletePlaylist:input_type -> protos.PlaylistReq
+	33, // 90: protos.Pod.EvaluatePlaylist:input_type -> protos.PlaylistReq
+	47, // 91: protos.Pod.RegisterDevice:input_type -> protos.Device
+	19, // 92: protos.Pod.GetDevices:input_type -> protos.Request
+	23, // 93: protos.Pod.RemoveDevice:input_type -> protos.DeviceReq
+	24, // 94: protos.Pod.Sync:input_type -> protos.SyncReq
+	19, // 95: protos.Pod.WatchPlayback:input_type -> protos.Request
+	2,  // 96: protos.Pod.GetPodcast:output_type -> protos.Podcast
+	39, // 97: protos.Pod.GetEpisodes:output_type -> protos.Episodes
+	46, // 98: protos.Pod.GetUserEpisode:output_type -> protos.UserEpisode
+	21, // 99: protos.Pod.UpdateUserEpisode:output_type -> protos.UserEpisodeRes
+	28, // 100: protos.Pod.GetSubscriptions:output_type -> protos.Subscriptions
+	49, // 101: protos.Pod.Subscribe:output_type -> protos.Subscription
+	26, // 102: protos.Pod.Unsubscribe:output_type -> protos.Response
+	49, // 103: protos.Pod.UpdateSubscription:output_type -> protos.Subscription
+	27, // 104: protos.Pod.GetUserLastPlayed:output_type -> protos.LastPlayedRes
+	40, // 105: protos.Pod.GetFeedSchedule:output_type -> protos.FeedSchedule
+	43, // 106: protos.Pod.GetUnhealthyFeeds:output_type -> protos.FeedHealthList
+	2,  // 107: protos.Pod.AddPrivatePodcast:output_type -> protos.Podcast
+	12, // 108: protos.Pod.GetChapters:output_type -> protos.ChapterList
+	14, // 109: protos.Pod.GetAdMarkers:output_type -> protos.AdMarkerList
+	15, // 110: protos.Pod.GetWaveform:output_type -> protos.Waveform
+	37, // 111: protos.Pod.ImportOPML:output_type -> protos.ImportProgress
+	37, // 112: protos.Pod.GetImportProgress:output_type -> protos.ImportProgress
+	35, // 113: protos.Pod.ExportOPML:output_type -> protos.OPML
+	52, // 114: protos.Pod.GetQueue:output_type -> protos.Queue
+	52, // 115: protos.Pod.Enqueue:output_type -> protos.Queue
+	52, // 116: protos.Pod.ReorderQueue:output_type -> protos.Queue
+	52, // 117: protos.Pod.RemoveFromQueue:output_type -> protos.Queue
+	3,  // 118: protos.Pod.PopQueue:output_type -> protos.Episode
+	32, // 119: protos.Pod.GetPlaylists:output_type -> protos.Playlists
+	51, // 120: protos.Pod.CreatePlaylist:output_type -> protos.Playlist
+	51, // 121: protos.Pod.UpdatePlaylist:output_type -> protos.Playlist
+	26, // 122: protos.Pod.DeletePlaylist:output_type -> protos.Response
+	34, // 123: protos.Pod.EvaluatePlaylist:output_type -> protos.PlaylistEpisodes
+	47, // 124: protos.Pod.RegisterDevice:output_type -> protos.Device
+	22, // 125: protos.Pod.GetDevices:output_type -> protos.Devices
+	26, // 126: protos.Pod.RemoveDevice:output_type -> protos.Response
+	25, // 127: protos.Pod.Sync:output_type -> protos.SyncRes
+	46, // 128: protos.Pod.WatchPlayback:output_type -> protos.UserEpisode
+	96, // [96:129] is the sub-list for method output_type
+	63, // [63:96] is the sub-list for method input_type
+	63, // [63:63] is the sub-list for extension type_name
+	63, // [63:63] is the sub-list for extension extendee
+	0,  // [0:63] is the sub-list for field type_name
 }
 
 func init() { file_podcast_proto_init() }
@@ -1067,8 +4031,128 @@ func file_podcast_proto_init() {
 				return nil
 			}
 		}
-		file_podcast_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Category); i {
+		file_podcast_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Category); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Podcast); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Episode); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Person); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Funding); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Location); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Value); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValueRecipient); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Transcript); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Chapters); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Chapter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1079,8 +4163,8 @@ func file_podcast_proto_init() {
 				return nil
 			}
 		}
-		file_podcast_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Podcast); i {
+		file_podcast_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChapterList); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1091,8 +4175,8 @@ func file_podcast_proto_init() {
 				return nil
 			}
 		}
-		file_podcast_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Episode); i {
+		file_podcast_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AdMarker); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1103,7 +4187,67 @@ func file_podcast_proto_init() {
 				return nil
 			}
 		}
-		file_podcast_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_podcast_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AdMarkerList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Waveform); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Silence); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Soundbite); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AlternateEnclosure); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Request); i {
 			case 0:
 				return &v.state
@@ -1115,7 +4259,7 @@ func file_podcast_proto_init() {
 				return nil
 			}
 		}
-		file_podcast_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_podcast_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UserEpisodeReq); i {
 			case 0:
 				return &v.state
@@ -1127,7 +4271,67 @@ func file_podcast_proto_init() {
 				return nil
 			}
 		}
-		file_podcast_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_podcast_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserEpisodeRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Devices); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeviceReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Response); i {
 			case 0:
 				return &v.state
@@ -1139,7 +4343,7 @@ func file_podcast_proto_init() {
 				return nil
 			}
 		}
-		file_podcast_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_podcast_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*LastPlayedRes); i {
 			case 0:
 				return &v.state
@@ -1151,7 +4355,7 @@ func file_podcast_proto_init() {
 				return nil
 			}
 		}
-		file_podcast_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_podcast_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Subscriptions); i {
 			case 0:
 				return &v.state
@@ -1163,7 +4367,127 @@ func file_podcast_proto_init() {
 				return nil
 			}
 		}
-		file_podcast_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_podcast_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriptionReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueueReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Playlists); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlaylistReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlaylistEpisodes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OPML); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportProgress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportFailure); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Episodes); i {
 			case 0:
 				return &v.state
@@ -1175,6 +4499,54 @@ func file_podcast_proto_init() {
 				return nil
 			}
 		}
+		file_podcast_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FeedSchedule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FeedHealth); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrivateFeedReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_podcast_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FeedHealthList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1182,7 +4554,7 @@ func file_podcast_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_podcast_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   44,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
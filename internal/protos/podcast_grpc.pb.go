@@ -20,9 +20,36 @@ type PodClient interface {
 	GetPodcast(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Podcast, error)
 	GetEpisodes(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Episodes, error)
 	GetUserEpisode(ctx context.Context, in *Request, opts ...grpc.CallOption) (*UserEpisode, error)
-	UpdateUserEpisode(ctx context.Context, in *UserEpisodeReq, opts ...grpc.CallOption) (*Response, error)
+	UpdateUserEpisode(ctx context.Context, in *UserEpisodeReq, opts ...grpc.CallOption) (*UserEpisodeRes, error)
 	GetSubscriptions(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Subscriptions, error)
+	Subscribe(ctx context.Context, in *SubscribeReq, opts ...grpc.CallOption) (*Subscription, error)
+	Unsubscribe(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Response, error)
+	UpdateSubscription(ctx context.Context, in *SubscriptionReq, opts ...grpc.CallOption) (*Subscription, error)
 	GetUserLastPlayed(ctx context.Context, in *Request, opts ...grpc.CallOption) (*LastPlayedRes, error)
+	GetFeedSchedule(ctx context.Context, in *Request, opts ...grpc.CallOption) (*FeedSchedule, error)
+	GetUnhealthyFeeds(ctx context.Context, in *Request, opts ...grpc.CallOption) (*FeedHealthList, error)
+	AddPrivatePodcast(ctx context.Context, in *PrivateFeedReq, opts ...grpc.CallOption) (*Podcast, error)
+	GetChapters(ctx context.Context, in *Request, opts ...grpc.CallOption) (*ChapterList, error)
+	GetAdMarkers(ctx context.Context, in *Request, opts ...grpc.CallOption) (*AdMarkerList, error)
+	GetWaveform(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Waveform, error)
+	ImportOPML(ctx context.Context, in *OPML, opts ...grpc.CallOption) (*ImportProgress, error)
+	GetImportProgress(ctx context.Context, in *ImportReq, opts ...grpc.CallOption) (*ImportProgress, error)
+	ExportOPML(ctx context.Context, in *Request, opts ...grpc.CallOption) (*OPML, error)
+	GetQueue(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Queue, error)
+	Enqueue(ctx context.Context, in *QueueReq, opts ...grpc.CallOption) (*Queue, error)
+	ReorderQueue(ctx context.Context, in *QueueReq, opts ...grpc.CallOption) (*Queue, error)
+	RemoveFromQueue(ctx context.Context, in *QueueReq, opts ...grpc.CallOption) (*Queue, error)
+	PopQueue(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Episode, error)
+	GetPlaylists(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Playlists, error)
+	CreatePlaylist(ctx context.Context, in *Playlist, opts ...grpc.CallOption) (*Playlist, error)
+	UpdatePlaylist(ctx context.Context, in *Playlist, opts ...grpc.CallOption) (*Playlist, error)
+	DeletePlaylist(ctx context.Context, in *PlaylistReq, opts ...grpc.CallOption) (*Response, error)
+	EvaluatePlaylist(ctx context.Context, in *PlaylistReq, opts ...grpc.CallOption) (*PlaylistEpisodes, error)
+	RegisterDevice(ctx context.Context, in *Device, opts ...grpc.CallOption) (*Device, error)
+	GetDevices(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Devices, error)
+	RemoveDevice(ctx context.Context, in *DeviceReq, opts ...grpc.CallOption) (*Response, error)
+	Sync(ctx context.Context, in *SyncReq, opts ...grpc.CallOption) (*SyncRes, error)
+	WatchPlayback(ctx context.Context, in *Request, opts ...grpc.CallOption) (Pod_WatchPlaybackClient, error)
 }
 
 type podClient struct {
@@ -60,8 +87,8 @@ func (c *podClient) GetUserEpisode(ctx context.Context, in *Request, opts ...grp
 	return out, nil
 }
 
-func (c *podClient) UpdateUserEpisode(ctx context.Context, in *UserEpisodeReq, opts ...grpc.CallOption) (*Response, error) {
-	out := new(Response)
+func (c *podClient) UpdateUserEpisode(ctx context.Context, in *UserEpisodeReq, opts ...grpc.CallOption) (*UserEpisodeRes, error) {
+	out := new(UserEpisodeRes)
 	err := c.cc.Invoke(ctx, "/protos.Pod/UpdateUserEpisode", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -78,6 +105,33 @@ func (c *podClient) GetSubscriptions(ctx context.Context, in *Request, opts ...g
 	return out, nil
 }
 
+func (c *podClient) Subscribe(ctx context.Context, in *SubscribeReq, opts ...grpc.CallOption) (*Subscription, error) {
+	out := new(Subscription)
+	err := c.cc.Invoke(ctx, "/protos.Pod/Subscribe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podClient) Unsubscribe(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/protos.Pod/Unsubscribe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podClient) UpdateSubscription(ctx context.Context, in *SubscriptionReq, opts ...grpc.CallOption) (*Subscription, error) {
+	out := new(Subscription)
+	err := c.cc.Invoke(ctx, "/protos.Pod/UpdateSubscription", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *podClient) GetUserLastPlayed(ctx context.Context, in *Request, opts ...grpc.CallOption) (*LastPlayedRes, error) {
 	out := new(LastPlayedRes)
 	err := c.cc.Invoke(ctx, "/protos.Pod/GetUserLastPlayed", in, out, opts...)
@@ -87,6 +141,245 @@ func (c *podClient) GetUserLastPlayed(ctx context.Context, in *Request, opts ...
 	return out, nil
 }
 
+func (c *podClient) GetFeedSchedule(ctx context.Context, in *Request, opts ...grpc.CallOption) (*FeedSchedule, error) {
+	out := new(FeedSchedule)
+	err := c.cc.Invoke(ctx, "/protos.Pod/GetFeedSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podClient) GetUnhealthyFeeds(ctx context.Context, in *Request, opts ...grpc.CallOption) (*FeedHealthList, error) {
+	out := new(FeedHealthList)
+	err := c.cc.Invoke(ctx, "/protos.Pod/GetUnhealthyFeeds", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podClient) AddPrivatePodcast(ctx context.Context, in *PrivateFeedReq, opts ...grpc.CallOption) (*Podcast, error) {
+	out := new(Podcast)
+	err := c.cc.Invoke(ctx, "/protos.Pod/AddPrivatePodcast", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podClient) GetChapters(ctx context.Context, in *Request, opts ...grpc.CallOption) (*ChapterList, error) {
+	out := new(ChapterList)
+	err := c.cc.Invoke(ctx, "/protos.Pod/GetChapters", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podClient) GetAdMarkers(ctx context.Context, in *Request, opts ...grpc.CallOption) (*AdMarkerList, error) {
+	out := new(AdMarkerList)
+	err := c.cc.Invoke(ctx, "/protos.Pod/GetAdMarkers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podClient) GetWaveform(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Waveform, error) {
+	out := new(Waveform)
+	err := c.cc.Invoke(ctx, "/protos.Pod/GetWaveform", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podClient) ImportOPML(ctx context.Context, in *OPML, opts ...grpc.CallOption) (*ImportProgress, error) {
+	out := new(ImportProgress)
+	err := c.cc.Invoke(ctx, "/protos.Pod/ImportOPML", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podClient) GetImportProgress(ctx context.Context, in *ImportReq, opts ...grpc.CallOption) (*ImportProgress, error) {
+	out := new(ImportProgress)
+	err := c.cc.Invoke(ctx, "/protos.Pod/GetImportProgress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podClient) ExportOPML(ctx context.Context, in *Request, opts ...grpc.CallOption) (*OPML, error) {
+	out := new(OPML)
+	err := c.cc.Invoke(ctx, "/protos.Pod/ExportOPML", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podClient) GetQueue(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Queue, error) {
+	out := new(Queue)
+	err := c.cc.Invoke(ctx, "/protos.Pod/GetQueue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podClient) Enqueue(ctx context.Context, in *QueueReq, opts ...grpc.CallOption) (*Queue, error) {
+	out := new(Queue)
+	err := c.cc.Invoke(ctx, "/protos.Pod/Enqueue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podClient) ReorderQueue(ctx context.Context, in *QueueReq, opts ...grpc.CallOption) (*Queue, error) {
+	out := new(Queue)
+	err := c.cc.Invoke(ctx, "/protos.Pod/ReorderQueue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podClient) RemoveFromQueue(ctx context.Context, in *QueueReq, opts ...grpc.CallOption) (*Queue, error) {
+	out := new(Queue)
+	err := c.cc.Invoke(ctx, "/protos.Pod/RemoveFromQueue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podClient) PopQueue(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Episode, error) {
+	out := new(Episode)
+	err := c.cc.Invoke(ctx, "/protos.Pod/PopQueue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podClient) GetPlaylists(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Playlists, error) {
+	out := new(Playlists)
+	err := c.cc.Invoke(ctx, "/protos.Pod/GetPlaylists", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podClient) CreatePlaylist(ctx context.Context, in *Playlist, opts ...grpc.CallOption) (*Playlist, error) {
+	out := new(Playlist)
+	err := c.cc.Invoke(ctx, "/protos.Pod/CreatePlaylist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podClient) UpdatePlaylist(ctx context.Context, in *Playlist, opts ...grpc.CallOption) (*Playlist, error) {
+	out := new(Playlist)
+	err := c.cc.Invoke(ctx, "/protos.Pod/UpdatePlaylist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podClient) DeletePlaylist(ctx context.Context, in *PlaylistReq, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/protos.Pod/DeletePlaylist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podClient) EvaluatePlaylist(ctx context.Context, in *PlaylistReq, opts ...grpc.CallOption) (*PlaylistEpisodes, error) {
+	out := new(PlaylistEpisodes)
+	err := c.cc.Invoke(ctx, "/protos.Pod/EvaluatePlaylist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podClient) RegisterDevice(ctx context.Context, in *Device, opts ...grpc.CallOption) (*Device, error) {
+	out := new(Device)
+	err := c.cc.Invoke(ctx, "/protos.Pod/RegisterDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podClient) GetDevices(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Devices, error) {
+	out := new(Devices)
+	err := c.cc.Invoke(ctx, "/protos.Pod/GetDevices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podClient) RemoveDevice(ctx context.Context, in *DeviceReq, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/protos.Pod/RemoveDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podClient) Sync(ctx context.Context, in *SyncReq, opts ...grpc.CallOption) (*SyncRes, error) {
+	out := new(SyncRes)
+	err := c.cc.Invoke(ctx, "/protos.Pod/Sync", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *podClient) WatchPlayback(ctx context.Context, in *Request, opts ...grpc.CallOption) (Pod_WatchPlaybackClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Pod_serviceDesc.Streams[0], "/protos.Pod/WatchPlayback", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &podWatchPlaybackClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Pod_WatchPlaybackClient interface {
+	Recv() (*UserEpisode, error)
+	grpc.ClientStream
+}
+
+type podWatchPlaybackClient struct {
+	grpc.ClientStream
+}
+
+func (x *podWatchPlaybackClient) Recv() (*UserEpisode, error) {
+	m := new(UserEpisode)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // PodServer is the server API for Pod service.
 // All implementations must embed UnimplementedPodServer
 // for forward compatibility
@@ -94,9 +387,36 @@ type PodServer interface {
 	GetPodcast(context.Context, *Request) (*Podcast, error)
 	GetEpisodes(context.Context, *Request) (*Episodes, error)
 	GetUserEpisode(context.Context, *Request) (*UserEpisode, error)
-	UpdateUserEpisode(context.Context, *UserEpisodeReq) (*Response, error)
+	UpdateUserEpisode(context.Context, *UserEpisodeReq) (*UserEpisodeRes, error)
 	GetSubscriptions(context.Context, *Request) (*Subscriptions, error)
+	Subscribe(context.Context, *SubscribeReq) (*Subscription, error)
+	Unsubscribe(context.Context, *Request) (*Response, error)
+	UpdateSubscription(context.Context, *SubscriptionReq) (*Subscription, error)
 	GetUserLastPlayed(context.Context, *Request) (*LastPlayedRes, error)
+	GetFeedSchedule(context.Context, *Request) (*FeedSchedule, error)
+	GetUnhealthyFeeds(context.Context, *Request) (*FeedHealthList, error)
+	AddPrivatePodcast(context.Context, *PrivateFeedReq) (*Podcast, error)
+	GetChapters(context.Context, *Request) (*ChapterList, error)
+	GetAdMarkers(context.Context, *Request) (*AdMarkerList, error)
+	GetWaveform(context.Context, *Request) (*Waveform, error)
+	ImportOPML(context.Context, *OPML) (*ImportProgress, error)
+	GetImportProgress(context.Context, *ImportReq) (*ImportProgress, error)
+	ExportOPML(context.Context, *Request) (*OPML, error)
+	GetQueue(context.Context, *Request) (*Queue, error)
+	Enqueue(context.Context, *QueueReq) (*Queue, error)
+	ReorderQueue(context.Context, *QueueReq) (*Queue, error)
+	RemoveFromQueue(context.Context, *QueueReq) (*Queue, error)
+	PopQueue(context.Context, *Request) (*Episode, error)
+	GetPlaylists(context.Context, *Request) (*Playlists, error)
+	CreatePlaylist(context.Context, *Playlist) (*Playlist, error)
+	UpdatePlaylist(context.Context, *Playlist) (*Playlist, error)
+	DeletePlaylist(context.Context, *PlaylistReq) (*Response, error)
+	EvaluatePlaylist(context.Context, *PlaylistReq) (*PlaylistEpisodes, error)
+	RegisterDevice(context.Context, *Device) (*Device, error)
+	GetDevices(context.Context, *Request) (*Devices, error)
+	RemoveDevice(context.Context, *DeviceReq) (*Response, error)
+	Sync(context.Context, *SyncReq) (*SyncRes, error)
+	WatchPlayback(*Request, Pod_WatchPlaybackServer) error
 	mustEmbedUnimplementedPodServer()
 }
 
@@ -113,15 +433,96 @@ func (UnimplementedPodServer) GetEpisodes(context.Context, *Request) (*Episodes,
 func (UnimplementedPodServer) GetUserEpisode(context.Context, *Request) (*UserEpisode, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserEpisode not implemented")
 }
-func (UnimplementedPodServer) UpdateUserEpisode(context.Context, *UserEpisodeReq) (*Response, error) {
+func (UnimplementedPodServer) UpdateUserEpisode(context.Context, *UserEpisodeReq) (*UserEpisodeRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserEpisode not implemented")
 }
 func (UnimplementedPodServer) GetSubscriptions(context.Context, *Request) (*Subscriptions, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSubscriptions not implemented")
 }
+func (UnimplementedPodServer) Subscribe(context.Context, *SubscribeReq) (*Subscription, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedPodServer) Unsubscribe(context.Context, *Request) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unsubscribe not implemented")
+}
+func (UnimplementedPodServer) UpdateSubscription(context.Context, *SubscriptionReq) (*Subscription, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSubscription not implemented")
+}
 func (UnimplementedPodServer) GetUserLastPlayed(context.Context, *Request) (*LastPlayedRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserLastPlayed not implemented")
 }
+func (UnimplementedPodServer) GetFeedSchedule(context.Context, *Request) (*FeedSchedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFeedSchedule not implemented")
+}
+func (UnimplementedPodServer) GetUnhealthyFeeds(context.Context, *Request) (*FeedHealthList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUnhealthyFeeds not implemented")
+}
+func (UnimplementedPodServer) AddPrivatePodcast(context.Context, *PrivateFeedReq) (*Podcast, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddPrivatePodcast not implemented")
+}
+func (UnimplementedPodServer) GetChapters(context.Context, *Request) (*ChapterList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChapters not implemented")
+}
+func (UnimplementedPodServer) GetAdMarkers(context.Context, *Request) (*AdMarkerList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAdMarkers not implemented")
+}
+func (UnimplementedPodServer) GetWaveform(context.Context, *Request) (*Waveform, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWaveform not implemented")
+}
+func (UnimplementedPodServer) ImportOPML(context.Context, *OPML) (*ImportProgress, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportOPML not implemented")
+}
+func (UnimplementedPodServer) GetImportProgress(context.Context, *ImportReq) (*ImportProgress, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetImportProgress not implemented")
+}
+func (UnimplementedPodServer) ExportOPML(context.Context, *Request) (*OPML, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportOPML not implemented")
+}
+func (UnimplementedPodServer) GetQueue(context.Context, *Request) (*Queue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQueue not implemented")
+}
+func (UnimplementedPodServer) Enqueue(context.Context, *QueueReq) (*Queue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Enqueue not implemented")
+}
+func (UnimplementedPodServer) ReorderQueue(context.Context, *QueueReq) (*Queue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderQueue not implemented")
+}
+func (UnimplementedPodServer) RemoveFromQueue(context.Context, *QueueReq) (*Queue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveFromQueue not implemented")
+}
+func (UnimplementedPodServer) PopQueue(context.Context, *Request) (*Episode, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PopQueue not implemented")
+}
+func (UnimplementedPodServer) GetPlaylists(context.Context, *Request) (*Playlists, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlaylists not implemented")
+}
+func (UnimplementedPodServer) CreatePlaylist(context.Context, *Playlist) (*Playlist, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePlaylist not implemented")
+}
+func (UnimplementedPodServer) UpdatePlaylist(context.Context, *Playlist) (*Playlist, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePlaylist not implemented")
+}
+func (UnimplementedPodServer) DeletePlaylist(context.Context, *PlaylistReq) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePlaylist not implemented")
+}
+func (UnimplementedPodServer) EvaluatePlaylist(context.Context, *PlaylistReq) (*PlaylistEpisodes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EvaluatePlaylist not implemented")
+}
+func (UnimplementedPodServer) RegisterDevice(context.Context, *Device) (*Device, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterDevice not implemented")
+}
+func (UnimplementedPodServer) GetDevices(context.Context, *Request) (*Devices, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDevices not implemented")
+}
+func (UnimplementedPodServer) RemoveDevice(context.Context, *DeviceReq) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveDevice not implemented")
+}
+func (UnimplementedPodServer) Sync(context.Context, *SyncReq) (*SyncRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Sync not implemented")
+}
+func (UnimplementedPodServer) WatchPlayback(*Request, Pod_WatchPlaybackServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchPlayback not implemented")
+}
 func (UnimplementedPodServer) mustEmbedUnimplementedPodServer() {}
 
 // UnsafePodServer may be embedded to opt out of forward compatibility for this service.
@@ -225,6 +626,60 @@ func _Pod_GetSubscriptions_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Pod_Subscribe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubscribeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodServer).Subscribe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Pod/Subscribe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodServer).Subscribe(ctx, req.(*SubscribeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pod_Unsubscribe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodServer).Unsubscribe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Pod/Unsubscribe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodServer).Unsubscribe(ctx, req.(*Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pod_UpdateSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubscriptionReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodServer).UpdateSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Pod/UpdateSubscription",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodServer).UpdateSubscription(ctx, req.(*SubscriptionReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Pod_GetUserLastPlayed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Request)
 	if err := dec(in); err != nil {
@@ -243,17 +698,452 @@ func _Pod_GetUserLastPlayed_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
-var _Pod_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "protos.Pod",
-	HandlerType: (*PodServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetPodcast",
-			Handler:    _Pod_GetPodcast_Handler,
-		},
-		{
-			MethodName: "GetEpisodes",
-			Handler:    _Pod_GetEpisodes_Handler,
+func _Pod_GetFeedSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodServer).GetFeedSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Pod/GetFeedSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodServer).GetFeedSchedule(ctx, req.(*Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pod_GetUnhealthyFeeds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodServer).GetUnhealthyFeeds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Pod/GetUnhealthyFeeds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodServer).GetUnhealthyFeeds(ctx, req.(*Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pod_AddPrivatePodcast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrivateFeedReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodServer).AddPrivatePodcast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Pod/AddPrivatePodcast",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodServer).AddPrivatePodcast(ctx, req.(*PrivateFeedReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pod_GetChapters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodServer).GetChapters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Pod/GetChapters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodServer).GetChapters(ctx, req.(*Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pod_GetAdMarkers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodServer).GetAdMarkers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Pod/GetAdMarkers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodServer).GetAdMarkers(ctx, req.(*Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pod_GetWaveform_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodServer).GetWaveform(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Pod/GetWaveform",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodServer).GetWaveform(ctx, req.(*Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pod_ImportOPML_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OPML)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodServer).ImportOPML(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Pod/ImportOPML",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodServer).ImportOPML(ctx, req.(*OPML))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pod_GetImportProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodServer).GetImportProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Pod/GetImportProgress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodServer).GetImportProgress(ctx, req.(*ImportReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pod_ExportOPML_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodServer).ExportOPML(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Pod/ExportOPML",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodServer).ExportOPML(ctx, req.(*Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pod_GetQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodServer).GetQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Pod/GetQueue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodServer).GetQueue(ctx, req.(*Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pod_Enqueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueueReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodServer).Enqueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Pod/Enqueue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodServer).Enqueue(ctx, req.(*QueueReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pod_ReorderQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueueReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodServer).ReorderQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Pod/ReorderQueue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodServer).ReorderQueue(ctx, req.(*QueueReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pod_RemoveFromQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueueReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodServer).RemoveFromQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Pod/RemoveFromQueue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodServer).RemoveFromQueue(ctx, req.(*QueueReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pod_PopQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodServer).PopQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Pod/PopQueue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodServer).PopQueue(ctx, req.(*Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pod_GetPlaylists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodServer).GetPlaylists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Pod/GetPlaylists",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodServer).GetPlaylists(ctx, req.(*Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pod_CreatePlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Playlist)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodServer).CreatePlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Pod/CreatePlaylist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodServer).CreatePlaylist(ctx, req.(*Playlist))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pod_UpdatePlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Playlist)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodServer).UpdatePlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Pod/UpdatePlaylist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodServer).UpdatePlaylist(ctx, req.(*Playlist))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pod_DeletePlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaylistReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodServer).DeletePlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Pod/DeletePlaylist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodServer).DeletePlaylist(ctx, req.(*PlaylistReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pod_EvaluatePlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaylistReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodServer).EvaluatePlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Pod/EvaluatePlaylist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodServer).EvaluatePlaylist(ctx, req.(*PlaylistReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pod_RegisterDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Device)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodServer).RegisterDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Pod/RegisterDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodServer).RegisterDevice(ctx, req.(*Device))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pod_GetDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodServer).GetDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Pod/GetDevices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodServer).GetDevices(ctx, req.(*Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pod_RemoveDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodServer).RemoveDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Pod/RemoveDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodServer).RemoveDevice(ctx, req.(*DeviceReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pod_Sync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodServer).Sync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Pod/Sync",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodServer).Sync(ctx, req.(*SyncReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pod_WatchPlayback_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Request)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PodServer).WatchPlayback(m, &podWatchPlaybackServer{stream})
+}
+
+type Pod_WatchPlaybackServer interface {
+	Send(*UserEpisode) error
+	grpc.ServerStream
+}
+
+type podWatchPlaybackServer struct {
+	grpc.ServerStream
+}
+
+func (x *podWatchPlaybackServer) Send(m *UserEpisode) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Pod_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "protos.Pod",
+	HandlerType: (*PodServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPodcast",
+			Handler:    _Pod_GetPodcast_Handler,
+		},
+		{
+			MethodName: "GetEpisodes",
+			Handler:    _Pod_GetEpisodes_Handler,
 		},
 		{
 			MethodName: "GetUserEpisode",
@@ -267,11 +1157,121 @@ var _Pod_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetSubscriptions",
 			Handler:    _Pod_GetSubscriptions_Handler,
 		},
+		{
+			MethodName: "Subscribe",
+			Handler:    _Pod_Subscribe_Handler,
+		},
+		{
+			MethodName: "Unsubscribe",
+			Handler:    _Pod_Unsubscribe_Handler,
+		},
+		{
+			MethodName: "UpdateSubscription",
+			Handler:    _Pod_UpdateSubscription_Handler,
+		},
 		{
 			MethodName: "GetUserLastPlayed",
 			Handler:    _Pod_GetUserLastPlayed_Handler,
 		},
+		{
+			MethodName: "GetFeedSchedule",
+			Handler:    _Pod_GetFeedSchedule_Handler,
+		},
+		{
+			MethodName: "GetUnhealthyFeeds",
+			Handler:    _Pod_GetUnhealthyFeeds_Handler,
+		},
+		{
+			MethodName: "AddPrivatePodcast",
+			Handler:    _Pod_AddPrivatePodcast_Handler,
+		},
+		{
+			MethodName: "GetChapters",
+			Handler:    _Pod_GetChapters_Handler,
+		},
+		{
+			MethodName: "GetAdMarkers",
+			Handler:    _Pod_GetAdMarkers_Handler,
+		},
+		{
+			MethodName: "GetWaveform",
+			Handler:    _Pod_GetWaveform_Handler,
+		},
+		{
+			MethodName: "ImportOPML",
+			Handler:    _Pod_ImportOPML_Handler,
+		},
+		{
+			MethodName: "GetImportProgress",
+			Handler:    _Pod_GetImportProgress_Handler,
+		},
+		{
+			MethodName: "ExportOPML",
+			Handler:    _Pod_ExportOPML_Handler,
+		},
+		{
+			MethodName: "GetQueue",
+			Handler:    _Pod_GetQueue_Handler,
+		},
+		{
+			MethodName: "Enqueue",
+			Handler:    _Pod_Enqueue_Handler,
+		},
+		{
+			MethodName: "ReorderQueue",
+			Handler:    _Pod_ReorderQueue_Handler,
+		},
+		{
+			MethodName: "RemoveFromQueue",
+			Handler:    _Pod_RemoveFromQueue_Handler,
+		},
+		{
+			MethodName: "PopQueue",
+			Handler:    _Pod_PopQueue_Handler,
+		},
+		{
+			MethodName: "GetPlaylists",
+			Handler:    _Pod_GetPlaylists_Handler,
+		},
+		{
+			MethodName: "CreatePlaylist",
+			Handler:    _Pod_CreatePlaylist_Handler,
+		},
+		{
+			MethodName: "UpdatePlaylist",
+			Handler:    _Pod_UpdatePlaylist_Handler,
+		},
+		{
+			MethodName: "DeletePlaylist",
+			Handler:    _Pod_DeletePlaylist_Handler,
+		},
+		{
+			MethodName: "EvaluatePlaylist",
+			Handler:    _Pod_EvaluatePlaylist_Handler,
+		},
+		{
+			MethodName: "RegisterDevice",
+			Handler:    _Pod_RegisterDevice_Handler,
+		},
+		{
+			MethodName: "GetDevices",
+			Handler:    _Pod_GetDevices_Handler,
+		},
+		{
+			MethodName: "RemoveDevice",
+			Handler:    _Pod_RemoveDevice_Handler,
+		},
+		{
+			MethodName: "Sync",
+			Handler:    _Pod_Sync_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchPlayback",
+			Handler:       _Pod_WatchPlayback_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "podcast.proto",
 }
@@ -0,0 +1,194 @@
+package queue
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+const (
+	// maxItems is the most episodes a queue holds
+	maxItems = 500
+	// autoQueueMaxAge is how recently an episode must have been published to be auto queued,
+	// so a publisher re-guiding their back catalogue doesn't flood queues
+	autoQueueMaxAge = 7 * 24 * time.Hour
+)
+
+// Queue errors, their messages are safe to show to the user
+var (
+	ErrNotQueued  = errors.New("episode is not queued")
+	ErrQueueFull  = fmt.Errorf("queue can't hold more than %d episodes", maxItems)
+	ErrQueueEmpty = errors.New("queue is empty")
+)
+
+// mutex serializes the changes to queues so concurrent ones aren't lost
+var mutex sync.Mutex
+
+// Find returns the user's queue, which is empty if they never queued anything
+func Find(dbClient db.Database, userID *protos.ObjectID) *protos.Queue {
+	q := &protos.Queue{}
+	err := dbClient.FindOne(database.ColQueue, q, &db.Filter{"userid": userID}, nil)
+	if err != nil {
+		return &protos.Queue{Id: protos.NewObjectID(), UserID: userID}
+	}
+	return q
+}
+
+// Enqueue queues the episode last, or next if next is set, moving it if it's already queued
+func Enqueue(dbClient db.Database, userID *protos.ObjectID, epi *protos.Episode, next bool) (*protos.Queue, error) {
+	return update(dbClient, userID, func(q *protos.Queue) error {
+		item := &protos.QueueItem{PodcastID: epi.PodcastID, EpisodeID: epi.Id, Added: ptypes.TimestampNow()}
+		if i := indexOf(q, epi.Id); i >= 0 {
+			item = q.Items[i]
+			item.Auto = false
+			q.Items = append(q.Items[:i], q.Items[i+1:]...)
+		} else if len(q.Items) >= maxItems {
+			return ErrQueueFull
+		}
+		position := len(q.Items)
+		if next {
+			position = 0
+		}
+		insert(q, item, position)
+		return nil
+	})
+}
+
+// Move moves the queued episode to the position, positions past the end move it last
+func Move(dbClient db.Database, userID, epiID *protos.ObjectID, position int) (*protos.Queue, error) {
+	return update(dbClient, userID, func(q *protos.Queue) error {
+		i := indexOf(q, epiID)
+		if i < 0 {
+			return ErrNotQueued
+		}
+		item := q.Items[i]
+		q.Items = append(q.Items[:i], q.Items[i+1:]...)
+		insert(q, item, position)
+		return nil
+	})
+}
+
+// Remove removes the episode from the user's queue
+func Remove(dbClient db.Database, userID, epiID *protos.ObjectID) (*protos.Queue, error) {
+	return update(dbClient, userID, func(q *protos.Queue) error {
+		i := indexOf(q, epiID)
+		if i < 0 {
+			return ErrNotQueued
+		}
+		q.Items = append(q.Items[:i], q.Items[i+1:]...)
+		return nil
+	})
+}
+
+// Pop removes and returns the first item of the user's queue
+func Pop(dbClient db.Database, userID *protos.ObjectID) (*protos.QueueItem, error) {
+	var item *protos.QueueItem
+	_, err := update(dbClient, userID, func(q *protos.Queue) error {
+		if len(q.Items) == 0 {
+			return ErrQueueEmpty
+		}
+		item = q.Items[0]
+		q.Items = q.Items[1:]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// PopEpisode pops the user's queue until an episode that still exists is found and returns it
+func PopEpisode(dbClient db.Database, userID *protos.ObjectID) (*protos.Episode, error) {
+	for {
+		item, err := Pop(dbClient, userID)
+		if err != nil {
+			return nil, err
+		}
+		epi := &protos.Episode{}
+		err = dbClient.FindOne(database.ColEpisode, epi, &db.Filter{"_id": item.EpisodeID}, nil)
+		if err == nil {
+			return epi, nil
+		}
+	}
+}
+
+// AutoQueue appends the newly published episode to the queue of every user
+// subscribed to its podcast with autoQueue set, full queues are skipped
+func AutoQueue(dbClient db.Database, epi *protos.Episode) error {
+	pubDate, err := ptypes.Timestamp(epi.PubDate)
+	if err != nil || time.Since(pubDate) > autoQueueMaxAge {
+		return nil
+	}
+
+	var subs []*protos.Subscription
+	err = dbClient.FindAll(database.ColSubscription, &subs, &db.Filter{"podcastid": epi.PodcastID}, nil)
+	if err != nil {
+		return fmt.Errorf("AutoQueue() error finding subscriptions: %v", err)
+	}
+	for _, sub := range subs {
+		if sub.Settings == nil || !sub.Settings.AutoQueue {
+			continue
+		}
+		_, err = update(dbClient, sub.UserID, func(q *protos.Queue) error {
+			if indexOf(q, epi.Id) < 0 && len(q.Items) < maxItems {
+				q.Items = append(q.Items, &protos.QueueItem{
+					PodcastID: epi.PodcastID,
+					EpisodeID: epi.Id,
+					Added:     ptypes.TimestampNow(),
+					Auto:      true,
+				})
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("AutoQueue() error: %v", err)
+		}
+	}
+	return nil
+}
+
+// update applies change to the user's queue and stores it, nothing is stored if change errors
+func update(dbClient db.Database, userID *protos.ObjectID, change func(*protos.Queue) error) (*protos.Queue, error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	q := Find(dbClient, userID)
+	err := change(q)
+	if err != nil {
+		return nil, err
+	}
+	err = dbClient.Upsert(database.ColQueue, q, &db.Filter{"userid": userID})
+	if err != nil {
+		return nil, fmt.Errorf("update() error upserting queue: %v", err)
+	}
+	return q, nil
+}
+
+// indexOf returns the index of the episode within the queue, -1 if it isn't queued
+func indexOf(q *protos.Queue, epiID *protos.ObjectID) int {
+	for i, item := range q.Items {
+		if item.EpisodeID.GetHex() == epiID.GetHex() {
+			return i
+		}
+	}
+	return -1
+}
+
+// insert inserts the item at the position, clamped to the queue's bounds
+func insert(q *protos.Queue, item *protos.QueueItem, position int) {
+	if position < 0 {
+		position = 0
+	}
+	if position > len(q.Items) {
+		position = len(q.Items)
+	}
+	q.Items = append(q.Items, nil)
+	copy(q.Items[position+1:], q.Items[position:])
+	q.Items[position] = item
+}
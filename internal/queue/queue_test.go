@@ -0,0 +1,246 @@
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/stockpile/mock"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+func insertOrFail(t *testing.T, mockDB db.Database, collection string, object interface{}) {
+	err := mockDB.Insert(collection, object)
+	if err != nil {
+		t.Fatalf("insertOrFail() error inserting: %v", err)
+	}
+}
+
+// queued returns the hex ids of the episodes in the user's queue, in order
+func queued(dbClient db.Database, userID *protos.ObjectID) []string {
+	var ids []string
+	for _, item := range Find(dbClient, userID).Items {
+		ids = append(ids, item.EpisodeID.GetHex())
+	}
+	return ids
+}
+
+func equalIDs(got []string, want ...string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range want {
+		if got[i] != protos.ObjectIDFromHex(want[i]).GetHex() {
+			return false
+		}
+	}
+	return true
+}
+
+func TestQueue(t *testing.T) {
+	mockDB := mock.CreateDB()
+	userID := protos.NewObjectID()
+	episode := func(id string) *protos.Episode {
+		return &protos.Episode{Id: protos.ObjectIDFromHex(id), PodcastID: protos.ObjectIDFromHex("pod_id")}
+	}
+
+	tests := []struct {
+		name    string
+		change  func() error
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "enqueue",
+			change: func() error {
+				_, err := Enqueue(mockDB, userID, episode("epi_1"), false)
+				return err
+			},
+			want: []string{"epi_1"},
+		},
+		{
+			name: "enqueue_last",
+			change: func() error {
+				_, err := Enqueue(mockDB, userID, episode("epi_2"), false)
+				return err
+			},
+			want: []string{"epi_1", "epi_2"},
+		},
+		{
+			name: "enqueue_next",
+			change: func() error {
+				_, err := Enqueue(mockDB, userID, episode("epi_3"), true)
+				return err
+			},
+			want: []string{"epi_3", "epi_1", "epi_2"},
+		},
+		{
+			name: "enqueue_queued_moves",
+			change: func() error {
+				_, err := Enqueue(mockDB, userID, episode("epi_3"), false)
+				return err
+			},
+			want: []string{"epi_1", "epi_2", "epi_3"},
+		},
+		{
+			name: "move",
+			change: func() error {
+				_, err := Move(mockDB, userID, protos.ObjectIDFromHex("epi_3"), 1)
+				return err
+			},
+			want: []string{"epi_1", "epi_3", "epi_2"},
+		},
+		{
+			name: "move_before_start",
+			change: func() error {
+				_, err := Move(mockDB, userID, protos.ObjectIDFromHex("epi_2"), -1)
+				return err
+			},
+			want: []string{"epi_2", "epi_1", "epi_3"},
+		},
+		{
+			name: "move_not_queued",
+			change: func() error {
+				_, err := Move(mockDB, userID, protos.ObjectIDFromHex("epi_4"), 0)
+				return err
+			},
+			want:    []string{"epi_2", "epi_1", "epi_3"},
+			wantErr: ErrNotQueued,
+		},
+		{
+			name: "remove",
+			change: func() error {
+				_, err := Remove(mockDB, userID, protos.ObjectIDFromHex("epi_1"))
+				return err
+			},
+			want: []string{"epi_2", "epi_3"},
+		},
+		{
+			name: "pop",
+			change: func() error {
+				item, err := Pop(mockDB, userID)
+				if err == nil && item.EpisodeID.GetHex() != protos.ObjectIDFromHex("epi_2").GetHex() {
+					t.Errorf("Pop() = %v", item)
+				}
+				return err
+			},
+			want: []string{"epi_3"},
+		},
+		{
+			name: "pop_last",
+			change: func() error {
+				_, err := Pop(mockDB, userID)
+				return err
+			},
+			want: nil,
+		},
+		{
+			name: "pop_empty",
+			change: func() error {
+				_, err := Pop(mockDB, userID)
+				return err
+			},
+			want:    nil,
+			wantErr: ErrQueueEmpty,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.change()
+			if err != tt.wantErr {
+				t.Fatalf("%s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if got := queued(mockDB, userID); !equalIDs(got, tt.want...) {
+				t.Errorf("%s queue = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPopEpisode(t *testing.T) {
+	mockDB := mock.CreateDB()
+	userID := protos.NewObjectID()
+	epi := &protos.Episode{Id: protos.ObjectIDFromHex("epi_2"), PodcastID: protos.ObjectIDFromHex("pod_id"), Title: "Episode 2"}
+	insertOrFail(t, mockDB, database.ColEpisode, epi)
+	// epi_1 has since been deleted
+	for _, e := range []*protos.Episode{{Id: protos.ObjectIDFromHex("epi_1")}, epi} {
+		if _, err := Enqueue(mockDB, userID, e, false); err != nil {
+			t.Fatalf("Enqueue() error = %v", err)
+		}
+	}
+
+	got, err := PopEpisode(mockDB, userID)
+	if err != nil || got.Title != "Episode 2" {
+		t.Errorf("PopEpisode() = %v, error = %v", got, err)
+	}
+	if _, err = PopEpisode(mockDB, userID); err != ErrQueueEmpty {
+		t.Errorf("PopEpisode() error = %v, want %v", err, ErrQueueEmpty)
+	}
+}
+
+func TestAutoQueue(t *testing.T) {
+	mockDB := mock.CreateDB()
+	podID := protos.ObjectIDFromHex("pod_id")
+	autoUser, manualUser, fullUser := protos.NewObjectID(), protos.NewObjectID(), protos.NewObjectID()
+	insertOrFail(t, mockDB, database.ColSubscription, &protos.Subscription{Id: protos.NewObjectID(), UserID: autoUser, PodcastID: podID,
+		Settings: &protos.SubscriptionSettings{AutoQueue: true}})
+	insertOrFail(t, mockDB, database.ColSubscription, &protos.Subscription{Id: protos.NewObjectID(), UserID: manualUser, PodcastID: podID,
+		Settings: &protos.SubscriptionSettings{}})
+	insertOrFail(t, mockDB, database.ColSubscription, &protos.Subscription{Id: protos.NewObjectID(), UserID: fullUser, PodcastID: podID,
+		Settings: &protos.SubscriptionSettings{AutoQueue: true}})
+	full := &protos.Queue{Id: protos.NewObjectID(), UserID: fullUser}
+	for i := 0; i < maxItems; i++ {
+		full.Items = append(full.Items, &protos.QueueItem{EpisodeID: protos.NewObjectID()})
+	}
+	insertOrFail(t, mockDB, database.ColQueue, full)
+
+	old, _ := ptypes.TimestampProto(time.Now().Add(-30 * 24 * time.Hour))
+	tests := []struct {
+		name string
+		epi  *protos.Episode
+		want []string
+	}{
+		{
+			name: "new",
+			epi:  &protos.Episode{Id: protos.ObjectIDFromHex("epi_1"), PodcastID: podID, PubDate: ptypes.TimestampNow()},
+			want: []string{"epi_1"},
+		},
+		{
+			name: "already_queued",
+			epi:  &protos.Episode{Id: protos.ObjectIDFromHex("epi_1"), PodcastID: podID, PubDate: ptypes.TimestampNow()},
+			want: []string{"epi_1"},
+		},
+		{
+			name: "old",
+			epi:  &protos.Episode{Id: protos.ObjectIDFromHex("epi_2"), PodcastID: podID, PubDate: old},
+			want: []string{"epi_1"},
+		},
+		{
+			name: "no_pub_date",
+			epi:  &protos.Episode{Id: protos.ObjectIDFromHex("epi_3"), PodcastID: podID},
+			want: []string{"epi_1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := AutoQueue(mockDB, tt.epi)
+			if err != nil {
+				t.Fatalf("AutoQueue() error = %v", err)
+			}
+			if got := queued(mockDB, autoUser); !equalIDs(got, tt.want...) {
+				t.Errorf("AutoQueue() queue = %v, want %v", got, tt.want)
+			}
+			if got := Find(mockDB, autoUser).Items; !got[0].Auto {
+				t.Errorf("AutoQueue() item not marked auto: %v", got[0])
+			}
+			if got := queued(mockDB, manualUser); len(got) != 0 {
+				t.Errorf("AutoQueue() queued for a subscription without autoQueue: %v", got)
+			}
+			if got := queued(mockDB, fullUser); len(got) != maxItems {
+				t.Errorf("AutoQueue() full queue has %d items", len(got))
+			}
+		})
+	}
+}
@@ -16,11 +16,12 @@ import (
 type AuthService struct {
 	*protos.UnimplementedAuthServer
 	dbClient db.Database
+	accounts *auth.Accounts
 }
 
 // NewAuthService creates a new *AuthService
-func NewAuthService(dbClient db.Database) *AuthService {
-	return &AuthService{dbClient: dbClient}
+func NewAuthService(dbClient db.Database, accounts *auth.Accounts) *AuthService {
+	return &AuthService{dbClient: dbClient, accounts: accounts}
 }
 
 // Authenticate handles the authentication to syncapod and returns response
@@ -71,3 +72,51 @@ func (a *AuthService) Logout(ctx context.Context, req *protos.AuthReq) (*protos.
 	}
 	return &protos.AuthRes{Success: true}, nil
 }
+
+// Register creates an account and emails its verification link, invalid or taken details are an unsuccessful response
+func (a *AuthService) Register(ctx context.Context, req *protos.RegisterReq) (*protos.AuthRes, error) {
+	u, err := a.accounts.Register(req.Username, req.Email, req.Password, req.DOB)
+	if err != nil {
+		return accountRes(err, "Register()")
+	}
+	u.Password = ""
+	return &protos.AuthRes{Success: true, User: u}, nil
+}
+
+// VerifyEmail redeems an email verification token
+func (a *AuthService) VerifyEmail(ctx context.Context, req *protos.TokenReq) (*protos.AuthRes, error) {
+	u, err := a.accounts.VerifyEmail(req.Token)
+	if err != nil {
+		return accountRes(err, "VerifyEmail()")
+	}
+	u.Password = ""
+	return &protos.AuthRes{Success: true, User: u}, nil
+}
+
+// RequestPasswordReset emails the user with the username or email a password reset link,
+// succeeding whether or not they exist
+func (a *AuthService) RequestPasswordReset(ctx context.Context, req *protos.AuthReq) (*protos.AuthRes, error) {
+	err := a.accounts.RequestPasswordReset(req.Username)
+	if err != nil {
+		return nil, fmt.Errorf("RequestPasswordReset() error: %v", err)
+	}
+	return &protos.AuthRes{Success: true}, nil
+}
+
+// ResetPassword redeems a password reset token, setting the new password
+func (a *AuthService) ResetPassword(ctx context.Context, req *protos.TokenReq) (*protos.AuthRes, error) {
+	u, err := a.accounts.ResetPassword(req.Token, req.Password)
+	if err != nil {
+		return accountRes(err, "ResetPassword()")
+	}
+	u.Password = ""
+	return &protos.AuthRes{Success: true, User: u}, nil
+}
+
+// accountRes returns an unsuccessful response with the message of an account error, otherwise the error
+func accountRes(err error, funcName string) (*protos.AuthRes, error) {
+	if auth.IsAccountError(err) {
+		return &protos.AuthRes{Success: false, Message: err.Error()}, nil
+	}
+	return nil, fmt.Errorf("%s error: %v", funcName, err)
+}
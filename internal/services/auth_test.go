@@ -4,13 +4,16 @@ import (
 	"context"
 	"log"
 	"net"
+	"net/url"
 	"reflect"
+	"regexp"
 	"testing"
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/sschwartz96/stockpile/db"
 	"github.com/sschwartz96/stockpile/mock"
+	"github.com/sschwartz96/syncapod/internal/auth"
 	"github.com/sschwartz96/syncapod/internal/database"
 	"github.com/sschwartz96/syncapod/internal/protos"
 	"github.com/sschwartz96/syncapod/internal/util"
@@ -70,7 +73,8 @@ func TestAuthService(t *testing.T) {
 
 	lis = bufconn.Listen(bufSize)
 	s := gogrpc.NewServer()
-	protos.RegisterAuthServer(s, NewAuthService(mockDB))
+	mailer := &fakeMailer{}
+	protos.RegisterAuthServer(s, NewAuthService(mockDB, auth.NewAccounts(mockDB, mailer, "https://syncapod.com")))
 
 	go func() {
 		if err := s.Serve(lis); err != nil {
@@ -91,6 +95,31 @@ func TestAuthService(t *testing.T) {
 	testAuthService_Authenticate(t, authClient)
 	testAuthService_Authorize(t, authClient)
 	testAuthService_Logout(t, authClient)
+	testAuthService_Register(t, authClient, mailer)
+	testAuthService_ResetPassword(t, authClient, mailer)
+}
+
+// fakeMailer records the emails sent instead of sending them
+type fakeMailer struct {
+	bodies []string
+}
+
+func (m *fakeMailer) Send(to, subject, body string) error {
+	m.bodies = append(m.bodies, body)
+	return nil
+}
+
+// lastToken returns the token of the link in the last email sent
+func (m *fakeMailer) lastToken(t *testing.T) string {
+	if len(m.bodies) == 0 {
+		t.Fatalf("fakeMailer no email sent")
+	}
+	match := regexp.MustCompile(`\?token=(\S+)`).FindStringSubmatch(m.bodies[len(m.bodies)-1])
+	if match == nil {
+		t.Fatalf("fakeMailer no link in email: %q", m.bodies[len(m.bodies)-1])
+	}
+	token, _ := url.QueryUnescape(match[1])
+	return token
 }
 
 func testAuthService_Authenticate(t *testing.T, authClient protos.AuthClient) {
@@ -236,3 +265,79 @@ func testAuthService_Logout(t *testing.T, authClient protos.AuthClient) {
 		})
 	}
 }
+
+func testAuthService_Register(t *testing.T, authClient protos.AuthClient, mailer *fakeMailer) {
+	tests := []struct {
+		name        string
+		req         *protos.RegisterReq
+		wantSuccess bool
+	}{
+		{
+			name:        "register_valid",
+			req:         &protos.RegisterReq{Username: "new_user", Email: "new@example.com", Password: "password"},
+			wantSuccess: true,
+		},
+		{
+			name:        "register_taken",
+			req:         &protos.RegisterReq{Username: "user", Email: "other@example.com", Password: "password"},
+			wantSuccess: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := authClient.Register(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("AuthService.Register() error = %v", err)
+			}
+			if got.Success != tt.wantSuccess || (got.Success && got.User.Password != "") || (!got.Success && got.Message == "") {
+				t.Errorf("AuthService.Register() = %v, want success %v", got, tt.wantSuccess)
+			}
+		})
+	}
+
+	// the emailed token verifies once
+	token := mailer.lastToken(t)
+	for i, wantSuccess := range []bool{true, false} {
+		got, err := authClient.VerifyEmail(context.Background(), &protos.TokenReq{Token: token})
+		if err != nil || got.Success != wantSuccess {
+			t.Errorf("AuthService.VerifyEmail() %d = %v, error %v, want success %v", i, got, err, wantSuccess)
+		}
+		if got.Success && !got.User.EmailVerified {
+			t.Errorf("AuthService.VerifyEmail() = %v, not verified", got)
+		}
+	}
+}
+
+func testAuthService_ResetPassword(t *testing.T, authClient protos.AuthClient, mailer *fakeMailer) {
+	// unknown users succeed so they aren't revealed
+	for _, username := range []string{"nobody", "user"} {
+		got, err := authClient.RequestPasswordReset(context.Background(), &protos.AuthReq{Username: username})
+		if err != nil || !got.Success {
+			t.Fatalf("AuthService.RequestPasswordReset() %s = %v, error %v", username, got, err)
+		}
+	}
+	token := mailer.lastToken(t)
+
+	tests := []struct {
+		name        string
+		req         *protos.TokenReq
+		wantSuccess bool
+	}{
+		{name: "reset_invalid", req: &protos.TokenReq{Token: "invalid", Password: "new_password"}, wantSuccess: false},
+		{name: "reset_valid", req: &protos.TokenReq{Token: token, Password: "new_password"}, wantSuccess: true},
+		{name: "reset_reused", req: &protos.TokenReq{Token: token, Password: "other_password"}, wantSuccess: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := authClient.ResetPassword(context.Background(), tt.req)
+			if err != nil || got.Success != tt.wantSuccess {
+				t.Errorf("AuthService.ResetPassword() = %v, error %v, want success %v", got, err, tt.wantSuccess)
+			}
+		})
+	}
+
+	got, err := authClient.Authenticate(context.Background(), &protos.AuthReq{Username: "user", Password: "new_password"})
+	if err != nil || !got.Success {
+		t.Errorf("AuthService.Authenticate() with the new password = %v, error %v", got, err)
+	}
+}
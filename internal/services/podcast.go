@@ -1,14 +1,20 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"log"
 
-	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/proto"
 	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/models"
+	"github.com/sschwartz96/syncapod/internal/opml"
+	"github.com/sschwartz96/syncapod/internal/playlist"
 	"github.com/sschwartz96/syncapod/internal/podcast"
 	"github.com/sschwartz96/syncapod/internal/protos"
+	"github.com/sschwartz96/syncapod/internal/queue"
 	"github.com/sschwartz96/syncapod/internal/user"
 	"google.golang.org/grpc/metadata"
 )
@@ -17,28 +23,76 @@ import (
 type PodcastService struct {
 	*protos.UnimplementedPodServer
 	dbClient db.Database
+	creds    *podcast.Credentials
 }
 
-// NewPodcastService creates a new *PodcastService
-func NewPodcastService(dbClient db.Database) *PodcastService {
-	return &PodcastService{dbClient: dbClient}
+// NewPodcastService creates a new *PodcastService, private podcasts are disabled if creds is nil
+func NewPodcastService(dbClient db.Database, creds *podcast.Credentials) *PodcastService {
+	return &PodcastService{dbClient: dbClient, creds: creds}
 }
 
-// GetPodcast returns a podcast via id
+// GetPodcast returns a podcast via id, private podcasts are only returned to their owner
 func (p *PodcastService) GetPodcast(ctx context.Context, req *protos.Request) (*protos.Podcast, error) {
-	podcast, err := podcast.FindPodcastByID(p.dbClient, req.PodcastID)
+	userID, _ := getUserIDFromContext(ctx)
+	podcast, err := podcast.FindPodcastForUser(p.dbClient, req.PodcastID, userID)
 	if err != nil {
 		return nil, fmt.Errorf("GetPodcast() error finding podcast: %v", err)
 	}
 	return podcast, nil
 }
 
+// GetFeedSchedule returns when the podcast's feed will next be checked and the result of the last check
+func (p *PodcastService) GetFeedSchedule(ctx context.Context, req *protos.Request) (*protos.FeedSchedule, error) {
+	userID, _ := getUserIDFromContext(ctx)
+	_, err := podcast.FindPodcastForUser(p.dbClient, req.PodcastID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("GetFeedSchedule() error finding podcast: %v", err)
+	}
+	schedule, err := podcast.FindFeedSchedule(p.dbClient, req.PodcastID)
+	if err != nil {
+		return nil, fmt.Errorf("GetFeedSchedule() error finding schedule: %v", err)
+	}
+	return schedule, nil
+}
+
+// GetUnhealthyFeeds returns the health of the feeds the user is subscribed to whose last check failed, most failures first
+func (p *PodcastService) GetUnhealthyFeeds(ctx context.Context, req *protos.Request) (*protos.FeedHealthList, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetUnhealthyFeeds() error getting user id: %v", err)
+	}
+	feeds, err := podcast.FindUnhealthyFeeds(p.dbClient, userID)
+	if err != nil {
+		return nil, fmt.Errorf("GetUnhealthyFeeds() error finding feeds: %v", err)
+	}
+	return &protos.FeedHealthList{Feeds: feeds}, nil
+}
+
+// AddPrivatePodcast adds the user's private feed, its url & credentials are stored encrypted
+// and the podcast is only visible to the user
+func (p *PodcastService) AddPrivatePodcast(ctx context.Context, req *protos.PrivateFeedReq) (*protos.Podcast, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("AddPrivatePodcast() error getting user id: %v", err)
+	}
+	auth := &models.FeedAuth{URL: req.Url, Username: req.Username, Password: req.Password}
+	pod, err := podcast.AddPrivatePodcast(p.dbClient, p.creds, userID, auth)
+	if err != nil {
+		return nil, fmt.Errorf("AddPrivatePodcast() error: %v", err)
+	}
+	return pod, nil
+}
+
 // GetEpisodes returns a list of episodes via podcast id
 func (p *PodcastService) GetEpisodes(ctx context.Context, req *protos.Request) (*protos.Episodes, error) {
 	var episodes []*protos.Episode
 	var err error
 	// get the id and validate
 	if req.PodcastID != nil || len(req.PodcastID.Hex) > 0 {
+		userID, _ := getUserIDFromContext(ctx)
+		if pod, err := podcast.FindPodcastByID(p.dbClient, req.PodcastID); err == nil && !podcast.CanAccessPodcast(pod, userID) {
+			return &protos.Episodes{Episodes: []*protos.Episode{}}, fmt.Errorf("GetEpisodes() error: podcast not found")
+		}
 		episodes, err = podcast.FindEpisodesByRange(p.dbClient, req.PodcastID, req.Start, req.End)
 		if err != nil {
 			fmt.Println("error grpc GetEpisodes:", err)
@@ -50,7 +104,61 @@ func (p *PodcastService) GetEpisodes(ctx context.Context, req *protos.Request) (
 	return &protos.Episodes{Episodes: episodes}, nil
 }
 
-// GetUserEpisode returns the user playback metadata via episode id & user id
+// GetChapters returns the chapters embedded in the episode's enclosure via episode id,
+// empty until they are extracted
+func (p *PodcastService) GetChapters(ctx context.Context, req *protos.Request) (*protos.ChapterList, error) {
+	userID, _ := getUserIDFromContext(ctx)
+	epi, err := podcast.FindEpisodeByID(p.dbClient, req.EpisodeID)
+	if err != nil {
+		return nil, fmt.Errorf("GetChapters() error finding episode: %v", err)
+	}
+	_, err = podcast.FindPodcastForUser(p.dbClient, epi.PodcastID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("GetChapters() error finding podcast: %v", err)
+	}
+	return &protos.ChapterList{Chapters: epi.EmbeddedChapters}, nil
+}
+
+// GetAdMarkers returns the ads dynamically inserted into the variant of the episode's enclosure
+// of enclosureLength bytes via episode id, empty until the enclosure is fingerprinted
+func (p *PodcastService) GetAdMarkers(ctx context.Context, req *protos.Request) (*protos.AdMarkerList, error) {
+	userID, _ := getUserIDFromContext(ctx)
+	epi, err := podcast.FindEpisodeByID(p.dbClient, req.EpisodeID)
+	if err != nil {
+		return nil, fmt.Errorf("GetAdMarkers() error finding episode: %v", err)
+	}
+	_, err = podcast.FindPodcastForUser(p.dbClient, epi.PodcastID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("GetAdMarkers() error finding podcast: %v", err)
+	}
+	ads, err := podcast.FindAdMarkers(p.dbClient, epi.Id, req.EnclosureLength)
+	if err != nil {
+		return &protos.AdMarkerList{}, nil
+	}
+	return &protos.AdMarkerList{Markers: ads}, nil
+}
+
+// GetWaveform returns the waveform & silence map of the episode's enclosure via episode id,
+// empty until it is generated
+func (p *PodcastService) GetWaveform(ctx context.Context, req *protos.Request) (*protos.Waveform, error) {
+	userID, _ := getUserIDFromContext(ctx)
+	epi, err := podcast.FindEpisodeByID(p.dbClient, req.EpisodeID)
+	if err != nil {
+		return nil, fmt.Errorf("GetWaveform() error finding episode: %v", err)
+	}
+	_, err = podcast.FindPodcastForUser(p.dbClient, epi.PodcastID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("GetWaveform() error finding podcast: %v", err)
+	}
+	waveform, err := podcast.FindWaveform(p.dbClient, epi.Id)
+	if err != nil {
+		return &protos.Waveform{EpisodeID: epi.Id}, nil
+	}
+	return waveform, nil
+}
+
+// GetUserEpisode returns the user playback metadata via episode id & user id,
+// the offset is within the enclosure variant of enclosureLength bytes
 func (p *PodcastService) GetUserEpisode(ctx context.Context, req *protos.Request) (*protos.UserEpisode, error) {
 	userID, err := getUserIDFromContext(ctx)
 	if err != nil {
@@ -59,30 +167,44 @@ func (p *PodcastService) GetUserEpisode(ctx context.Context, req *protos.Request
 	userEpi, err := user.FindUserEpisode(p.dbClient, userID, req.EpisodeID)
 	if err != nil {
 		fmt.Println("error finding userEpi:", err)
+		return userEpi, nil
 	}
+	userEpi.Offset = podcast.MapOffset(p.dbClient, req.EpisodeID, 0, req.EnclosureLength, userEpi.Offset)
 	return userEpi, nil
 }
 
-// UpdateUserEpisode updates the user playback metadata via episode id & user id
-func (p *PodcastService) UpdateUserEpisode(ctx context.Context, req *protos.UserEpisodeReq) (*protos.Response, error) {
-	if req.LastSeen == nil {
-		req.LastSeen = ptypes.TimestampNow()
+// UpdateUserEpisode merges the update of the user's playback of the episode into the stored one,
+// the update with the latest req.LastSeen wins and a stale one is reported back with the stored playback
+func (p *PodcastService) UpdateUserEpisode(ctx context.Context, req *protos.UserEpisodeReq) (*protos.UserEpisodeRes, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("UpdateUserEpisode() error getting user id: %v", err)
 	}
 	userEpi := &protos.UserEpisode{
+		UserID:    userID,
 		EpisodeID: req.EpisodeID,
 		PodcastID: req.PodcastID,
 		Played:    req.Played,
-		Offset:    req.Offset,
+		LastSeen:  req.LastSeen,
+		DeviceID:  req.DeviceID,
+		// stored in the reference variant's timeline, so it resumes at the same content from any variant
+		Offset: podcast.MapOffset(p.dbClient, req.EpisodeID, req.EnclosureLength, 0, req.Offset),
 	}
-	err := user.UpsertUserEpisode(p.dbClient, userEpi)
+	stored, applied, err := user.SyncUserEpisode(p.dbClient, userEpi)
 	if err != nil {
 		fmt.Println("error updating user episode", err)
-		return &protos.Response{Success: false, Message: err.Error()}, nil
+		return &protos.UserEpisodeRes{Success: false, Message: err.Error()}, nil
+	}
+	// returned in the timeline of the client's variant
+	stored = proto.Clone(stored).(*protos.UserEpisode)
+	stored.Offset = podcast.MapOffset(p.dbClient, req.EpisodeID, 0, req.EnclosureLength, stored.Offset)
+	if !applied {
+		return &protos.UserEpisodeRes{Success: false, Message: "stale update, a newer one is stored", UserEpisode: stored}, nil
 	}
-	return &protos.Response{Success: true, Message: ""}, nil
+	return &protos.UserEpisodeRes{Success: true, UserEpisode: stored}, nil
 }
 
-// GetSubscriptions returns a list of podcasts via user id
+// GetSubscriptions returns the user's subscriptions and their podcasts, in the same order
 func (p *PodcastService) GetSubscriptions(ctx context.Context, req *protos.Request) (*protos.Subscriptions, error) {
 	userID, err := getUserIDFromContext(ctx)
 	if err != nil {
@@ -95,7 +217,346 @@ func (p *PodcastService) GetSubscriptions(ctx context.Context, req *protos.Reque
 		return &protos.Subscriptions{}, nil
 	}
 
-	return &protos.Subscriptions{Subscriptions: subs}, nil
+	res := &protos.Subscriptions{Subscriptions: []*protos.Subscription{}, Podcasts: []*protos.Podcast{}}
+	for _, sub := range subs {
+		pod, err := podcast.FindPodcastByID(p.dbClient, sub.PodcastID)
+		if err != nil {
+			log.Println("GetSubscriptions() error finding podcast:", err)
+			continue
+		}
+		res.Subscriptions = append(res.Subscriptions, sub)
+		res.Podcasts = append(res.Podcasts, pod)
+	}
+	return res, nil
+}
+
+// Subscribe subscribes the user to the podcast with the id, or with the rss url adding it if it's new
+func (p *PodcastService) Subscribe(ctx context.Context, req *protos.SubscribeReq) (*protos.Subscription, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Subscribe() error getting user id: %v", err)
+	}
+
+	var pod *protos.Podcast
+	if req.PodcastID != nil {
+		pod, err = podcast.FindPodcastForUser(p.dbClient, req.PodcastID, userID)
+	} else if req.Url != "" {
+		pod, err = podcast.FindOrAddPodcast(p.dbClient, req.Url)
+		if err == nil && !podcast.CanAccessPodcast(pod, userID) {
+			err = errors.New("podcast not found")
+		}
+	} else {
+		err = errors.New("podcast id or url required")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Subscribe() error: %v", err)
+	}
+
+	sub, err := user.Subscribe(p.dbClient, userID, pod.Id)
+	if err != nil {
+		return nil, fmt.Errorf("Subscribe() error: %v", err)
+	}
+	return sub, nil
+}
+
+// Unsubscribe deletes the user's subscription to the podcast
+func (p *PodcastService) Unsubscribe(ctx context.Context, req *protos.Request) (*protos.Response, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Unsubscribe() error getting user id: %v", err)
+	}
+	err = user.Unsubscribe(p.dbClient, userID, req.PodcastID)
+	if err != nil {
+		return &protos.Response{Success: false, Message: "not subscribed"}, nil
+	}
+	return &protos.Response{Success: true}, nil
+}
+
+// UpdateSubscription replaces the settings of the user's subscription to the podcast
+func (p *PodcastService) UpdateSubscription(ctx context.Context, req *protos.SubscriptionReq) (*protos.Subscription, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("UpdateSubscription() error getting user id: %v", err)
+	}
+	sub, err := user.FindSubscription(p.dbClient, userID, req.PodcastID)
+	if err != nil {
+		return nil, fmt.Errorf("UpdateSubscription() error: not subscribed")
+	}
+	settings := req.Settings
+	if settings == nil {
+		settings = &protos.SubscriptionSettings{}
+	}
+	if settings.PlaybackSpeed < 0 || settings.SkipIntroMillis < 0 || settings.SkipOutroMillis < 0 {
+		return nil, fmt.Errorf("UpdateSubscription() error: invalid settings")
+	}
+	sub.Settings = settings
+	err = user.UpsertSubscription(p.dbClient, sub)
+	if err != nil {
+		return nil, fmt.Errorf("UpdateSubscription() error: %v", err)
+	}
+	return sub, nil
+}
+
+// ImportOPML subscribes the user to the feeds of the OPML document in the background,
+// returning the import's progress which GetImportProgress updates
+func (p *PodcastService) ImportOPML(ctx context.Context, req *protos.OPML) (*protos.ImportProgress, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ImportOPML() error getting user id: %v", err)
+	}
+	feeds, err := opml.Parse(bytes.NewReader(req.Document))
+	if err != nil {
+		return nil, fmt.Errorf("ImportOPML() error: %v", err)
+	}
+	return opml.StartImport(p.dbClient, userID, feeds), nil
+}
+
+// GetImportProgress returns the progress of the user's OPML import
+func (p *PodcastService) GetImportProgress(ctx context.Context, req *protos.ImportReq) (*protos.ImportProgress, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetImportProgress() error getting user id: %v", err)
+	}
+	progress, err := opml.FindImport(p.dbClient, req.ImportID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("GetImportProgress() error: import not found")
+	}
+	return progress, nil
+}
+
+// ExportOPML returns the user's subscriptions as an OPML document
+func (p *PodcastService) ExportOPML(ctx context.Context, req *protos.Request) (*protos.OPML, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ExportOPML() error getting user id: %v", err)
+	}
+	doc, err := opml.Export(p.dbClient, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ExportOPML() error: %v", err)
+	}
+	return &protos.OPML{Document: doc}, nil
+}
+
+// GetQueue returns the user's up next queue
+func (p *PodcastService) GetQueue(ctx context.Context, req *protos.Request) (*protos.Queue, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetQueue() error getting user id: %v", err)
+	}
+	return queue.Find(p.dbClient, userID), nil
+}
+
+// Enqueue queues the episode last, or next if req.Next is set
+func (p *PodcastService) Enqueue(ctx context.Context, req *protos.QueueReq) (*protos.Queue, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Enqueue() error getting user id: %v", err)
+	}
+	epi, err := podcast.FindEpisodeByID(p.dbClient, req.EpisodeID)
+	if err != nil {
+		return nil, fmt.Errorf("Enqueue() error: episode not found")
+	}
+	// private podcasts' episodes can only be queued by their owner
+	if _, err = podcast.FindPodcastForUser(p.dbClient, epi.PodcastID, userID); err != nil {
+		return nil, fmt.Errorf("Enqueue() error: episode not found")
+	}
+	q, err := queue.Enqueue(p.dbClient, userID, epi, req.Next)
+	if err != nil {
+		return nil, fmt.Errorf("Enqueue() error: %v", err)
+	}
+	return q, nil
+}
+
+// ReorderQueue moves the queued episode to req.Position
+func (p *PodcastService) ReorderQueue(ctx context.Context, req *protos.QueueReq) (*protos.Queue, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ReorderQueue() error getting user id: %v", err)
+	}
+	q, err := queue.Move(p.dbClient, userID, req.EpisodeID, int(req.Position))
+	if err != nil {
+		return nil, fmt.Errorf("ReorderQueue() error: %v", err)
+	}
+	return q, nil
+}
+
+// RemoveFromQueue removes the episode from the user's queue
+func (p *PodcastService) RemoveFromQueue(ctx context.Context, req *protos.QueueReq) (*protos.Queue, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("RemoveFromQueue() error getting user id: %v", err)
+	}
+	q, err := queue.Remove(p.dbClient, userID, req.EpisodeID)
+	if err != nil {
+		return nil, fmt.Errorf("RemoveFromQueue() error: %v", err)
+	}
+	return q, nil
+}
+
+// PopQueue removes the first episode of the user's queue and returns it to be played
+func (p *PodcastService) PopQueue(ctx context.Context, req *protos.Request) (*protos.Episode, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("PopQueue() error getting user id: %v", err)
+	}
+	epi, err := queue.PopEpisode(p.dbClient, userID)
+	if err != nil {
+		return nil, fmt.Errorf("PopQueue() error: %v", err)
+	}
+	return epi, nil
+}
+
+// GetPlaylists returns the user's smart playlists
+func (p *PodcastService) GetPlaylists(ctx context.Context, req *protos.Request) (*protos.Playlists, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetPlaylists() error getting user id: %v", err)
+	}
+	playlists, err := playlist.FindPlaylists(p.dbClient, userID)
+	if err != nil {
+		log.Println("GetPlaylists() error finding playlists:", err)
+		return &protos.Playlists{}, nil
+	}
+	return &protos.Playlists{Playlists: playlists}, nil
+}
+
+// CreatePlaylist creates a smart playlist with the name and query
+func (p *PodcastService) CreatePlaylist(ctx context.Context, req *protos.Playlist) (*protos.Playlist, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("CreatePlaylist() error getting user id: %v", err)
+	}
+	created, err := playlist.Create(p.dbClient, userID, req.Name, req.Query)
+	if err != nil {
+		return nil, fmt.Errorf("CreatePlaylist() error: %v", err)
+	}
+	return created, nil
+}
+
+// UpdatePlaylist replaces the name and query of the user's playlist with the id
+func (p *PodcastService) UpdatePlaylist(ctx context.Context, req *protos.Playlist) (*protos.Playlist, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("UpdatePlaylist() error getting user id: %v", err)
+	}
+	updated, err := playlist.Update(p.dbClient, req.Id, userID, req.Name, req.Query)
+	if err != nil {
+		return nil, fmt.Errorf("UpdatePlaylist() error: %v", err)
+	}
+	return updated, nil
+}
+
+// DeletePlaylist deletes the user's playlist
+func (p *PodcastService) DeletePlaylist(ctx context.Context, req *protos.PlaylistReq) (*protos.Response, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("DeletePlaylist() error getting user id: %v", err)
+	}
+	err = playlist.Delete(p.dbClient, req.PlaylistID, userID)
+	if err != nil {
+		return &protos.Response{Success: false, Message: err.Error()}, nil
+	}
+	return &protos.Response{Success: true}, nil
+}
+
+// EvaluatePlaylist returns the episodes from req.Start to req.End of the user's playlist
+func (p *PodcastService) EvaluatePlaylist(ctx context.Context, req *protos.PlaylistReq) (*protos.PlaylistEpisodes, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("EvaluatePlaylist() error getting user id: %v", err)
+	}
+	pl, err := playlist.FindPlaylist(p.dbClient, req.PlaylistID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("EvaluatePlaylist() error: %v", err)
+	}
+	episodes, more, err := playlist.Evaluate(p.dbClient, pl, req.Start, req.End)
+	if err != nil {
+		return nil, fmt.Errorf("EvaluatePlaylist() error: %v", err)
+	}
+	return &protos.PlaylistEpisodes{Episodes: episodes, More: more}, nil
+}
+
+// RegisterDevice registers a device of the user's, its id identifies its playback updates
+func (p *PodcastService) RegisterDevice(ctx context.Context, req *protos.Device) (*protos.Device, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("RegisterDevice() error getting user id: %v", err)
+	}
+	device, err := user.RegisterDevice(p.dbClient, userID, req.Name, req.Platform)
+	if err != nil {
+		return nil, fmt.Errorf("RegisterDevice() error: %v", err)
+	}
+	return device, nil
+}
+
+// GetDevices returns the user's devices
+func (p *PodcastService) GetDevices(ctx context.Context, req *protos.Request) (*protos.Devices, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetDevices() error getting user id: %v", err)
+	}
+	devices, err := user.FindDevices(p.dbClient, userID)
+	if err != nil {
+		log.Println("GetDevices() error finding devices:", err)
+		return &protos.Devices{}, nil
+	}
+	return &protos.Devices{Devices: devices}, nil
+}
+
+// RemoveDevice removes the user's device
+func (p *PodcastService) RemoveDevice(ctx context.Context, req *protos.DeviceReq) (*protos.Response, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("RemoveDevice() error getting user id: %v", err)
+	}
+	err = user.RemoveDevice(p.dbClient, req.DeviceID, userID)
+	if err != nil {
+		return &protos.Response{Success: false, Message: err.Error()}, nil
+	}
+	return &protos.Response{Success: true}, nil
+}
+
+// Sync returns the user's subscription and playback changes since the request's cursor
+func (p *PodcastService) Sync(ctx context.Context, req *protos.SyncReq) (*protos.SyncRes, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Sync() error getting user id: %v", err)
+	}
+	changes, cursor, err := user.Sync(p.dbClient, userID, req.Cursor)
+	if err != nil {
+		return nil, fmt.Errorf("Sync() error: %v", err)
+	}
+	return &protos.SyncRes{Changes: changes, Cursor: cursor}, nil
+}
+
+// WatchPlayback streams the user's playback updates as they're stored until the client cancels,
+// only those of the request's episode if it's set, mapped to the timeline of its enclosure variant
+func (p *PodcastService) WatchPlayback(req *protos.Request, stream protos.Pod_WatchPlaybackServer) error {
+	userID, err := getUserIDFromContext(stream.Context())
+	if err != nil {
+		return fmt.Errorf("WatchPlayback() error getting user id: %v", err)
+	}
+	updates, stop, err := user.WatchPlayback(userID)
+	if err != nil {
+		return fmt.Errorf("WatchPlayback() error: %v", err)
+	}
+	defer stop()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case userEpi := <-updates:
+			if req.EpisodeID != nil {
+				if userEpi.EpisodeID.GetHex() != req.EpisodeID.GetHex() {
+					continue
+				}
+				userEpi.Offset = podcast.MapOffset(p.dbClient, userEpi.EpisodeID, 0, req.EnclosureLength, userEpi.Offset)
+			}
+			if err = stream.Send(userEpi); err != nil {
+				return fmt.Errorf("WatchPlayback() error sending: %v", err)
+			}
+		}
+	}
 }
 
 // GetUserLastPlayed returns the last episode the user was playing & metadata
@@ -3,16 +3,21 @@ package services
 import (
 	"context"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/sschwartz96/stockpile/db"
 	"github.com/sschwartz96/stockpile/mock"
 	"github.com/sschwartz96/syncapod/internal/config"
 	"github.com/sschwartz96/syncapod/internal/database"
 	"github.com/sschwartz96/syncapod/internal/grpc"
+	"github.com/sschwartz96/syncapod/internal/models"
 	"github.com/sschwartz96/syncapod/internal/protos"
 	"github.com/sschwartz96/syncapod/internal/util"
 	gogrpc "google.golang.org/grpc"
@@ -33,6 +38,16 @@ func createPodcastServiceMockDB(t *testing.T) db.Database {
 	if err != nil {
 		t.Fatalf("createAuthSerivceMockDB() error inserting mock episode: %v", err)
 	}
+	chaptered := &protos.Podcast{Id: protos.ObjectIDFromHex("chap_pod_id"), Title: "Chaptered Podcast"}
+	err = dbClient.Insert(database.ColPodcast, chaptered)
+	if err != nil {
+		t.Fatalf("createAuthSerivceMockDB() error inserting mock podcast: %v", err)
+	}
+	err = dbClient.Insert(database.ColEpisode, &protos.Episode{PodcastID: chaptered.Id, Id: protos.ObjectIDFromHex("chap_epi_id"), Title: "Chaptered Episode",
+		EmbeddedChapters: []*protos.Chapter{{StartMillis: 0, EndMillis: 60000, Title: "Intro"}, {StartMillis: 60000, EndMillis: 120000, Title: "News"}}})
+	if err != nil {
+		t.Fatalf("createAuthSerivceMockDB() error inserting mock episode: %v", err)
+	}
 	user := &protos.User{
 		Id:       protos.ObjectIDFromHex("user_id"),
 		Username: "user",
@@ -48,12 +63,36 @@ func createPodcastServiceMockDB(t *testing.T) db.Database {
 	if err != nil {
 		t.Fatalf("createAuthSerivceMockDB() error inserting mock session: %v", err)
 	}
+	// another user, without subscriptions
+	err = dbClient.Insert(database.ColUser, &protos.User{Id: protos.ObjectIDFromHex("other_user_id"), Username: "other", DOB: ptypes.TimestampNow()})
+	if err != nil {
+		t.Fatalf("createAuthSerivceMockDB() error inserting mock user: %v", err)
+	}
+	err = dbClient.Insert(database.ColSession, &protos.Session{Id: protos.NewObjectID(), Expires: util.AddToTimestamp(ptypes.TimestampNow(), time.Hour), SessionKey: "other_secret", UserID: protos.ObjectIDFromHex("other_user_id")})
+	if err != nil {
+		t.Fatalf("createAuthSerivceMockDB() error inserting mock session: %v", err)
+	}
 	err = dbClient.Insert(database.ColUserEpisode, &protos.UserEpisode{
 		Id: protos.ObjectIDFromHex("userepi_id"), EpisodeID: protos.ObjectIDFromHex("epi_id"),
 		UserID: protos.ObjectIDFromHex("user_id"), PodcastID: protos.ObjectIDFromHex("pod_id")})
 	if err != nil {
 		t.Fatalf("createAuthSerivceMockDB() error inserting mock user episode: %v", err)
 	}
+	// a variant of epi_id's enclosure with a 30 second preroll
+	err = dbClient.Insert(database.ColVariant, &models.EnclosureVariant{
+		EpisodeID: protos.ObjectIDFromHex("epi_id"), Length: 2000,
+		Segments: []models.VariantSegment{{RefStartMillis: 0, StartMillis: 30000, LengthMillis: 600000}},
+		Ads:      []*protos.AdMarker{{StartMillis: 0, EndMillis: 30000}},
+	})
+	if err != nil {
+		t.Fatalf("createAuthSerivceMockDB() error inserting mock enclosure variant: %v", err)
+	}
+	err = dbClient.Insert(database.ColWaveform, &protos.Waveform{
+		EpisodeID: protos.ObjectIDFromHex("epi_id"), DurationMillis: 300, PeaksPerSecond: 10, Peaks: []byte{0, 128, 255},
+	})
+	if err != nil {
+		t.Fatalf("createAuthSerivceMockDB() error inserting mock waveform: %v", err)
+	}
 	err = dbClient.Insert(database.ColSubscription, &protos.Subscription{
 		Id:            protos.ObjectIDFromHex("sub_id"),
 		UserID:        protos.ObjectIDFromHex("user_id"),
@@ -63,6 +102,50 @@ func createPodcastServiceMockDB(t *testing.T) db.Database {
 	if err != nil {
 		t.Fatalf("createAuthSerivceMockDB() error inserting mock subscription: %v", err)
 	}
+	err = dbClient.Insert(database.ColFeedSchedule, &protos.FeedSchedule{
+		PodcastID:      protos.ObjectIDFromHex("pod_id"),
+		LastResult:     "success",
+		IntervalMillis: 900000,
+	})
+	if err != nil {
+		t.Fatalf("createAuthSerivceMockDB() error inserting mock feed schedule: %v", err)
+	}
+	// another user's private feed
+	err = dbClient.Insert(database.ColPodcast, &protos.Podcast{Id: protos.ObjectIDFromHex("private_pod_id"),
+		Title: "Private", Rss: "private://private_pod_id", Private: true, OwnerID: protos.ObjectIDFromHex("other_user_id")})
+	if err != nil {
+		t.Fatalf("createAuthSerivceMockDB() error inserting mock private podcast: %v", err)
+	}
+	err = dbClient.Insert(database.ColFeedSchedule, &protos.FeedSchedule{PodcastID: protos.ObjectIDFromHex("private_pod_id")})
+	if err != nil {
+		t.Fatalf("createAuthSerivceMockDB() error inserting mock feed schedule: %v", err)
+	}
+	for _, id := range []string{"failing_pod_id", "broken_pod_id"} {
+		err = dbClient.Insert(database.ColPodcast, &protos.Podcast{Id: protos.ObjectIDFromHex(id)})
+		if err != nil {
+			t.Fatalf("createAuthSerivceMockDB() error inserting mock podcast: %v", err)
+		}
+	}
+	for _, health := range []*protos.FeedHealth{
+		{PodcastID: protos.ObjectIDFromHex("pod_id"), Title: "healthy"},
+		{PodcastID: protos.ObjectIDFromHex("failing_pod_id"), Title: "failing", ConsecutiveFailures: 2, LastErrorClass: "dns"},
+		{PodcastID: protos.ObjectIDFromHex("broken_pod_id"), Title: "broken", ConsecutiveFailures: 12, LastErrorClass: "http_status", Quarantined: true},
+		{PodcastID: protos.ObjectIDFromHex("private_pod_id"), Title: "Private", ConsecutiveFailures: 3, LastErrorClass: "auth"},
+	} {
+		err = dbClient.Insert(database.ColFeedHealth, health)
+		if err != nil {
+			t.Fatalf("createAuthSerivceMockDB() error inserting mock feed health: %v", err)
+		}
+	}
+	// another user's device & playlist
+	err = dbClient.Insert(database.ColDevice, &protos.Device{Id: protos.NewObjectID(), UserID: protos.ObjectIDFromHex("other_user_id"), Name: "Other"})
+	if err != nil {
+		t.Fatalf("createAuthSerivceMockDB() error inserting mock device: %v", err)
+	}
+	err = dbClient.Insert(database.ColPlaylist, &protos.Playlist{Id: protos.NewObjectID(), UserID: protos.ObjectIDFromHex("other_user_id"), Name: "Other"})
+	if err != nil {
+		t.Fatalf("createAuthSerivceMockDB() error inserting mock playlist: %v", err)
+	}
 	return dbClient
 }
 
@@ -84,7 +167,7 @@ func TestPodcastService(t *testing.T) {
 	mockDB := createPodcastServiceMockDB(t)
 
 	lis = bufconn.Listen(bufSize)
-	s := grpc.NewServer(&config.Config{}, mockDB, NewAuthService(mockDB), NewPodcastService(mockDB))
+	s := grpc.NewServer(&config.Config{}, mockDB, NewAuthService(mockDB, nil), NewPodcastService(mockDB, nil))
 
 	go func() {
 		if err := s.Start(lis); err != nil {
@@ -103,9 +186,22 @@ func TestPodcastService(t *testing.T) {
 
 	// go through tests
 	testPodcastService_GetEpisodes(t, podcastClient)
+	testPodcastService_GetFeedSchedule(t, podcastClient)
+	testPodcastService_GetUnhealthyFeeds(t, podcastClient)
+	testPodcastService_GetChapters(t, podcastClient)
+	testPodcastService_GetAdMarkers(t, podcastClient)
+	testPodcastService_GetWaveform(t, podcastClient)
 	testPodcastService_GetUserEpisode(t, podcastClient)
 	testPodcastService_UpdateUserEpisode(t, podcastClient)
 	testPodcastService_GetSubscriptions(t, podcastClient)
+	testPodcastService_Subscribe(t, podcastClient)
+	testPodcastService_UpdateSubscription(t, podcastClient)
+	testPodcastService_Unsubscribe(t, podcastClient)
+	testPodcastService_OPML(t, podcastClient)
+	testPodcastService_Queue(t, podcastClient)
+	testPodcastService_Playlists(t, podcastClient)
+	testPodcastService_Sync(t, podcastClient)
+	testPodcastService_WatchPlayback(t, podcastClient)
 	testPodcastService_GetUserLastPlayed(t, podcastClient)
 }
 
@@ -153,7 +249,7 @@ func testPodcastService_GetEpisodes(t *testing.T, podClient protos.PodClient) {
 	}
 }
 
-func testPodcastService_GetUserEpisode(t *testing.T, podClient protos.PodClient) {
+func testPodcastService_GetFeedSchedule(t *testing.T, podClient protos.PodClient) {
 	type args struct {
 		ctx context.Context
 		req *protos.Request
@@ -161,79 +257,379 @@ func testPodcastService_GetUserEpisode(t *testing.T, podClient protos.PodClient)
 	tests := []struct {
 		name    string
 		args    args
-		want    *protos.UserEpisode
+		want    *protos.FeedSchedule
 		wantErr bool
 	}{
 		{
-			name: "GetUserEpisode_valid",
+			name: "GetFeedSchedule_valid",
 			args: args{
 				ctx: metadata.AppendToOutgoingContext(context.Background(), "token", "secret"),
-				req: &protos.Request{
-					EpisodeID: protos.ObjectIDFromHex("epi_id"),
-					PodcastID: protos.ObjectIDFromHex("pod_id"),
-				},
+				req: &protos.Request{PodcastID: protos.ObjectIDFromHex("pod_id")},
 			},
-			want: &protos.UserEpisode{
-				Id: protos.ObjectIDFromHex("userepi_id"), EpisodeID: protos.ObjectIDFromHex("epi_id"),
-				UserID: protos.ObjectIDFromHex("user_id"), PodcastID: protos.ObjectIDFromHex("pod_id"),
+			want:    &protos.FeedSchedule{PodcastID: protos.ObjectIDFromHex("pod_id"), LastResult: "success", IntervalMillis: 900000},
+			wantErr: false,
+		},
+		{
+			name: "GetFeedSchedule_private",
+			args: args{
+				ctx: metadata.AppendToOutgoingContext(context.Background(), "token", "secret"),
+				req: &protos.Request{PodcastID: protos.ObjectIDFromHex("private_pod_id")},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "GetFeedSchedule_not_found",
+			args: args{
+				ctx: metadata.AppendToOutgoingContext(context.Background(), "token", "secret"),
+				req: &protos.Request{PodcastID: protos.ObjectIDFromHex("no_pod_id")},
 			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := podClient.GetFeedSchedule(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PodcastService.GetFeedSchedule() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got.String(), tt.want.String()) {
+				t.Errorf("PodcastService.GetFeedSchedule() = %v, want %v", got.String(), tt.want.String())
+			}
+		})
+	}
+}
+
+func testPodcastService_GetUnhealthyFeeds(t *testing.T, podClient protos.PodClient) {
+	type args struct {
+		ctx context.Context
+		req *protos.Request
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *protos.FeedHealthList
+		wantErr bool
+	}{
+		// the feed health is found by database operators the mock database doesn't support, so only a user
+		// without subscriptions is tested here and the podcast package tests the filter
+		{
+			name: "GetUnhealthyFeeds_no_subscriptions",
+			args: args{
+				ctx: metadata.AppendToOutgoingContext(context.Background(), "token", "other_secret"),
+				req: &protos.Request{},
+			},
+			want:    &protos.FeedHealthList{Feeds: []*protos.FeedHealth{}},
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := podClient.GetUserEpisode(tt.args.ctx, tt.args.req)
+			got, err := podClient.GetUnhealthyFeeds(tt.args.ctx, tt.args.req)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("PodcastService.GetUserEpisode() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("PodcastService.GetUnhealthyFeeds() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if !reflect.DeepEqual(got.String(), tt.want.String()) {
-				t.Errorf("PodcastService.GetUserEpisode() = %v, want %v", got.String(), tt.want.String())
+				t.Errorf("PodcastService.GetUnhealthyFeeds() = %v, want %v", got.String(), tt.want.String())
 			}
 		})
 	}
 }
 
-func testPodcastService_UpdateUserEpisode(t *testing.T, podClient protos.PodClient) {
+func testPodcastService_GetChapters(t *testing.T, podClient protos.PodClient) {
 	type args struct {
 		ctx context.Context
-		req *protos.UserEpisodeReq
+		req *protos.Request
 	}
 	tests := []struct {
 		name    string
 		args    args
-		want    *protos.Response
+		want    *protos.ChapterList
 		wantErr bool
 	}{
 		{
-			name: "UpdateUserEpisode_valid",
+			name: "GetChapters_valid",
 			args: args{
 				ctx: metadata.AppendToOutgoingContext(context.Background(), "token", "secret"),
-				req: &protos.UserEpisodeReq{
-					PodcastID: protos.ObjectIDFromHex("pod_id"),
+				req: &protos.Request{EpisodeID: protos.ObjectIDFromHex("chap_epi_id")},
+			},
+			want:    &protos.ChapterList{Chapters: []*protos.Chapter{{StartMillis: 0, EndMillis: 60000, Title: "Intro"}, {StartMillis: 60000, EndMillis: 120000, Title: "News"}}},
+			wantErr: false,
+		},
+		{
+			name: "GetChapters_not_extracted",
+			args: args{
+				ctx: metadata.AppendToOutgoingContext(context.Background(), "token", "secret"),
+				req: &protos.Request{EpisodeID: protos.ObjectIDFromHex("epi_id")},
+			},
+			want:    &protos.ChapterList{},
+			wantErr: false,
+		},
+		{
+			name: "GetChapters_not_found",
+			args: args{
+				ctx: metadata.AppendToOutgoingContext(context.Background(), "token", "secret"),
+				req: &protos.Request{EpisodeID: protos.ObjectIDFromHex("no_epi_id")},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := podClient.GetChapters(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PodcastService.GetChapters() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got.String(), tt.want.String()) {
+				t.Errorf("PodcastService.GetChapters() = %v, want %v", got.String(), tt.want.String())
+			}
+		})
+	}
+}
+
+func testPodcastService_GetAdMarkers(t *testing.T, podClient protos.PodClient) {
+	type args struct {
+		ctx context.Context
+		req *protos.Request
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *protos.AdMarkerList
+		wantErr bool
+	}{
+		{
+			name: "GetAdMarkers_valid",
+			args: args{
+				ctx: metadata.AppendToOutgoingContext(context.Background(), "token", "secret"),
+				req: &protos.Request{EpisodeID: protos.ObjectIDFromHex("epi_id"), EnclosureLength: 2000},
+			},
+			want:    &protos.AdMarkerList{Markers: []*protos.AdMarker{{StartMillis: 0, EndMillis: 30000}}},
+			wantErr: false,
+		},
+		{
+			name: "GetAdMarkers_not_fingerprinted",
+			args: args{
+				ctx: metadata.AppendToOutgoingContext(context.Background(), "token", "secret"),
+				req: &protos.Request{EpisodeID: protos.ObjectIDFromHex("chap_epi_id"), EnclosureLength: 2000},
+			},
+			want:    &protos.AdMarkerList{},
+			wantErr: false,
+		},
+		{
+			name: "GetAdMarkers_not_found",
+			args: args{
+				ctx: metadata.AppendToOutgoingContext(context.Background(), "token", "secret"),
+				req: &protos.Request{EpisodeID: protos.ObjectIDFromHex("no_epi_id")},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := podClient.GetAdMarkers(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PodcastService.GetAdMarkers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got.String(), tt.want.String()) {
+				t.Errorf("PodcastService.GetAdMarkers() = %v, want %v", got.String(), tt.want.String())
+			}
+		})
+	}
+}
+
+func testPodcastService_GetWaveform(t *testing.T, podClient protos.PodClient) {
+	type args struct {
+		ctx context.Context
+		req *protos.Request
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *protos.Waveform
+		wantErr bool
+	}{
+		{
+			name: "GetWaveform_valid",
+			args: args{
+				ctx: metadata.AppendToOutgoingContext(context.Background(), "token", "secret"),
+				req: &protos.Request{EpisodeID: protos.ObjectIDFromHex("epi_id")},
+			},
+			want:    &protos.Waveform{EpisodeID: protos.ObjectIDFromHex("epi_id"), DurationMillis: 300, PeaksPerSecond: 10, Peaks: []byte{0, 128, 255}},
+			wantErr: false,
+		},
+		{
+			name: "GetWaveform_not_generated",
+			args: args{
+				ctx: metadata.AppendToOutgoingContext(context.Background(), "token", "secret"),
+				req: &protos.Request{EpisodeID: protos.ObjectIDFromHex("chap_epi_id")},
+			},
+			want:    &protos.Waveform{EpisodeID: protos.ObjectIDFromHex("chap_epi_id")},
+			wantErr: false,
+		},
+		{
+			name: "GetWaveform_not_found",
+			args: args{
+				ctx: metadata.AppendToOutgoingContext(context.Background(), "token", "secret"),
+				req: &protos.Request{EpisodeID: protos.ObjectIDFromHex("no_epi_id")},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := podClient.GetWaveform(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PodcastService.GetWaveform() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got.String(), tt.want.String()) {
+				t.Errorf("PodcastService.GetWaveform() = %v, want %v", got.String(), tt.want.String())
+			}
+		})
+	}
+}
+
+func testPodcastService_GetUserEpisode(t *testing.T, podClient protos.PodClient) {
+	type args struct {
+		ctx context.Context
+		req *protos.Request
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *protos.UserEpisode
+		wantErr bool
+	}{
+		{
+			name: "GetUserEpisode_valid",
+			args: args{
+				ctx: metadata.AppendToOutgoingContext(context.Background(), "token", "secret"),
+				req: &protos.Request{
 					EpisodeID: protos.ObjectIDFromHex("epi_id"),
-					Offset:    11111,
-					Played:    true,
+					PodcastID: protos.ObjectIDFromHex("pod_id"),
 				},
 			},
-			want:    &protos.Response{Success: true},
+			want: &protos.UserEpisode{
+				Id: protos.ObjectIDFromHex("userepi_id"), EpisodeID: protos.ObjectIDFromHex("epi_id"),
+				UserID: protos.ObjectIDFromHex("user_id"), PodcastID: protos.ObjectIDFromHex("pod_id"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "GetUserEpisode_variant",
+			args: args{
+				ctx: metadata.AppendToOutgoingContext(context.Background(), "token", "secret"),
+				req: &protos.Request{
+					EpisodeID:       protos.ObjectIDFromHex("epi_id"),
+					PodcastID:       protos.ObjectIDFromHex("pod_id"),
+					EnclosureLength: 2000,
+				},
+			},
+			// the start of the content, after the variant's preroll
+			want: &protos.UserEpisode{
+				Id: protos.ObjectIDFromHex("userepi_id"), EpisodeID: protos.ObjectIDFromHex("epi_id"),
+				UserID: protos.ObjectIDFromHex("user_id"), PodcastID: protos.ObjectIDFromHex("pod_id"), Offset: 30000,
+			},
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := podClient.UpdateUserEpisode(tt.args.ctx, tt.args.req)
+			got, err := podClient.GetUserEpisode(tt.args.ctx, tt.args.req)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("PodcastService.UpdateUserEpisode() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("PodcastService.GetUserEpisode() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if !reflect.DeepEqual(got.String(), tt.want.String()) {
-				t.Errorf("PodcastService.UpdateUserEpisode() = %v, want %v", got.String(), tt.want.String())
+				t.Errorf("PodcastService.GetUserEpisode() = %v, want %v", got.String(), tt.want.String())
 			}
 		})
 	}
 }
 
+func testPodcastService_UpdateUserEpisode(t *testing.T, podClient protos.PodClient) {
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "token", "secret")
+	device, err := podClient.RegisterDevice(ctx, &protos.Device{Name: "Phone", Platform: "android"})
+	if err != nil {
+		t.Fatalf("PodcastService.RegisterDevice() error = %v", err)
+	}
+	devices, err := podClient.GetDevices(ctx, &protos.Request{})
+	if err != nil || len(devices.Devices) != 1 || devices.Devices[0].Name != "Phone" {
+		t.Errorf("PodcastService.GetDevices() = %v, error = %v", devices, err)
+	}
+	removed, err := podClient.RegisterDevice(ctx, &protos.Device{Name: "Old Phone"})
+	if err != nil {
+		t.Fatalf("PodcastService.RegisterDevice() error = %v", err)
+	}
+	res, err := podClient.RemoveDevice(ctx, &protos.DeviceReq{DeviceID: removed.Id})
+	if err != nil || !res.Success {
+		t.Errorf("PodcastService.RemoveDevice() = %v, error = %v", res, err)
+	}
+
+	now := time.Now()
+	at := func(d time.Duration) *timestamp.Timestamp {
+		ts, _ := ptypes.TimestampProto(now.Add(d))
+		return ts
+	}
+	tests := []struct {
+		name        string
+		req         *protos.UserEpisodeReq
+		wantSuccess bool
+		wantOffset  int64
+	}{
+		{
+			name:        "valid",
+			req:         &protos.UserEpisodeReq{EpisodeID: protos.ObjectIDFromHex("epi_id"), Offset: 11111, Played: true, LastSeen: at(-time.Minute)},
+			wantSuccess: true,
+			wantOffset:  11111,
+		},
+		{
+			name:        "stale",
+			req:         &protos.UserEpisodeReq{EpisodeID: protos.ObjectIDFromHex("epi_id"), Offset: 22222, LastSeen: at(-time.Hour), DeviceID: device.Id},
+			wantSuccess: false,
+			wantOffset:  11111,
+		},
+		{
+			name:        "removed_device",
+			req:         &protos.UserEpisodeReq{EpisodeID: protos.ObjectIDFromHex("epi_id"), Offset: 33333, DeviceID: removed.Id},
+			wantSuccess: false,
+		},
+		{
+			name:        "newer_device",
+			req:         &protos.UserEpisodeReq{EpisodeID: protos.ObjectIDFromHex("epi_id"), Offset: 44444, LastSeen: at(0), DeviceID: device.Id},
+			wantSuccess: true,
+			wantOffset:  44444,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := podClient.UpdateUserEpisode(ctx, tt.req)
+			if err != nil {
+				t.Fatalf("PodcastService.UpdateUserEpisode() error = %v", err)
+			}
+			if got.Success != tt.wantSuccess || got.UserEpisode.GetOffset() != tt.wantOffset {
+				t.Errorf("PodcastService.UpdateUserEpisode() = %v, want success %v, offset %v", got, tt.wantSuccess, tt.wantOffset)
+			}
+		})
+	}
+
+	// the stored playback is the caller's, updated in place
+	stored, err := podClient.GetUserEpisode(ctx, &protos.Request{EpisodeID: protos.ObjectIDFromHex("epi_id")})
+	if err != nil || stored.Offset != 44444 || stored.Id.GetHex() != protos.ObjectIDFromHex("userepi_id").GetHex() ||
+		stored.UserID.GetHex() != protos.ObjectIDFromHex("user_id").GetHex() || stored.DeviceID.GetHex() != device.Id.GetHex() {
+		t.Errorf("PodcastService.UpdateUserEpisode() stored %v, error = %v", stored, err)
+	}
+}
+
 func testPodcastService_GetSubscriptions(t *testing.T, podClient protos.PodClient) {
 	type args struct {
 		ctx context.Context
@@ -260,6 +656,7 @@ func testPodcastService_GetSubscriptions(t *testing.T, podClient protos.PodClien
 					PodcastID:     protos.ObjectIDFromHex("pod_id"),
 					InProgressIDs: []*protos.ObjectID{protos.ObjectIDFromHex("epi_id")},
 				}},
+				Podcasts: []*protos.Podcast{{Id: protos.ObjectIDFromHex("pod_id"), Author: "Sam Schwartz", Title: "Mock Podcast"}},
 			},
 			wantErr: false,
 		},
@@ -278,6 +675,372 @@ func testPodcastService_GetSubscriptions(t *testing.T, podClient protos.PodClien
 	}
 }
 
+func testPodcastService_Subscribe(t *testing.T, podClient protos.PodClient) {
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0"><channel><title>New Podcast</title>
+<item><title>New Episode</title><guid>new-1</guid><enclosure url="https://example.com/1.mp3" type="audio/mpeg" /></item>
+</channel></rss>`))
+	}))
+	defer server.Close()
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "token", "secret")
+
+	tests := []struct {
+		name      string
+		req       *protos.SubscribeReq
+		wantTitle string
+		wantErr   bool
+	}{
+		{name: "Subscribe_id", req: &protos.SubscribeReq{PodcastID: protos.ObjectIDFromHex("chap_pod_id")}, wantTitle: "Chaptered Podcast"},
+		{name: "Subscribe_new_url", req: &protos.SubscribeReq{Url: server.URL + "/feed"}, wantTitle: "New Podcast"},
+		{name: "Subscribe_known_url", req: &protos.SubscribeReq{Url: server.URL + "/feed"}, wantTitle: "New Podcast"},
+		{name: "Subscribe_unknown_id", req: &protos.SubscribeReq{PodcastID: protos.ObjectIDFromHex("no_pod_id")}, wantErr: true},
+		{name: "Subscribe_empty", req: &protos.SubscribeReq{}, wantErr: true},
+	}
+	subIDs := map[string]string{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := podClient.Subscribe(ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PodcastService.Subscribe() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			subs, err := podClient.GetSubscriptions(ctx, &protos.Request{})
+			if err != nil {
+				t.Fatalf("PodcastService.GetSubscriptions() error = %v", err)
+			}
+			found := 0
+			for i, sub := range subs.Subscriptions {
+				if sub.Id.GetHex() == got.Id.GetHex() {
+					found++
+					if subs.Podcasts[i].Title != tt.wantTitle || sub.PodcastID.GetHex() != subs.Podcasts[i].Id.GetHex() {
+						t.Errorf("PodcastService.Subscribe() subscribed to %v, want %v", subs.Podcasts[i], tt.wantTitle)
+					}
+				}
+			}
+			if found != 1 {
+				t.Errorf("PodcastService.Subscribe() subscription listed %d times", found)
+			}
+			// subscribing again returns the same subscription
+			if id, ok := subIDs[tt.wantTitle]; ok && id != got.Id.GetHex() {
+				t.Errorf("PodcastService.Subscribe() subscribed twice to %v", tt.wantTitle)
+			}
+			subIDs[tt.wantTitle] = got.Id.GetHex()
+		})
+	}
+}
+
+func testPodcastService_UpdateSubscription(t *testing.T, podClient protos.PodClient) {
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "token", "secret")
+	tests := []struct {
+		name    string
+		req     *protos.SubscriptionReq
+		wantErr bool
+	}{
+		{
+			name: "UpdateSubscription_valid",
+			req: &protos.SubscriptionReq{PodcastID: protos.ObjectIDFromHex("chap_pod_id"),
+				Settings: &protos.SubscriptionSettings{PlaybackSpeed: 1.5, SkipIntroMillis: 30000, AutoDownload: true}},
+		},
+		{
+			name: "UpdateSubscription_invalid",
+			req: &protos.SubscriptionReq{PodcastID: protos.ObjectIDFromHex("chap_pod_id"),
+				Settings: &protos.SubscriptionSettings{PlaybackSpeed: -1}},
+			wantErr: true,
+		},
+		{
+			name:    "UpdateSubscription_not_subscribed",
+			req:     &protos.SubscriptionReq{PodcastID: protos.ObjectIDFromHex("no_pod_id"), Settings: &protos.SubscriptionSettings{}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := podClient.UpdateSubscription(ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PodcastService.UpdateSubscription() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Settings.String() != tt.req.Settings.String() || got.PodcastID.GetHex() != tt.req.PodcastID.GetHex() {
+				t.Errorf("PodcastService.UpdateSubscription() = %v, want settings %v", got, tt.req.Settings)
+			}
+		})
+	}
+
+	// the settings are stored
+	subs, err := podClient.GetSubscriptions(ctx, &protos.Request{})
+	if err != nil {
+		t.Fatalf("PodcastService.GetSubscriptions() error = %v", err)
+	}
+	for _, sub := range subs.Subscriptions {
+		if sub.PodcastID.GetHex() == protos.ObjectIDFromHex("chap_pod_id").GetHex() && sub.Settings.PlaybackSpeed != 1.5 {
+			t.Errorf("PodcastService.UpdateSubscription() stored %v", sub.Settings)
+		}
+	}
+}
+
+func testPodcastService_Unsubscribe(t *testing.T, podClient protos.PodClient) {
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "token", "secret")
+	// only the first unsubscribe succeeds
+	for i, wantSuccess := range []bool{true, false} {
+		got, err := podClient.Unsubscribe(ctx, &protos.Request{PodcastID: protos.ObjectIDFromHex("chap_pod_id")})
+		if err != nil || got.Success != wantSuccess {
+			t.Errorf("PodcastService.Unsubscribe() %d = %v, error %v, want success %v", i, got, err, wantSuccess)
+		}
+	}
+	subs, err := podClient.GetSubscriptions(ctx, &protos.Request{})
+	if err != nil {
+		t.Fatalf("PodcastService.GetSubscriptions() error = %v", err)
+	}
+	for _, sub := range subs.Subscriptions {
+		if sub.PodcastID.GetHex() == protos.ObjectIDFromHex("chap_pod_id").GetHex() {
+			t.Errorf("PodcastService.Unsubscribe() left %v", sub)
+		}
+	}
+}
+
+func testPodcastService_OPML(t *testing.T, podClient protos.PodClient) {
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0"><channel><title>Imported Podcast</title><link>https://example.com</link>
+<item><title>Imported Episode</title><guid>imported-1</guid><enclosure url="https://example.com/1.mp3" type="audio/mpeg" /></item>
+</channel></rss>`))
+	}))
+	defer server.Close()
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "token", "secret")
+
+	_, err := podClient.ImportOPML(ctx, &protos.OPML{Document: []byte("not opml")})
+	if err == nil {
+		t.Errorf("PodcastService.ImportOPML() invalid document error = nil")
+	}
+	doc := `<opml version="1.0"><body><outline text="imported">` +
+		`<outline text="Imported Podcast" type="rss" xmlUrl="` + server.URL + `/imported.rss" /></outline></body></opml>`
+	started, err := podClient.ImportOPML(ctx, &protos.OPML{Document: []byte(doc)})
+	if err != nil || started.Total != 1 {
+		t.Fatalf("PodcastService.ImportOPML() = %v, error = %v", started, err)
+	}
+	progress := started
+	for i := 0; i < 100 && !progress.Finished; i++ {
+		time.Sleep(10 * time.Millisecond)
+		progress, err = podClient.GetImportProgress(ctx, &protos.ImportReq{ImportID: started.Id})
+		if err != nil {
+			t.Fatalf("PodcastService.GetImportProgress() error = %v", err)
+		}
+	}
+	if !progress.Finished || progress.Subscribed != 1 {
+		t.Fatalf("PodcastService.GetImportProgress() = %v", progress)
+	}
+	if _, err = podClient.GetImportProgress(ctx, &protos.ImportReq{ImportID: protos.NewObjectID()}); err == nil {
+		t.Errorf("PodcastService.GetImportProgress() unknown import error = nil")
+	}
+
+	exported, err := podClient.ExportOPML(ctx, &protos.Request{})
+	if err != nil {
+		t.Fatalf("PodcastService.ExportOPML() error = %v", err)
+	}
+	want := `<outline text="Imported Podcast" title="Imported Podcast" type="rss" xmlUrl="` + server.URL + `/imported.rss" htmlUrl="https://example.com"></outline>`
+	if !strings.Contains(string(exported.Document), want) {
+		t.Errorf("PodcastService.ExportOPML() = %s, want it to contain %s", exported.Document, want)
+	}
+}
+
+func testPodcastService_Queue(t *testing.T, podClient protos.PodClient) {
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "token", "secret")
+	epiID := protos.ObjectIDFromHex("epi_id")
+	chapEpiID := protos.ObjectIDFromHex("chap_epi_id")
+
+	tests := []struct {
+		name    string
+		call    func() (*protos.Queue, error)
+		want    []*protos.ObjectID
+		wantErr bool
+	}{
+		{
+			name: "get_empty",
+			call: func() (*protos.Queue, error) { return podClient.GetQueue(ctx, &protos.Request{}) },
+			want: nil,
+		},
+		{
+			name: "enqueue",
+			call: func() (*protos.Queue, error) { return podClient.Enqueue(ctx, &protos.QueueReq{EpisodeID: epiID}) },
+			want: []*protos.ObjectID{epiID},
+		},
+		{
+			name: "enqueue_next",
+			call: func() (*protos.Queue, error) {
+				return podClient.Enqueue(ctx, &protos.QueueReq{EpisodeID: chapEpiID, Next: true})
+			},
+			want: []*protos.ObjectID{chapEpiID, epiID},
+		},
+		{
+			name: "enqueue_unknown",
+			call: func() (*protos.Queue, error) {
+				return podClient.Enqueue(ctx, &protos.QueueReq{EpisodeID: protos.ObjectIDFromHex("unknown_epi_id")})
+			},
+			wantErr: true,
+		},
+		{
+			name: "reorder",
+			call: func() (*protos.Queue, error) {
+				return podClient.ReorderQueue(ctx, &protos.QueueReq{EpisodeID: chapEpiID, Position: 5})
+			},
+			want: []*protos.ObjectID{epiID, chapEpiID},
+		},
+		{
+			name: "remove",
+			call: func() (*protos.Queue, error) {
+				return podClient.RemoveFromQueue(ctx, &protos.QueueReq{EpisodeID: chapEpiID})
+			},
+			want: []*protos.ObjectID{epiID},
+		},
+		{
+			name: "remove_not_queued",
+			call: func() (*protos.Queue, error) {
+				return podClient.RemoveFromQueue(ctx, &protos.QueueReq{EpisodeID: chapEpiID})
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.call()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PodcastService %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(got.Items) != len(tt.want) {
+				t.Fatalf("PodcastService %s = %v, want %v", tt.name, got.Items, tt.want)
+			}
+			for i := range tt.want {
+				if got.Items[i].EpisodeID.GetHex() != tt.want[i].GetHex() {
+					t.Errorf("PodcastService %s = %v, want %v", tt.name, got.Items, tt.want)
+				}
+			}
+		})
+	}
+
+	epi, err := podClient.PopQueue(ctx, &protos.Request{})
+	if err != nil || epi.Title != "Mock Episode" {
+		t.Errorf("PodcastService.PopQueue() = %v, error = %v", epi, err)
+	}
+	if _, err = podClient.PopQueue(ctx, &protos.Request{}); err == nil {
+		t.Errorf("PodcastService.PopQueue() empty queue error = nil")
+	}
+}
+
+func testPodcastService_Playlists(t *testing.T, podClient protos.PodClient) {
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "token", "secret")
+
+	_, err := podClient.CreatePlaylist(ctx, &protos.Playlist{Name: "Invalid", Query: "rating > 3"})
+	if err == nil {
+		t.Errorf("PodcastService.CreatePlaylist() invalid query error = nil")
+	}
+	created, err := podClient.CreatePlaylist(ctx, &protos.Playlist{Name: "Unplayed", Query: `played = false and podcast.author = "Sam Schwartz"`})
+	if err != nil {
+		t.Fatalf("PodcastService.CreatePlaylist() error = %v", err)
+	}
+	// the episodes are found by database operators the mock database doesn't support, so only invalid
+	// evaluations are tested here and the playlist package tests the filters and matching
+	got, err := podClient.EvaluatePlaylist(ctx, &protos.PlaylistReq{PlaylistID: created.Id, Start: 3, End: 1})
+	if err == nil {
+		t.Errorf("PodcastService.EvaluatePlaylist() invalid range = %v, error = nil", got)
+	}
+
+	updated, err := podClient.UpdatePlaylist(ctx, &protos.Playlist{Id: created.Id, Name: "Chaptered", Query: `title ~ chaptered`})
+	if err != nil || updated.Name != "Chaptered" {
+		t.Fatalf("PodcastService.UpdatePlaylist() = %v, error = %v", updated, err)
+	}
+	list, err := podClient.GetPlaylists(ctx, &protos.Request{})
+	if err != nil || len(list.Playlists) != 1 || list.Playlists[0].Name != "Chaptered" {
+		t.Errorf("PodcastService.GetPlaylists() = %v, error = %v", list, err)
+	}
+
+	// only the first delete succeeds
+	for i, wantSuccess := range []bool{true, false} {
+		res, err := podClient.DeletePlaylist(ctx, &protos.PlaylistReq{PlaylistID: created.Id})
+		if err != nil || res.Success != wantSuccess {
+			t.Errorf("PodcastService.DeletePlaylist() %d = %v, error %v, want success %v", i, res, err, wantSuccess)
+		}
+	}
+	if _, err = podClient.EvaluatePlaylist(ctx, &protos.PlaylistReq{PlaylistID: created.Id}); err == nil {
+		t.Errorf("PodcastService.EvaluatePlaylist() deleted playlist error = nil")
+	}
+}
+
+func testPodcastService_Sync(t *testing.T, podClient protos.PodClient) {
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "token", "secret")
+	all, err := podClient.Sync(ctx, &protos.SyncReq{})
+	if err != nil || len(all.Changes) == 0 || all.Cursor == "" {
+		t.Fatalf("PodcastService.Sync() = %v, error = %v", all, err)
+	}
+
+	_, err = podClient.UpdateUserEpisode(ctx, &protos.UserEpisodeReq{EpisodeID: protos.ObjectIDFromHex("epi_id"), Offset: 55555})
+	if err != nil {
+		t.Fatalf("PodcastService.UpdateUserEpisode() error = %v", err)
+	}
+	got, err := podClient.Sync(ctx, &protos.SyncReq{Cursor: all.Cursor})
+	if err != nil || len(got.Changes) != 1 || got.Changes[0].UserEpisode.GetOffset() != 55555 || got.Cursor == all.Cursor {
+		t.Errorf("PodcastService.Sync() = %v, error = %v", got, err)
+	}
+	if _, err = podClient.Sync(ctx, &protos.SyncReq{Cursor: "invalid!"}); err == nil {
+		t.Errorf("PodcastService.Sync() invalid cursor error = nil")
+	}
+}
+
+func testPodcastService_WatchPlayback(t *testing.T, podClient protos.PodClient) {
+	unauthorized, err := podClient.WatchPlayback(context.Background(), &protos.Request{})
+	if err == nil {
+		_, err = unauthorized.Recv()
+	}
+	if err == nil {
+		t.Errorf("PodcastService.WatchPlayback() without token error = nil")
+	}
+
+	ctx, cancel := context.WithCancel(metadata.AppendToOutgoingContext(context.Background(), "token", "secret"))
+	defer cancel()
+	// offsets mapped to the variant with a 30 second preroll
+	stream, err := podClient.WatchPlayback(ctx, &protos.Request{EpisodeID: protos.ObjectIDFromHex("epi_id"), EnclosureLength: 2000})
+	if err != nil {
+		t.Fatalf("PodcastService.WatchPlayback() error = %v", err)
+	}
+	received := make(chan *protos.UserEpisode)
+	go func() {
+		for {
+			userEpi, err := stream.Recv()
+			if err != nil {
+				close(received)
+				return
+			}
+			received <- userEpi
+		}
+	}()
+
+	// updated until the stream has started watching
+	timeout := time.After(5 * time.Second)
+	for {
+		_, err = podClient.UpdateUserEpisode(ctx, &protos.UserEpisodeReq{EpisodeID: protos.ObjectIDFromHex("epi_id"), Offset: 60000})
+		if err != nil {
+			t.Fatalf("PodcastService.UpdateUserEpisode() error = %v", err)
+		}
+		select {
+		case got := <-received:
+			if got.GetOffset() != 90000 {
+				t.Errorf("PodcastService.WatchPlayback() = %v, want offset 90000", got)
+			}
+			return
+		case <-time.After(10 * time.Millisecond):
+		case <-timeout:
+			t.Fatalf("PodcastService.WatchPlayback() received nothing")
+		}
+	}
+}
+
 func testPodcastService_GetUserLastPlayed(t *testing.T, podClient protos.PodClient) {
 	type args struct {
 		ctx context.Context
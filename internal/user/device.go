@@ -0,0 +1,144 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+const (
+	// maxDevices is the most devices a user can register
+	maxDevices = 50
+	// maxDeviceNameLength is the longest device name or platform accepted
+	maxDeviceNameLength = 100
+	// maxClockSkew is how far ahead of ours a client's clock is trusted,
+	// later timestamps are clamped so a wrong clock can't make its updates win forever
+	maxClockSkew = 5 * time.Minute
+)
+
+// Device & sync errors
+var (
+	ErrTooManyDevices = fmt.Errorf("can't register more than %d devices", maxDevices)
+	ErrInvalidDevice  = fmt.Errorf("device name and platform must be at most %d characters", maxDeviceNameLength)
+	ErrUnknownDevice  = errors.New("unknown device")
+)
+
+// syncMutex serializes the merging of playback updates so concurrent ones are compared against each other
+var syncMutex sync.Mutex
+
+// RegisterDevice registers the user's new device
+func RegisterDevice(dbClient db.Database, userID *protos.ObjectID, name, platform string) (*protos.Device, error) {
+	name, platform = strings.TrimSpace(name), strings.TrimSpace(platform)
+	if len(name) > maxDeviceNameLength || len(platform) > maxDeviceNameLength {
+		return nil, ErrInvalidDevice
+	}
+	devices, err := FindDevices(dbClient, userID)
+	if err != nil {
+		return nil, fmt.Errorf("RegisterDevice() error: %v", err)
+	}
+	if len(devices) >= maxDevices {
+		return nil, ErrTooManyDevices
+	}
+	device := &protos.Device{
+		Id:         protos.NewObjectID(),
+		UserID:     userID,
+		Name:       name,
+		Platform:   platform,
+		Registered: ptypes.TimestampNow(),
+		LastSeen:   ptypes.TimestampNow(),
+	}
+	err = dbClient.Insert(database.ColDevice, device)
+	if err != nil {
+		return nil, fmt.Errorf("RegisterDevice() error: %v", err)
+	}
+	return device, nil
+}
+
+// FindDevices returns the user's devices
+func FindDevices(dbClient db.Database, userID *protos.ObjectID) ([]*protos.Device, error) {
+	var devices []*protos.Device
+	err := dbClient.FindAll(database.ColDevice, &devices, &db.Filter{"userid": userID}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("FindDevices() error: %v", err)
+	}
+	return devices, nil
+}
+
+// FindDevice finds the user's device with the id
+func FindDevice(dbClient db.Database, id, userID *protos.ObjectID) (*protos.Device, error) {
+	device := &protos.Device{}
+	err := dbClient.FindOne(database.ColDevice, device, &db.Filter{"_id": id, "userid": userID}, nil)
+	if err != nil {
+		return nil, ErrUnknownDevice
+	}
+	return device, nil
+}
+
+// RemoveDevice deletes the user's device, its updates are no longer accepted
+func RemoveDevice(dbClient db.Database, id, userID *protos.ObjectID) error {
+	err := dbClient.Delete(database.ColDevice, &db.Filter{"_id": id, "userid": userID})
+	if err != nil {
+		return ErrUnknownDevice
+	}
+	return nil
+}
+
+// SyncUserEpisode merges the update of the user's playback into the stored one, the last writer wins
+// by the update's LastSeen timestamp. An update without a timestamp is stamped now and one with a device
+// must be from a registered device of the user. It returns the playback stored afterwards and whether
+// the update was applied, stale updates are not
+func SyncUserEpisode(dbClient db.Database, update *protos.UserEpisode) (*protos.UserEpisode, bool, error) {
+	if update.DeviceID != nil {
+		device, err := FindDevice(dbClient, update.DeviceID, update.UserID)
+		if err != nil {
+			return nil, false, err
+		}
+		device.LastSeen = ptypes.TimestampNow()
+		if err = dbClient.Update(database.ColDevice, device, &db.Filter{"_id": device.Id}); err != nil {
+			fmt.Println("SyncUserEpisode() error updating device last seen:", err)
+		}
+	}
+
+	now := time.Now()
+	lastSeen, err := ptypes.Timestamp(update.LastSeen)
+	if err != nil || lastSeen.After(now.Add(maxClockSkew)) {
+		update.LastSeen, _ = ptypes.TimestampProto(now)
+	}
+
+	syncMutex.Lock()
+	defer syncMutex.Unlock()
+	stored, err := FindUserEpisode(dbClient, update.UserID, update.EpisodeID)
+	if err == nil && !newer(update, stored) {
+		return stored, false, nil
+	}
+	if err == nil && update.PodcastID == nil {
+		update.PodcastID = stored.PodcastID
+	}
+	err = UpsertUserEpisode(dbClient, update)
+	if err != nil {
+		return nil, false, fmt.Errorf("SyncUserEpisode() error: %v", err)
+	}
+	return update, true, nil
+}
+
+// newer returns whether the update was made after the stored playback, ties are broken by device
+// so every server picks the same winner, and a device retrying its update is applied again
+func newer(update, stored *protos.UserEpisode) bool {
+	a, _ := ptypes.Timestamp(update.LastSeen)
+	b, _ := ptypes.Timestamp(stored.LastSeen)
+	if !a.Equal(b) {
+		return a.After(b)
+	}
+	if proto.Equal(update.DeviceID, stored.DeviceID) {
+		return true
+	}
+	return update.DeviceID.GetHex() > stored.DeviceID.GetHex()
+}
@@ -0,0 +1,98 @@
+package user
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sschwartz96/stockpile/mock"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/protos"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestDevices(t *testing.T) {
+	mockDB := mock.CreateDB()
+	userID, otherID := protos.NewObjectID(), protos.NewObjectID()
+	insertOrFail(t, mockDB, database.ColDevice, &protos.Device{Id: protos.NewObjectID(), UserID: otherID, Name: "Other"})
+
+	if _, err := RegisterDevice(mockDB, userID, strings.Repeat("a", maxDeviceNameLength+1), ""); err != ErrInvalidDevice {
+		t.Errorf("RegisterDevice() error = %v, want %v", err, ErrInvalidDevice)
+	}
+	device, err := RegisterDevice(mockDB, userID, " Phone ", "android")
+	if err != nil || device.Name != "Phone" || device.UserID != userID {
+		t.Fatalf("RegisterDevice() = %v, error = %v", device, err)
+	}
+	for i := 1; i < maxDevices; i++ {
+		if _, err = RegisterDevice(mockDB, userID, "Device", ""); err != nil {
+			t.Fatalf("RegisterDevice() error = %v", err)
+		}
+	}
+	if _, err = RegisterDevice(mockDB, userID, "Device", ""); err != ErrTooManyDevices {
+		t.Errorf("RegisterDevice() error = %v, want %v", err, ErrTooManyDevices)
+	}
+
+	// other users can't see or remove it
+	if _, err = FindDevice(mockDB, device.Id, otherID); err != ErrUnknownDevice {
+		t.Errorf("FindDevice() other user error = %v", err)
+	}
+	if err = RemoveDevice(mockDB, device.Id, otherID); err != ErrUnknownDevice {
+		t.Errorf("RemoveDevice() other user error = %v", err)
+	}
+	if err = RemoveDevice(mockDB, device.Id, userID); err != nil {
+		t.Errorf("RemoveDevice() error = %v", err)
+	}
+	if _, err = FindDevice(mockDB, device.Id, userID); err != ErrUnknownDevice {
+		t.Errorf("RemoveDevice() left the device: %v", err)
+	}
+}
+
+func TestSyncUserEpisode(t *testing.T) {
+	initial, mockDB := createMockDBUserEpi(t)
+	phone := &protos.Device{Id: protos.ObjectIDFromHex("device_a"), UserID: initial.UserID}
+	tablet := &protos.Device{Id: protos.ObjectIDFromHex("device_b"), UserID: initial.UserID}
+	insertOrFail(t, mockDB, database.ColDevice, phone)
+	insertOrFail(t, mockDB, database.ColDevice, tablet)
+
+	at := func(d time.Duration) *timestamppb.Timestamp {
+		return timestamppb.New(initial.LastSeen.AsTime().Add(d))
+	}
+	update := func(offset int64, lastSeen *timestamppb.Timestamp, device *protos.ObjectID) *protos.UserEpisode {
+		return &protos.UserEpisode{UserID: initial.UserID, EpisodeID: initial.EpisodeID,
+			Offset: offset, LastSeen: lastSeen, DeviceID: device}
+	}
+	tests := []struct {
+		name        string
+		update      *protos.UserEpisode
+		wantApplied bool
+		wantOffset  int64
+		wantErr     bool
+	}{
+		{name: "stale", update: update(1, at(-time.Second), phone.Id), wantApplied: false, wantOffset: 123456},
+		{name: "newer", update: update(2, at(time.Second), tablet.Id), wantApplied: true, wantOffset: 2},
+		{name: "tie_lower_device", update: update(3, at(time.Second), phone.Id), wantApplied: false, wantOffset: 2},
+		{name: "tie_same_device", update: update(4, at(time.Second), tablet.Id), wantApplied: true, wantOffset: 4},
+		{name: "unknown_device", update: update(5, at(time.Minute), protos.NewObjectID()), wantErr: true},
+		// clamped to now, which is before the stored update
+		{name: "future_clock", update: update(6, at(time.Hour), phone.Id), wantApplied: false, wantOffset: 4},
+		{name: "within_clock_skew", update: update(7, at(time.Minute), phone.Id), wantApplied: true, wantOffset: 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, applied, err := SyncUserEpisode(mockDB, tt.update)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SyncUserEpisode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if applied != tt.wantApplied || got.Offset != tt.wantOffset {
+				t.Errorf("SyncUserEpisode() = %v, applied %v, want offset %v, applied %v", got, applied, tt.wantOffset, tt.wantApplied)
+			}
+			stored, err := FindUserEpisode(mockDB, initial.UserID, initial.EpisodeID)
+			if err != nil || stored.Offset != tt.wantOffset || stored.Id.GetHex() != initial.Id.GetHex() {
+				t.Errorf("SyncUserEpisode() stored %v, error = %v", stored, err)
+			}
+		})
+	}
+}
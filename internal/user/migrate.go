@@ -0,0 +1,67 @@
+package user
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// userPage is how many users are found at once migrating them
+const userPage = 1000
+
+// NormalizeEmails is a one-off migration lowercasing the emails of the users created before emails were stored
+// lowercased, which FindUser couldn't find. Of the users sharing an email the one who verified it, otherwise the
+// oldest, keeps it and the others' are cleared so it can be uniquely indexed, they're logged to be contacted by username.
+// returns the number of emails cleared
+func NormalizeEmails(dbClient db.Database) (int, error) {
+	var users []*protos.User
+	for start := int64(0); ; start += userPage {
+		var page []*protos.User
+		opts := db.CreateOptions().SetSort("_id", 1).SetSkip(start).SetLimit(userPage)
+		err := dbClient.FindAll(database.ColUser, &page, &db.Filter{}, opts)
+		if err != nil {
+			return 0, fmt.Errorf("NormalizeEmails() error finding users: %v", err)
+		}
+		users = append(users, page...)
+		if len(page) < userPage {
+			break
+		}
+	}
+
+	// the users are in the order they were created, so the first of each email is the oldest
+	owners := map[string]*protos.User{}
+	for _, u := range users {
+		email := strings.ToLower(strings.TrimSpace(u.Email))
+		if email == "" {
+			continue
+		}
+		owner, ok := owners[email]
+		if !ok || (u.EmailVerified && !owner.EmailVerified) {
+			owners[email] = u
+		}
+	}
+
+	cleared := 0
+	for _, u := range users {
+		email := strings.ToLower(strings.TrimSpace(u.Email))
+		if owner, ok := owners[email]; ok && owner != u {
+			log.Printf("NormalizeEmails() clearing the email of %s, %s is %s's\n", u.Username, email, owner.Username)
+			email = ""
+			u.EmailVerified = false
+			cleared++
+		}
+		if email == u.Email {
+			continue
+		}
+		u.Email = email
+		err := UpdateUser(dbClient, u)
+		if err != nil {
+			return cleared, fmt.Errorf("NormalizeEmails() error updating %s: %v", u.Username, err)
+		}
+	}
+	return cleared, nil
+}
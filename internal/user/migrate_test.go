@@ -0,0 +1,49 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/sschwartz96/stockpile/mock"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+func TestNormalizeEmails(t *testing.T) {
+	mockDB := mock.CreateDB()
+	// shared is registered by oldShared first, but verified by verifiedShared
+	users := []*protos.User{
+		{Id: protos.ObjectIDFromHex("user1"), Username: "mixed", Email: " Mixed@Example.com"},
+		{Id: protos.ObjectIDFromHex("user2"), Username: "lower", Email: "lower@example.com", EmailVerified: true},
+		{Id: protos.ObjectIDFromHex("user3"), Username: "none"},
+		{Id: protos.ObjectIDFromHex("user4"), Username: "oldShared", Email: "Shared@example.com"},
+		{Id: protos.ObjectIDFromHex("user5"), Username: "verifiedShared", Email: "shared@example.com", EmailVerified: true},
+		{Id: protos.ObjectIDFromHex("user6"), Username: "newShared", Email: "SHARED@example.com", EmailVerified: true},
+		{Id: protos.ObjectIDFromHex("user7"), Username: "firstDup", Email: "dup@example.com"},
+		{Id: protos.ObjectIDFromHex("user8"), Username: "secondDup", Email: "dup@example.com"},
+	}
+	for _, u := range users {
+		insertOrFail(t, mockDB, database.ColUser, u)
+	}
+
+	cleared, err := NormalizeEmails(mockDB)
+	if err != nil || cleared != 3 {
+		t.Fatalf("NormalizeEmails() = %d, error = %v, want 3", cleared, err)
+	}
+	want := map[string]string{
+		"mixed": "mixed@example.com", "lower": "lower@example.com", "none": "",
+		"oldShared": "", "verifiedShared": "shared@example.com", "newShared": "",
+		"firstDup": "dup@example.com", "secondDup": "",
+	}
+	for _, u := range users {
+		stored, err := FindUserByID(mockDB, u.Id)
+		if err != nil {
+			t.Fatalf("FindUserByID() error = %v", err)
+		}
+		if stored.Email != want[u.Username] || (stored.Email == "" && stored.EmailVerified) {
+			t.Errorf("NormalizeEmails() %s email = %q, verified %v, want %q", u.Username, stored.Email, stored.EmailVerified, want[u.Username])
+		}
+	}
+	if found, err := FindUser(mockDB, "Mixed@Example.com"); err != nil || found.Username != "mixed" {
+		t.Errorf("FindUser() = %v, error = %v", found, err)
+	}
+}
@@ -0,0 +1,128 @@
+package user
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sschwartz96/stockpile/db"
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+// ErrInvalidCursor is returned syncing with a cursor that wasn't returned by a sync
+var ErrInvalidCursor = errors.New("invalid sync cursor")
+
+// changeMutex serializes the recording of changes so each gets the next seq
+var changeMutex sync.Mutex
+
+// Sync returns the user's changes since the cursor and the cursor of the next sync. If the cursor is empty
+// it returns all of the user's subscriptions and playbacks, including those from before the change log
+func Sync(dbClient db.Database, userID *protos.ObjectID, cursor string) ([]*protos.Change, string, error) {
+	if cursor == "" {
+		return snapshot(dbClient, userID)
+	}
+	after, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	var logged []*protos.Change
+	err = dbClient.FindAll(database.ColChange, &logged, &db.Filter{"userid": userID}, db.CreateOptions().SetSort("seq", 1))
+	if err != nil {
+		return nil, "", fmt.Errorf("Sync() error: %v", err)
+	}
+	// the log holds one change per subscription & playback, so it's filtered by seq after finding
+	changes := []*protos.Change{}
+	last := after
+	for _, change := range logged {
+		if change.Seq > after {
+			changes = append(changes, change)
+			last = change.Seq
+		}
+	}
+	return changes, encodeCursor(last), nil
+}
+
+// snapshot returns a change of each of the user's subscriptions and playbacks, and the cursor of the
+// latest logged change. The cursor is read first so changes made meanwhile are synced again next time
+func snapshot(dbClient db.Database, userID *protos.ObjectID) ([]*protos.Change, string, error) {
+	seq := latestSeq(dbClient, userID)
+	subs, err := FindSubscriptions(dbClient, userID)
+	if err != nil {
+		return nil, "", fmt.Errorf("snapshot() error: %v", err)
+	}
+	var userEpis []*protos.UserEpisode
+	err = dbClient.FindAll(database.ColUserEpisode, &userEpis, &db.Filter{"userid": userID}, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("snapshot() error finding user episodes: %v", err)
+	}
+	changes := []*protos.Change{}
+	for _, sub := range subs {
+		changes = append(changes, &protos.Change{UserID: userID, Key: subscriptionKey(sub.PodcastID), Subscription: sub})
+	}
+	for _, userEpi := range userEpis {
+		changes = append(changes, &protos.Change{UserID: userID, Key: userEpisodeKey(userEpi.EpisodeID), UserEpisode: userEpi})
+	}
+	return changes, encodeCursor(seq), nil
+}
+
+// recordChange logs the change of the user's subscription or playback with the next seq,
+// replacing the previously logged change of it
+func recordChange(dbClient db.Database, userID *protos.ObjectID, change *protos.Change) error {
+	changeMutex.Lock()
+	defer changeMutex.Unlock()
+	change.UserID = userID
+	change.Seq = latestSeq(dbClient, userID) + 1
+	change.Changed = ptypes.TimestampNow()
+	filter := &db.Filter{"userid": userID, "key": change.Key}
+	// finding fails if the change was never logged
+	existing := &protos.Change{}
+	if err := dbClient.FindOne(database.ColChange, existing, filter, nil); err == nil {
+		change.Id = existing.Id
+	} else {
+		change.Id = protos.NewObjectID()
+	}
+	err := dbClient.Upsert(database.ColChange, change, filter)
+	if err != nil {
+		return fmt.Errorf("recordChange() error: %v", err)
+	}
+	return nil
+}
+
+// latestSeq returns the seq of the user's latest change, 0 if none was logged, which finding fails on
+func latestSeq(dbClient db.Database, userID *protos.ObjectID) int64 {
+	change := &protos.Change{}
+	opts := db.CreateOptions().SetSort("seq", -1)
+	if err := dbClient.FindOne(database.ColChange, change, &db.Filter{"userid": userID}, opts); err != nil {
+		return 0
+	}
+	return change.Seq
+}
+
+func subscriptionKey(podID *protos.ObjectID) string {
+	return "subscription:" + podID.GetHex()
+}
+
+func userEpisodeKey(epiID *protos.ObjectID) string {
+	return "episode:" + epiID.GetHex()
+}
+
+// encodeCursor encodes the seq so clients treat the cursor as opaque
+func encodeCursor(seq int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(seq, 10)))
+}
+
+func decodeCursor(cursor string) (int64, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+	seq, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil || seq < 0 {
+		return 0, ErrInvalidCursor
+	}
+	return seq, nil
+}
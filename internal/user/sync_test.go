@@ -0,0 +1,70 @@
+package user
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sschwartz96/syncapod/internal/database"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+func changeKeys(changes []*protos.Change) []string {
+	keys := []string{}
+	for _, change := range changes {
+		keys = append(keys, change.Key)
+	}
+	return keys
+}
+
+func TestSync(t *testing.T) {
+	initial, mockDB := createMockDBUserEpi(t)
+	userID := initial.UserID
+	podA, podB, epi := protos.ObjectIDFromHex("pod_a"), protos.ObjectIDFromHex("pod_b"), protos.ObjectIDFromHex("epi_a")
+	// stored before the change log
+	insertOrFail(t, mockDB, database.ColSubscription, &protos.Subscription{Id: protos.NewObjectID(), UserID: userID, PodcastID: podA})
+	// another user's change, logging the first change stores the change log
+	if _, err := Subscribe(mockDB, protos.NewObjectID(), podA); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	changes, cursor, err := Sync(mockDB, userID, "")
+	want := []string{"subscription:pod_a", "episode:epi_id1"}
+	if err != nil || !reflect.DeepEqual(changeKeys(changes), want) {
+		t.Fatalf("Sync() snapshot = %v, error = %v, want %v", changeKeys(changes), err, want)
+	}
+	if changes, _, err = Sync(mockDB, userID, cursor); err != nil || len(changes) != 0 {
+		t.Errorf("Sync() without changes = %v, error = %v", changeKeys(changes), err)
+	}
+
+	// the second offset update replaces the first, the unsubscribe replaces the subscribe
+	if _, err = Subscribe(mockDB, userID, podB); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	if err = UpdateOffset(mockDB, userID, podA, epi, 1000); err != nil {
+		t.Fatalf("UpdateOffset() error = %v", err)
+	}
+	if err = Unsubscribe(mockDB, userID, podB); err != nil {
+		t.Fatalf("Unsubscribe() error = %v", err)
+	}
+	if err = UpdateOffset(mockDB, userID, podA, epi, 2000); err != nil {
+		t.Fatalf("UpdateOffset() error = %v", err)
+	}
+
+	changes, next, err := Sync(mockDB, userID, cursor)
+	want = []string{"subscription:pod_b", "episode:epi_a"}
+	if err != nil || !reflect.DeepEqual(changeKeys(changes), want) {
+		t.Fatalf("Sync() = %v, error = %v, want %v", changeKeys(changes), err, want)
+	}
+	if changes[0].UnsubscribedID.GetHex() != "pod_b" || changes[0].Subscription != nil || changes[1].UserEpisode.Offset != 2000 {
+		t.Errorf("Sync() = %v", changes)
+	}
+	if changes, _, err = Sync(mockDB, userID, next); err != nil || len(changes) != 0 {
+		t.Errorf("Sync() after the latest change = %v, error = %v", changeKeys(changes), err)
+	}
+
+	for _, invalid := range []string{"not base64!", encodeCursor(-1), "YWJj"} {
+		if _, _, err = Sync(mockDB, userID, invalid); err != ErrInvalidCursor {
+			t.Errorf("Sync(%q) error = %v, want %v", invalid, err, ErrInvalidCursor)
+		}
+	}
+}
@@ -44,6 +44,21 @@ func DeleteSessionByKey(dbClient db.Database, key string) error {
 	return nil
 }
 
+// DeleteUserSessions deletes every session of the user, logging them out everywhere
+func DeleteUserSessions(dbClient db.Database, userID *protos.ObjectID) error {
+	var sessions []*protos.Session
+	err := dbClient.FindAll(database.ColSession, &sessions, &db.Filter{"userid": userID}, nil)
+	if err != nil {
+		return fmt.Errorf("error finding user sessions: %v", err)
+	}
+	for _, session := range sessions {
+		if err = DeleteSession(dbClient, session.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func FindUserByID(dbClient db.Database, id *protos.ObjectID) (*protos.User, error) {
 	user := &protos.User{}
 	err := dbClient.FindOne(database.ColUser, user, &db.Filter{"_id": id}, nil)
@@ -75,6 +90,22 @@ func FindUser(dbClient db.Database, username string) (*protos.User, error) {
 	return user, nil
 }
 
+// InsertUser inserts a new user
+func InsertUser(dbClient db.Database, user *protos.User) error {
+	if err := dbClient.Insert(database.ColUser, user); err != nil {
+		return fmt.Errorf("error inserting user: %v", err)
+	}
+	return nil
+}
+
+// UpdateUser replaces the stored user with the same id
+func UpdateUser(dbClient db.Database, user *protos.User) error {
+	if err := dbClient.Update(database.ColUser, user, &db.Filter{"_id": user.Id}); err != nil {
+		return fmt.Errorf("error updating user: %v", err)
+	}
+	return nil
+}
+
 func DeleteUser(dbClient db.Database, id *protos.ObjectID) error {
 	if err := dbClient.Delete(database.ColUser, &db.Filter{"_id": id}); err != nil {
 		return fmt.Errorf("error deleting user: %v", err)
@@ -108,16 +139,25 @@ func FindLatestUserEpisode(dbClient db.Database, userID *protos.ObjectID) (*prot
 	return userEpi, nil
 }
 
+// UpsertUserEpisode stores the user's playback of the episode, replacing the one they have
+// and keeping its id, and publishes it to the user's watchers. LastSeen is set to now if it isn't set
 func UpsertUserEpisode(dbClient db.Database, userEpisode *protos.UserEpisode) error {
-	if userEpisode.Id == nil {
+	if existing, err := FindUserEpisode(dbClient, userEpisode.UserID, userEpisode.EpisodeID); err == nil {
+		userEpisode.Id = existing.Id
+	} else if userEpisode.Id == nil {
 		userEpisode.Id = protos.NewObjectID()
 	}
-	userEpisode.LastSeen = ptypes.TimestampNow()
-	err := dbClient.Upsert(database.ColUserEpisode, userEpisode, &db.Filter{"_id": userEpisode.Id})
+	if userEpisode.LastSeen == nil {
+		userEpisode.LastSeen = ptypes.TimestampNow()
+	}
+	filter := &db.Filter{"userid": userEpisode.UserID, "episodeid": userEpisode.EpisodeID}
+	err := dbClient.Upsert(database.ColUserEpisode, userEpisode, filter)
 	if err != nil {
 		return fmt.Errorf("error upserting user episode: %v", err)
 	}
-	return nil
+	publishPlayback(userEpisode)
+	return recordChange(dbClient, userEpisode.UserID,
+		&protos.Change{Key: userEpisodeKey(userEpisode.EpisodeID), UserEpisode: userEpisode})
 }
 
 // Subscriptions
@@ -136,7 +176,50 @@ func UpsertSubscription(dbClient db.Database, subscription *protos.Subscription)
 	if err != nil {
 		return fmt.Errorf("error upserting subscription: %v", err)
 	}
-	return nil
+	return recordChange(dbClient, subscription.UserID,
+		&protos.Change{Key: subscriptionKey(subscription.PodcastID), Subscription: subscription})
+}
+
+// FindSubscription finds the user's subscription to the podcast
+func FindSubscription(dbClient db.Database, userID, podID *protos.ObjectID) (*protos.Subscription, error) {
+	sub := &protos.Subscription{}
+	err := dbClient.FindOne(database.ColSubscription, sub, &db.Filter{"userid": userID, "podcastid": podID}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("FindSubscription() error: %v", err)
+	}
+	return sub, nil
+}
+
+// Subscribe subscribes the user to the podcast, returning the existing subscription if already subscribed
+func Subscribe(dbClient db.Database, userID, podID *protos.ObjectID) (*protos.Subscription, error) {
+	if sub, err := FindSubscription(dbClient, userID, podID); err == nil {
+		return sub, nil
+	}
+	sub := &protos.Subscription{
+		Id:         protos.NewObjectID(),
+		UserID:     userID,
+		PodcastID:  podID,
+		Settings:   &protos.SubscriptionSettings{},
+		Subscribed: ptypes.TimestampNow(),
+	}
+	err := dbClient.Insert(database.ColSubscription, sub)
+	if err != nil {
+		return nil, fmt.Errorf("Subscribe() error: %v", err)
+	}
+	err = recordChange(dbClient, userID, &protos.Change{Key: subscriptionKey(podID), Subscription: sub})
+	if err != nil {
+		return nil, fmt.Errorf("Subscribe() error: %v", err)
+	}
+	return sub, nil
+}
+
+// Unsubscribe deletes the user's subscription to the podcast
+func Unsubscribe(dbClient db.Database, userID, podID *protos.ObjectID) error {
+	err := dbClient.Delete(database.ColSubscription, &db.Filter{"userid": userID, "podcastid": podID})
+	if err != nil {
+		return fmt.Errorf("Unsubscribe() error: %v", err)
+	}
+	return recordChange(dbClient, userID, &protos.Change{Key: subscriptionKey(podID), UnsubscribedID: podID})
 }
 
 // helpers
@@ -206,7 +289,8 @@ func UpdateOffset(dbClient db.Database, uID, pID, eID *protos.ObjectID, offset i
 		Played:    false,
 		LastSeen:  ptypes.TimestampNow(),
 	}
-	return UpsertUserEpisode(dbClient, userEpi)
+	_, _, err := SyncUserEpisode(dbClient, userEpi)
+	return err
 }
 
 func UpdateUserEpiPlayed(dbClient db.Database, uID, pID, eID *protos.ObjectID, played bool) error {
@@ -218,5 +302,6 @@ func UpdateUserEpiPlayed(dbClient db.Database, uID, pID, eID *protos.ObjectID, p
 		Played:    played,
 		LastSeen:  ptypes.TimestampNow(),
 	}
-	return UpsertUserEpisode(dbClient, userEpi)
+	_, _, err := SyncUserEpisode(dbClient, userEpi)
+	return err
 }
@@ -0,0 +1,76 @@
+package user
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+const (
+	// maxWatchers is the most playback watchers a user can have at once
+	maxWatchers = 20
+	// watchBuffer is how many updates are buffered for a watcher before the oldest is dropped
+	watchBuffer = 16
+)
+
+// ErrTooManyWatchers is returned watching the playback of a user with too many watchers
+var ErrTooManyWatchers = fmt.Errorf("can't watch playback from more than %d clients", maxWatchers)
+
+// hub publishes the users' playback updates to their watchers, only within this process
+type hub struct {
+	sync.Mutex
+	watchers map[string]map[chan *protos.UserEpisode]struct{}
+}
+
+var playbackHub = &hub{watchers: map[string]map[chan *protos.UserEpisode]struct{}{}}
+
+// WatchPlayback returns a channel of the user's playback updates as they're stored,
+// and the func to stop watching which must be called once done
+func WatchPlayback(userID *protos.ObjectID) (<-chan *protos.UserEpisode, func(), error) {
+	playbackHub.Lock()
+	defer playbackHub.Unlock()
+	watchers := playbackHub.watchers[userID.GetHex()]
+	if len(watchers) >= maxWatchers {
+		return nil, nil, ErrTooManyWatchers
+	}
+	if watchers == nil {
+		watchers = map[chan *protos.UserEpisode]struct{}{}
+		playbackHub.watchers[userID.GetHex()] = watchers
+	}
+	ch := make(chan *protos.UserEpisode, watchBuffer)
+	watchers[ch] = struct{}{}
+
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			playbackHub.Lock()
+			defer playbackHub.Unlock()
+			delete(watchers, ch)
+			if len(watchers) == 0 {
+				delete(playbackHub.watchers, userID.GetHex())
+			}
+		})
+	}
+	return ch, stop, nil
+}
+
+// publishPlayback sends the update to the user's watchers without blocking, a watcher
+// too slow to keep up misses its oldest update rather than the latest
+func publishPlayback(userEpisode *protos.UserEpisode) {
+	playbackHub.Lock()
+	defer playbackHub.Unlock()
+	for ch := range playbackHub.watchers[userEpisode.UserID.GetHex()] {
+		update := proto.Clone(userEpisode).(*protos.UserEpisode)
+		select {
+		case ch <- update:
+		default:
+			select {
+			case <-ch:
+			default:
+			}
+			ch <- update
+		}
+	}
+}
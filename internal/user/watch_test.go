@@ -0,0 +1,67 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/sschwartz96/syncapod/internal/protos"
+)
+
+func TestWatchPlayback(t *testing.T) {
+	initial, mockDB := createMockDBUserEpi(t)
+	updates, stop, err := WatchPlayback(initial.UserID)
+	if err != nil {
+		t.Fatalf("WatchPlayback() error = %v", err)
+	}
+	other, stopOther, err := WatchPlayback(protos.ObjectIDFromHex("user_id2"))
+	if err != nil {
+		t.Fatalf("WatchPlayback() error = %v", err)
+	}
+	defer stopOther()
+
+	if err = UpdateOffset(mockDB, initial.UserID, initial.PodcastID, initial.EpisodeID, 5000); err != nil {
+		t.Fatalf("UpdateOffset() error = %v", err)
+	}
+	select {
+	case got := <-updates:
+		if got.EpisodeID.GetHex() != initial.EpisodeID.GetHex() || got.Offset != 5000 {
+			t.Errorf("WatchPlayback() = %v", got)
+		}
+	default:
+		t.Errorf("WatchPlayback() received nothing")
+	}
+	select {
+	case got := <-other:
+		t.Errorf("WatchPlayback() other user received %v", got)
+	default:
+	}
+
+	// a watcher too slow to keep up gets the latest updates
+	for i := 1; i <= watchBuffer+5; i++ {
+		publishPlayback(&protos.UserEpisode{UserID: initial.UserID, Offset: int64(i)})
+	}
+	var last int64
+	for len(updates) > 0 {
+		last = (<-updates).Offset
+	}
+	if last != watchBuffer+5 {
+		t.Errorf("WatchPlayback() last update = %v, want %v", last, watchBuffer+5)
+	}
+
+	stop()
+	stop()
+	publishPlayback(&protos.UserEpisode{UserID: initial.UserID})
+	if len(updates) != 0 {
+		t.Errorf("WatchPlayback() received after stopping")
+	}
+
+	for i := 0; i < maxWatchers; i++ {
+		_, stop, err := WatchPlayback(initial.UserID)
+		if err != nil {
+			t.Fatalf("WatchPlayback() error = %v", err)
+		}
+		defer stop()
+	}
+	if _, _, err = WatchPlayback(initial.UserID); err != ErrTooManyWatchers {
+		t.Errorf("WatchPlayback() error = %v, want %v", err, ErrTooManyWatchers)
+	}
+}